@@ -0,0 +1,813 @@
+// business.go
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+/* ----- data types ----- */
+
+// clockWindow is a daily local window expressed as offsets from midnight.
+type clockWindow struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+type BusinessAddResult struct {
+	Start           TimeResult `json:"start"`
+	Result          TimeResult `json:"result"`
+	BusinessHours   float64    `json:"business_hours"`
+	CalendarElapsed string     `json:"calendar_elapsed"`
+}
+
+type DayWork struct {
+	Date  string  `json:"date"`
+	Hours float64 `json:"hours"`
+}
+
+type WorkHoursSplitResult struct {
+	Start            TimeResult `json:"start"`
+	End              TimeResult `json:"end"`
+	Windows          []string   `json:"windows"`
+	BusinessHours    float64    `json:"business_hours"`
+	BusinessDuration string     `json:"business_duration"`
+	Days             []DayWork  `json:"days"`
+}
+
+type BusinessWeekResult struct {
+	Timezone      string     `json:"timezone"`
+	WeekStart     TimeResult `json:"week_start"`
+	WeekEnd       TimeResult `json:"week_end"`
+	BusinessStart TimeResult `json:"business_start"`
+	BusinessEnd   TimeResult `json:"business_end"`
+	BusinessDays  []string   `json:"business_days"`
+	IsBusinessDay bool       `json:"is_business_day"`
+}
+
+type MonthEndResult struct {
+	Date              string   `json:"date"`
+	Timezone          string   `json:"timezone"`
+	MonthEnd          string   `json:"month_end"`
+	LastBusinessDay   string   `json:"last_business_day,omitempty"`
+	BusinessDays      int      `json:"business_days"`
+	IncludesToday     bool     `json:"includes_today"`
+	IsLastBusinessDay bool     `json:"is_last_business_day"`
+	Remaining         []string `json:"remaining"`
+}
+
+type BusinessDayProgressResult struct {
+	Timezone    string      `json:"timezone"`
+	Now         TimeResult  `json:"now"`
+	Status      string      `json:"status"` // "before", "in_progress", "after" or "no_business_day"
+	Fraction    float64     `json:"fraction"`
+	Percent     float64     `json:"percent"`
+	WindowStart *TimeResult `json:"window_start,omitempty"`
+	WindowEnd   *TimeResult `json:"window_end,omitempty"`
+	Elapsed     string      `json:"elapsed,omitempty"`
+	Remaining   string      `json:"remaining,omitempty"`
+}
+
+type ObservedDay struct {
+	Name     string `json:"name"`
+	Actual   string `json:"actual"`
+	Observed string `json:"observed"`
+}
+
+type ObservedHolidayResult struct {
+	Date       string        `json:"date"`
+	Country    string        `json:"country"`
+	Policy     string        `json:"policy"`
+	IsHoliday  bool          `json:"is_holiday"` // a public holiday is observed on date
+	Substitute bool          `json:"substitute"` // ... in place of one that fell elsewhere
+	Observed   []ObservedDay `json:"observed,omitempty"`
+	MovedAway  []ObservedDay `json:"moved_away,omitempty"` // holidays falling on date but observed elsewhere
+}
+
+const defaultBusinessWindow = "09:00-17:00"
+
+/* ----- helpers ----- */
+
+// parseClock parses a local HH:MM or HH:MM:SS time of day. "24:00" is
+// accepted so that windows may run to the end of the day.
+func parseClock(s string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("time of day must be HH:MM: %s", s)
+	}
+	h, errH := atoiStrict(parts[0])
+	m, errM := atoiStrict(parts[1])
+	sec := 0
+	var errS error
+	if len(parts) == 3 {
+		sec, errS = atoiStrict(parts[2])
+	}
+	if errH != nil || errM != nil || errS != nil || h < 0 || h > 24 || m < 0 || m > 59 || sec < 0 || sec > 59 {
+		return 0, fmt.Errorf("invalid time of day: %s", s)
+	}
+	d := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(sec)*time.Second
+	if d > day {
+		return 0, fmt.Errorf("invalid time of day: %s", s)
+	}
+	return d, nil
+}
+
+// parseClockWindow parses "HH:MM-HH:MM". Unless allowWrap is set the window
+// must end after it starts.
+func parseClockWindow(s string, allowWrap bool) (clockWindow, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return clockWindow{}, fmt.Errorf("window must be HH:MM-HH:MM: %s", s)
+	}
+	start, err := parseClock(parts[0])
+	if err != nil {
+		return clockWindow{}, err
+	}
+	end, err := parseClock(parts[1])
+	if err != nil {
+		return clockWindow{}, err
+	}
+	if start == end || (!allowWrap && end < start) {
+		return clockWindow{}, fmt.Errorf("window must end after it starts: %s", s)
+	}
+	return clockWindow{Start: start, End: end}, nil
+}
+
+// on returns the window's bounds on the local calendar date of d. Bounds are
+// built with time.Date so they keep their wall-clock meaning across DST. A
+// window that wraps past midnight (22:00-02:00) ends on the following date.
+func (w clockWindow) on(d time.Time) (time.Time, time.Time) {
+	if w.End < w.Start {
+		return atClock(d, w.Start), atClock(d.AddDate(0, 0, 1), w.End)
+	}
+	return atClock(d, w.Start), atClock(d, w.End)
+}
+
+// atClock returns the instant at offset c from local midnight on d's date.
+func atClock(d time.Time, c time.Duration) time.Time {
+	c = c.Round(time.Second)
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, int(c/time.Second), 0, d.Location())
+}
+
+// startOfDay returns local midnight on d's date.
+func startOfDay(d time.Time) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, d.Location())
+}
+
+// parseHolidays turns a list of YYYY-MM-DD strings into a lookup set.
+func parseHolidays(list []string) (map[string]bool, error) {
+	set := make(map[string]bool, len(list))
+	for _, h := range list {
+		d, err := time.Parse("2006-01-02", strings.TrimSpace(h))
+		if err != nil {
+			return nil, fmt.Errorf("holiday must be YYYY-MM-DD: %s", h)
+		}
+		set[d.Format("2006-01-02")] = true
+	}
+	return set, nil
+}
+
+// isBusinessDay reports whether d's local date is a weekday and not a holiday.
+func isBusinessDay(d time.Time, holidays map[string]bool) bool {
+	if wd := d.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	return !holidays[d.Format("2006-01-02")]
+}
+
+// defaultWorkweek is the Monday-Friday business week.
+var defaultWorkweek = []string{"monday", "tuesday", "wednesday", "thursday", "friday"}
+
+// parseWeekdaySet turns a list of day names or ISO numbers into a set.
+func parseWeekdaySet(list []string) (map[time.Weekday]bool, error) {
+	set := make(map[time.Weekday]bool, len(list))
+	for _, s := range list {
+		wd, err := parseWeekday(s)
+		if err != nil {
+			return nil, err
+		}
+		set[wd] = true
+	}
+	return set, nil
+}
+
+// maxBusinessScanDays bounds forward scans so a window that never opens (for
+// example a holiday list covering every weekday) cannot loop forever.
+const maxBusinessScanDays = 3660
+
+// parseClockWindows parses a list of same-day windows, sorts them by start
+// and rejects overlaps so time is never counted twice.
+func parseClockWindows(list []string) ([]clockWindow, error) {
+	if len(list) == 0 {
+		list = []string{defaultBusinessWindow}
+	}
+	ws := make([]clockWindow, 0, len(list))
+	for _, s := range list {
+		w, err := parseClockWindow(s, false)
+		if err != nil {
+			return nil, err
+		}
+		ws = append(ws, w)
+	}
+	sort.Slice(ws, func(i, j int) bool { return ws[i].Start < ws[j].Start })
+	for i := 1; i < len(ws); i++ {
+		if ws[i].Start < ws[i-1].End {
+			return nil, fmt.Errorf("windows overlap: %s-%s and %s-%s", formatClock(ws[i-1].Start), formatClock(ws[i-1].End), formatClock(ws[i].Start), formatClock(ws[i].End))
+		}
+	}
+	return ws, nil
+}
+
+/* ----- core methods ----- */
+
+// BusinessAddHours advances start by the given number of working hours,
+// counting only time inside the daily window on business days. When a day's
+// window is exhausted the remainder carries over to the next business day.
+func (t *TimeServer) BusinessAddHours(start string, hours float64, window, tz string, holidays []string, format string) (BusinessAddResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return BusinessAddResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return BusinessAddResult{}, err
+	}
+	if hours < 0 || math.IsNaN(hours) || math.IsInf(hours, 0) {
+		return BusinessAddResult{}, fmt.Errorf("hours must be a non-negative number")
+	}
+	if window == "" {
+		window = defaultBusinessWindow
+	}
+	w, err := parseClockWindow(window, false)
+	if err != nil {
+		return BusinessAddResult{}, err
+	}
+	hs, err := parseHolidays(holidays)
+	if err != nil {
+		return BusinessAddResult{}, err
+	}
+	begin, err := t.parseDateTime(start, loc)
+	if err != nil {
+		return BusinessAddResult{}, err
+	}
+
+	remaining := time.Duration(hours * float64(time.Hour))
+	cur := begin
+	for i := 0; i < maxBusinessScanDays; i++ {
+		if isBusinessDay(cur, hs) {
+			opens, closes := w.on(cur)
+			if cur.Before(opens) {
+				cur = opens
+			}
+			if cur.Before(closes) {
+				avail := closes.Sub(cur)
+				if remaining <= avail {
+					end := cur.Add(remaining)
+					return BusinessAddResult{
+						Start:           makeTimeResult(tz, begin),
+						Result:          makeTimeResult(tz, end),
+						BusinessHours:   hours,
+						CalendarElapsed: render(end.Sub(begin)),
+					}, nil
+				}
+				remaining -= avail
+			}
+		}
+		cur = startOfDay(cur).AddDate(0, 0, 1)
+	}
+	return BusinessAddResult{}, fmt.Errorf("no business time found within %d days", maxBusinessScanDays)
+}
+
+// WorkHoursSplit sums the time between start and end that falls inside any
+// of several disjoint daily windows (a split shift such as 09:00-12:00 and
+// 13:00-17:00) on business days. The first and last days are clipped to the
+// interval, and the per-date totals are listed.
+func (t *TimeServer) WorkHoursSplit(start, end string, windows []string, tz string, holidays []string, format string) (WorkHoursSplitResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return WorkHoursSplitResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return WorkHoursSplitResult{}, err
+	}
+	ws, err := parseClockWindows(windows)
+	if err != nil {
+		return WorkHoursSplitResult{}, err
+	}
+	hs, err := parseHolidays(holidays)
+	if err != nil {
+		return WorkHoursSplitResult{}, err
+	}
+	from, err := t.parseDateTime(start, loc)
+	if err != nil {
+		return WorkHoursSplitResult{}, err
+	}
+	until, err := t.parseDateTime(end, loc)
+	if err != nil {
+		return WorkHoursSplitResult{}, err
+	}
+	if until.Before(from) {
+		return WorkHoursSplitResult{}, fmt.Errorf("start must not be after end")
+	}
+
+	res := WorkHoursSplitResult{Start: makeTimeResult(tz, from), End: makeTimeResult(tz, until)}
+	for _, w := range ws {
+		res.Windows = append(res.Windows, formatClock(w.Start)+"-"+formatClock(w.End))
+	}
+	var total time.Duration
+	for d, i := startOfDay(from), 0; d.Before(until); d, i = d.AddDate(0, 0, 1), i+1 {
+		if i == maxBusinessScanDays {
+			return WorkHoursSplitResult{}, fmt.Errorf("interval spans more than %d days", maxBusinessScanDays)
+		}
+		if !isBusinessDay(d, hs) {
+			continue
+		}
+		var worked time.Duration
+		for _, w := range ws {
+			opens, closes := w.on(d)
+			if opens.Before(from) {
+				opens = from
+			}
+			if closes.After(until) {
+				closes = until
+			}
+			if closes.After(opens) {
+				worked += closes.Sub(opens)
+			}
+		}
+		if worked > 0 {
+			res.Days = append(res.Days, DayWork{Date: d.Format("2006-01-02"), Hours: worked.Hours()})
+			total += worked
+		}
+	}
+	res.BusinessHours = total.Hours()
+	res.BusinessDuration = render(total)
+	return res, nil
+}
+
+// BusinessWeek returns the week containing datetime, starting at local
+// midnight on weekStart, and the span of its business days. The business
+// days are configurable so Sunday-Thursday weeks can be described; the
+// business span runs from midnight on the first business day to midnight
+// after the last one.
+func (t *TimeServer) BusinessWeek(datetime, tz, weekStart string, businessDays []string) (BusinessWeekResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return BusinessWeekResult{}, err
+	}
+	if weekStart == "" {
+		weekStart = "monday"
+	}
+	first, err := parseWeekday(weekStart)
+	if err != nil {
+		return BusinessWeekResult{}, err
+	}
+	if len(businessDays) == 0 {
+		businessDays = defaultWorkweek
+	}
+	set, err := parseWeekdaySet(businessDays)
+	if err != nil {
+		return BusinessWeekResult{}, err
+	}
+	at, err := t.parseDateTime(datetime, loc)
+	if err != nil {
+		return BusinessWeekResult{}, err
+	}
+
+	start := startOfDay(at).AddDate(0, 0, -mod(int(at.Weekday())-int(first), 7))
+	res := BusinessWeekResult{
+		Timezone:      tz,
+		WeekStart:     makeTimeResult(tz, start),
+		WeekEnd:       makeTimeResult(tz, start.AddDate(0, 0, 7)),
+		IsBusinessDay: set[at.Weekday()],
+	}
+	var lo, hi time.Time
+	for i := 0; i < 7; i++ {
+		d := start.AddDate(0, 0, i)
+		if !set[d.Weekday()] {
+			continue
+		}
+		if lo.IsZero() {
+			lo = d
+		}
+		hi = d.AddDate(0, 0, 1)
+		res.BusinessDays = append(res.BusinessDays, d.Format("2006-01-02")+" "+d.Weekday().String())
+	}
+	res.BusinessStart = makeTimeResult(tz, lo)
+	res.BusinessEnd = makeTimeResult(tz, hi)
+	return res, nil
+}
+
+// BusinessDaysToMonthEnd counts the business days left in datetime's local
+// month, up to and including the last calendar day. Today counts only when
+// includeToday is set, so on the month's last business day the count is 1
+// or 0 respectively.
+func (t *TimeServer) BusinessDaysToMonthEnd(datetime, tz string, holidays []string, includeToday bool) (MonthEndResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return MonthEndResult{}, err
+	}
+	hs, err := parseHolidays(holidays)
+	if err != nil {
+		return MonthEndResult{}, err
+	}
+	at, err := t.parseDateTime(datetime, loc)
+	if err != nil {
+		return MonthEndResult{}, err
+	}
+
+	today := startOfDay(at)
+	end := time.Date(today.Year(), today.Month(), daysIn(today.Year(), today.Month()), 0, 0, 0, 0, loc)
+	res := MonthEndResult{
+		Date:          today.Format("2006-01-02"),
+		Timezone:      tz,
+		MonthEnd:      end.Format("2006-01-02"),
+		IncludesToday: includeToday,
+		Remaining:     []string{},
+	}
+	for d := end; d.Month() == end.Month(); d = d.AddDate(0, 0, -1) {
+		if isBusinessDay(d, hs) {
+			res.LastBusinessDay = d.Format("2006-01-02")
+			break
+		}
+	}
+	for d := today; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if isBusinessDay(d, hs) && (includeToday || !d.Equal(today)) {
+			res.Remaining = append(res.Remaining, d.Format("2006-01-02"))
+		}
+	}
+	res.BusinessDays = len(res.Remaining)
+	res.IsLastBusinessDay = res.LastBusinessDay == res.Date
+	return res, nil
+}
+
+// BusinessDayProgress reports how far the injected now is through today's
+// business window in tz, clamped to 0 before it opens and 1 after it
+// closes. The window is placed on today's local wall clock and measured in
+// elapsed time, so a DST change inside it is counted as it happened.
+// Weekends and holidays have no business day and report fraction 0.
+func (t *TimeServer) BusinessDayProgress(tz, window string, holidays []string, format string) (BusinessDayProgressResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return BusinessDayProgressResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return BusinessDayProgressResult{}, err
+	}
+	if window == "" {
+		window = defaultBusinessWindow
+	}
+	w, err := parseClockWindow(window, false)
+	if err != nil {
+		return BusinessDayProgressResult{}, err
+	}
+	hs, err := parseHolidays(holidays)
+	if err != nil {
+		return BusinessDayProgressResult{}, err
+	}
+	now := t.nowFunc().In(loc)
+
+	res := BusinessDayProgressResult{Timezone: tz, Now: makeTimeResult(tz, now)}
+	if !isBusinessDay(now, hs) {
+		res.Status = "no_business_day"
+		return res, nil
+	}
+	opens, closes := w.on(now)
+	start, end := makeTimeResult(tz, opens), makeTimeResult(tz, closes)
+	res.WindowStart, res.WindowEnd = &start, &end
+	elapsed := now.Sub(opens)
+	switch {
+	case elapsed <= 0:
+		res.Status, elapsed = "before", 0
+	case !now.Before(closes):
+		res.Status, elapsed = "after", closes.Sub(opens)
+	default:
+		res.Status = "in_progress"
+	}
+	res.Fraction = float64(elapsed) / float64(closes.Sub(opens))
+	res.Percent = math.Round(res.Fraction*10000) / 100
+	res.Elapsed = render(elapsed)
+	res.Remaining = render(closes.Sub(opens) - elapsed)
+	return res, nil
+}
+
+// fixedHoliday is a public holiday on the same calendar date every year,
+// first observed in year since (0 for always).
+type fixedHoliday struct {
+	name  string
+	month time.Month
+	day   int
+	since int
+}
+
+// countryHolidays lists the fixed-date public holidays per country. Only
+// these can land on a weekend; holidays defined by weekday (Memorial Day, the
+// August bank holiday) never need a substitute and are not listed.
+var countryHolidays = map[string][]fixedHoliday{
+	"GB": {
+		{"New Year's Day", time.January, 1, 0},
+		{"Christmas Day", time.December, 25, 0},
+		{"Boxing Day", time.December, 26, 0},
+	},
+	"IE": {
+		{"New Year's Day", time.January, 1, 0},
+		{"Saint Patrick's Day", time.March, 17, 0},
+		{"Christmas Day", time.December, 25, 0},
+		{"Saint Stephen's Day", time.December, 26, 0},
+	},
+	"US": {
+		{"New Year's Day", time.January, 1, 0},
+		{"Juneteenth", time.June, 19, 2021},
+		{"Independence Day", time.July, 4, 0},
+		{"Veterans Day", time.November, 11, 0},
+		{"Christmas Day", time.December, 25, 0},
+	},
+}
+
+// countryPolicies is each country's substitution rule: "next_weekday" moves
+// a weekend holiday to the first following weekday not already a holiday,
+// so a Christmas weekend cascades to Monday and Tuesday; "nearest_weekday"
+// (US federal) moves Saturday to Friday and Sunday to Monday; "none" keeps
+// the calendar date.
+var countryPolicies = map[string]string{"GB": "next_weekday", "IE": "next_weekday", "US": "nearest_weekday"}
+
+// observedDays returns country's fixed holidays for year with the date each
+// is observed under policy, in calendar order.
+func observedDays(country string, year int, policy string) []ObservedDay {
+	var actual []time.Time
+	var names []string
+	taken := map[string]bool{}
+	for _, h := range countryHolidays[country] {
+		if year < h.since {
+			continue
+		}
+		d := time.Date(year, h.month, h.day, 0, 0, 0, 0, time.UTC)
+		actual = append(actual, d)
+		names = append(names, h.name)
+		if isBusinessDay(d, nil) {
+			taken[d.Format("2006-01-02")] = true
+		}
+	}
+	out := make([]ObservedDay, len(actual))
+	for i, d := range actual {
+		obs := d
+		if !isBusinessDay(d, nil) {
+			switch policy {
+			case "next_weekday":
+				for !isBusinessDay(obs, nil) || taken[obs.Format("2006-01-02")] {
+					obs = obs.AddDate(0, 0, 1)
+				}
+				taken[obs.Format("2006-01-02")] = true
+			case "nearest_weekday":
+				if d.Weekday() == time.Saturday {
+					obs = d.AddDate(0, 0, -1)
+				} else {
+					obs = d.AddDate(0, 0, 1)
+				}
+			}
+		}
+		out[i] = ObservedDay{Name: names[i], Actual: d.Format("2006-01-02"), Observed: obs.Format("2006-01-02")}
+	}
+	return out
+}
+
+// ObservedHoliday reports whether date is a day off for a public holiday in
+// country once weekend holidays are substituted under policy (default: the
+// country's own rule). Substitutes can cross a year boundary, as when a
+// Saturday New Year's Day is observed on the Friday before in the US.
+func (t *TimeServer) ObservedHoliday(date, country, policy string) (ObservedHolidayResult, error) {
+	d, err := time.Parse("2006-01-02", strings.TrimSpace(date))
+	if err != nil {
+		return ObservedHolidayResult{}, fmt.Errorf("date must be YYYY-MM-DD: %s", date)
+	}
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country == "UK" {
+		country = "GB"
+	}
+	if _, ok := countryHolidays[country]; !ok {
+		return ObservedHolidayResult{}, fmt.Errorf("unsupported country %q (supported: GB, IE, US)", country)
+	}
+	if policy = strings.ToLower(strings.TrimSpace(policy)); policy == "" {
+		policy = countryPolicies[country]
+	}
+	if policy != "next_weekday" && policy != "nearest_weekday" && policy != "none" {
+		return ObservedHolidayResult{}, fmt.Errorf("policy must be next_weekday, nearest_weekday or none: %s", policy)
+	}
+
+	key := d.Format("2006-01-02")
+	res := ObservedHolidayResult{Date: key, Country: country, Policy: policy}
+	for y := d.Year() - 1; y <= d.Year()+1; y++ {
+		for _, od := range observedDays(country, y, policy) {
+			if od.Observed == key {
+				res.Observed = append(res.Observed, od)
+				res.Substitute = res.Substitute || od.Actual != key
+			} else if od.Actual == key {
+				res.MovedAway = append(res.MovedAway, od)
+			}
+		}
+	}
+	res.IsHoliday = len(res.Observed) > 0
+	return res, nil
+}
+
+// AddBusinessDays moves base forward n business days (backward for negative
+// n), skipping weekends and holidays, and keeps base's local time of day.
+// n == 0 returns base unchanged even when it falls on a non-business day.
+func (t *TimeServer) AddBusinessDays(base string, n int, tz string, holidays []string) (TimeResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return TimeResult{}, err
+	}
+	hs, err := parseHolidays(holidays)
+	if err != nil {
+		return TimeResult{}, err
+	}
+	if n > maxBusinessScanDays || n < -maxBusinessScanDays {
+		return TimeResult{}, fmt.Errorf("n must be within ±%d business days: %d", maxBusinessScanDays, n)
+	}
+	from, err := t.parseDateTime(base, loc)
+	if err != nil {
+		return TimeResult{}, err
+	}
+
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	d := startOfDay(from)
+	for n > 0 {
+		d = d.AddDate(0, 0, step)
+		if isBusinessDay(d, hs) {
+			n--
+		}
+	}
+	return makeTimeResult(tz, time.Date(d.Year(), d.Month(), d.Day(),
+		from.Hour(), from.Minute(), from.Second(), from.Nanosecond(), loc)), nil
+}
+
+/* ----- tools ----- */
+
+func registerBusinessTools(s *server.MCPServer, ts *TimeServer) {
+	addHours := mcp.NewTool(
+		"business_add_hours",
+		mcp.WithDescription("Add N working hours to an instant, counting only time inside the daily business window on weekdays that are not holidays."),
+		mcp.WithString("start", mcp.Description("Starting instant (RFC3339, YYYY-MM-DD HH:MM, or natural language). Defaults to now.")),
+		mcp.WithNumber("hours", mcp.Required()),
+		mcp.WithString("window", mcp.Description("Daily business window HH:MM-HH:MM (default 09:00-17:00).")),
+		mcp.WithString("timezone"),
+		mcp.WithArray("holidays", mcp.Items(map[string]any{"type": "string"}), mcp.Description("Dates to skip, as YYYY-MM-DD.")),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(addHours, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		hours, err := r.RequireFloat("hours")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.BusinessAddHours(
+			r.GetString("start", ""), hours, r.GetString("window", ""),
+			r.GetString("timezone", ""), r.GetStringSlice("holidays", nil),
+			r.GetString("format", ""),
+		)
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	split := mcp.NewTool(
+		"work_hours_split",
+		mcp.WithDescription("Working time between two instants when each day has several windows, e.g. 09:00-12:00 and 13:00-17:00 to exclude lunch. Weekends and holidays are skipped."),
+		mcp.WithString("start", mcp.Required()),
+		mcp.WithString("end", mcp.Required()),
+		mcp.WithArray("windows", mcp.Items(map[string]any{"type": "string"}), mcp.Description("Disjoint daily windows HH:MM-HH:MM (default 09:00-17:00).")),
+		mcp.WithString("timezone"),
+		mcp.WithArray("holidays", mcp.Items(map[string]any{"type": "string"}), mcp.Description("Dates to skip, as YYYY-MM-DD.")),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(split, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start, err := r.RequireString("start")
+		if err != nil {
+			return errorResult(err)
+		}
+		end, err := r.RequireString("end")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.WorkHoursSplit(start, end, r.GetStringSlice("windows", nil),
+			r.GetString("timezone", ""), r.GetStringSlice("holidays", nil), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	week := mcp.NewTool(
+		"business_week",
+		mcp.WithDescription("The business week containing a datetime: week bounds for a configurable start day and the span and list of business days (Mon-Fri by default; pass e.g. Sunday-Thursday for other regions)."),
+		mcp.WithString("datetime", mcp.Description("Defaults to now.")),
+		mcp.WithString("timezone"),
+		mcp.WithString("week_start", mcp.Description("First day of the week (default monday).")),
+		mcp.WithArray("business_days", mcp.Items(map[string]any{"type": "string"}), mcp.Description("Working weekdays (default monday-friday).")),
+	)
+
+	s.AddTool(week, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := ts.BusinessWeek(r.GetString("datetime", ""), r.GetString("timezone", ""),
+			r.GetString("week_start", ""), r.GetStringSlice("business_days", nil))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	monthEnd := mcp.NewTool(
+		"business_days_to_month_end",
+		mcp.WithDescription("Business days remaining in the current month for month-end close planning, skipping weekends and holidays."),
+		mcp.WithString("datetime", mcp.Description("Defaults to now.")),
+		mcp.WithString("timezone"),
+		mcp.WithArray("holidays", mcp.Items(map[string]any{"type": "string"}), mcp.Description("Dates to skip, as YYYY-MM-DD.")),
+		mcp.WithBoolean("include_today", mcp.Description("Count today if it is a business day (default true).")),
+	)
+
+	s.AddTool(monthEnd, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := ts.BusinessDaysToMonthEnd(r.GetString("datetime", ""), r.GetString("timezone", ""),
+			r.GetStringSlice("holidays", nil), r.GetBool("include_today", true))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	progress := mcp.NewTool(
+		"business_day_progress",
+		mcp.WithDescription("Fraction of today's business window elapsed in a timezone: 0 before it opens, 1 after it closes. Weekends and holidays report no business day."),
+		mcp.WithString("timezone"),
+		mcp.WithString("window", mcp.Description("Daily window HH:MM-HH:MM (default 09:00-17:00).")),
+		mcp.WithArray("holidays", mcp.Items(map[string]any{"type": "string"}), mcp.Description("Dates to skip, as YYYY-MM-DD.")),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(progress, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := ts.BusinessDayProgress(r.GetString("timezone", ""), r.GetString("window", ""), r.GetStringSlice("holidays", nil), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	observed := mcp.NewTool(
+		"observed_holiday",
+		mcp.WithDescription("Whether a date is an observed public holiday in a country once weekend holidays are substituted, e.g. UK Christmas on a Saturday observed the following Monday. Covers fixed-date holidays for GB, IE and US."),
+		mcp.WithString("date", mcp.Required(), mcp.Description("Date YYYY-MM-DD.")),
+		mcp.WithString("country", mcp.Required(), mcp.Description("GB (or UK), IE or US.")),
+		mcp.WithString("policy", mcp.Description("next_weekday, nearest_weekday or none. Defaults to the country's rule.")),
+	)
+
+	s.AddTool(observed, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		date, err := r.RequireString("date")
+		if err != nil {
+			return errorResult(err)
+		}
+		country, err := r.RequireString("country")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.ObservedHoliday(date, country, r.GetString("policy", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	addDays := mcp.NewTool(
+		"add_business_days",
+		mcp.WithDescription("Move a timestamp n business days forward (or back for negative n), skipping weekends and holidays and keeping the time of day."),
+		mcp.WithNumber("n", mcp.Required(), mcp.Description("Business days to add; negative moves backward.")),
+		mcp.WithString("base", mcp.Description("Defaults to now.")),
+		mcp.WithString("timezone"),
+		mcp.WithArray("holidays", mcp.Items(map[string]any{"type": "string"}), mcp.Description("Dates to skip, as YYYY-MM-DD.")),
+	)
+
+	s.AddTool(addDays, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		n, err := r.RequireInt("n")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.AddBusinessDays(r.GetString("base", ""), n, r.GetString("timezone", ""), r.GetStringSlice("holidays", nil))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+}
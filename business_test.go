@@ -0,0 +1,282 @@
+// business_test.go
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBusinessAddHours(t *testing.T) {
+	ts := NewTimeServer("America/New_York")
+	cases := []struct {
+		name     string
+		start    string
+		hours    float64
+		holidays []string
+		want     string
+	}{
+		{"withinDay", "2025-05-14 10:00", 2, nil, "2025-05-14T12:00:00-04:00"},
+		{"fridayCarriesToMonday", "2025-05-16 16:00", 3, nil, "2025-05-19T11:00:00-04:00"},
+		{"fractionalCarry", "2025-05-14 16:30", 1.5, nil, "2025-05-15T10:00:00-04:00"},
+		{"beforeWindowSnapsToOpen", "2025-05-14 06:00", 1, nil, "2025-05-14T10:00:00-04:00"},
+		{"weekendStart", "2025-05-17 12:00", 8, nil, "2025-05-19T17:00:00-04:00"},
+		{"skipsHoliday", "2025-05-16 16:00", 3, []string{"2025-05-19"}, "2025-05-20T11:00:00-04:00"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.BusinessAddHours(tc.start, tc.hours, "", "America/New_York", tc.holidays, "")
+			if err != nil {
+				t.Fatalf("BusinessAddHours error: %v", err)
+			}
+			if res.Result.Datetime != tc.want {
+				t.Errorf("got %s, want %s", res.Result.Datetime, tc.want)
+			}
+		})
+	}
+
+	if _, err := ts.BusinessAddHours("2025-05-14 10:00", 1, "17:00-09:00", "UTC", nil, ""); err == nil {
+		t.Errorf("expected error for inverted window, got nil")
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	if got, err := parseClock("09:30"); err != nil || got != 9*time.Hour+30*time.Minute {
+		t.Errorf("parseClock(09:30) = %v, %v", got, err)
+	}
+	for _, bad := range []string{"9", "25:00", "12:60", "24:01"} {
+		if _, err := parseClock(bad); err == nil {
+			t.Errorf("parseClock(%q) expected error, got nil", bad)
+		}
+	}
+}
+
+func TestWorkHoursSplit(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	split := []string{"13:00-17:00", "09:00-12:00"}
+	cases := []struct {
+		name       string
+		start, end string
+		holidays   []string
+		wantHours  float64
+		wantDays   int
+	}{
+		{"fullDay", "2025-05-14 00:00", "2025-05-15 00:00", nil, 7, 1},
+		{"throughLunch", "2025-05-14 11:00", "2025-05-14 14:00", nil, 2, 1},
+		// Thursday 10:30 to Monday 09:30: 5.5h + 7h + weekend + 0.5h.
+		{"acrossWeekend", "2025-05-15 10:30", "2025-05-19 09:30", nil, 13, 3},
+		{"holidaySkipped", "2025-05-15 10:30", "2025-05-19 09:30", []string{"2025-05-16"}, 6, 2},
+		{"insideLunch", "2025-05-14 12:10", "2025-05-14 12:50", nil, 0, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.WorkHoursSplit(tc.start, tc.end, split, "Europe/London", tc.holidays, "")
+			if err != nil {
+				t.Fatalf("WorkHoursSplit error: %v", err)
+			}
+			if res.BusinessHours != tc.wantHours || len(res.Days) != tc.wantDays {
+				t.Errorf("got %.2fh over %d days, want %.2fh over %d days", res.BusinessHours, len(res.Days), tc.wantHours, tc.wantDays)
+			}
+		})
+	}
+
+	if _, err := ts.WorkHoursSplit("2025-05-14", "2025-05-15", []string{"09:00-13:00", "12:00-17:00"}, "UTC", nil, ""); err == nil {
+		t.Errorf("expected error for overlapping windows, got nil")
+	}
+	// The clash is reported between the sorted neighbours, not the input pair.
+	_, err := ts.WorkHoursSplit("2025-05-14", "2025-05-15", []string{"13:00-17:00", "08:00-10:00", "09:00-12:00"}, "UTC", nil, "")
+	if err == nil || !strings.Contains(err.Error(), "08:00-10:00 and 09:00-12:00") {
+		t.Errorf("overlap error = %v, want it to name 08:00-10:00 and 09:00-12:00", err)
+	}
+}
+
+func TestBusinessWeek(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	res, err := ts.BusinessWeek("2025-05-14 15:00", "Europe/Paris", "", nil)
+	if err != nil {
+		t.Fatalf("BusinessWeek error: %v", err)
+	}
+	if res.WeekStart.Datetime != "2025-05-12T00:00:00+02:00" || res.BusinessEnd.Datetime != "2025-05-17T00:00:00+02:00" || len(res.BusinessDays) != 5 {
+		t.Errorf("default week = %+v", res)
+	}
+
+	// Sunday-Thursday week in Riyadh; Friday is not a business day.
+	sunThu := []string{"sunday", "monday", "tuesday", "wednesday", "thursday"}
+	gulf, err := ts.BusinessWeek("2025-05-16 10:00", "Asia/Riyadh", "sunday", sunThu)
+	if err != nil {
+		t.Fatalf("BusinessWeek error: %v", err)
+	}
+	if gulf.WeekStart.Datetime != "2025-05-11T00:00:00+03:00" || gulf.BusinessStart.Datetime != "2025-05-11T00:00:00+03:00" ||
+		gulf.BusinessEnd.Datetime != "2025-05-16T00:00:00+03:00" || gulf.IsBusinessDay {
+		t.Errorf("Sunday-Thursday week = %+v", gulf)
+	}
+
+	if _, err := ts.BusinessWeek("", "UTC", "someday", nil); err == nil {
+		t.Errorf("expected error for an invalid week start, got nil")
+	}
+}
+
+func TestBusinessDaysToMonthEnd(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	cases := []struct {
+		name, datetime string
+		holidays       []string
+		include        bool
+		want           int
+		wantLast       string
+	}{
+		// May 2025 ends on a Saturday, so the last business day is the 30th.
+		{"inclusive", "2025-05-26", nil, true, 5, "2025-05-30"},
+		{"exclusive", "2025-05-26", nil, false, 4, "2025-05-30"},
+		{"holiday", "2025-05-26", []string{"2025-05-26"}, true, 4, "2025-05-30"},
+		{"lastBusinessDayInclusive", "2025-05-30 16:00", nil, true, 1, "2025-05-30"},
+		{"lastBusinessDayExclusive", "2025-05-30 16:00", nil, false, 0, "2025-05-30"},
+		{"weekendAfterClose", "2025-05-31", nil, true, 0, "2025-05-30"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.BusinessDaysToMonthEnd(tc.datetime, "UTC", tc.holidays, tc.include)
+			if err != nil {
+				t.Fatalf("BusinessDaysToMonthEnd error: %v", err)
+			}
+			if res.BusinessDays != tc.want || res.LastBusinessDay != tc.wantLast {
+				t.Errorf("got %d days (last %q), want %d (last %q)", res.BusinessDays, res.LastBusinessDay, tc.want, tc.wantLast)
+			}
+		})
+	}
+}
+
+func TestBusinessDayProgress(t *testing.T) {
+	cases := []struct {
+		name, now, window string
+		holidays          []string
+		status            string
+		fraction          float64
+	}{
+		// 2025-05-14 is a Wednesday; New York is UTC-4.
+		{"before", "2025-05-14T12:00:00Z", "09:00-17:00", nil, "before", 0},
+		{"opening", "2025-05-14T13:00:00Z", "09:00-17:00", nil, "before", 0},
+		{"quarter", "2025-05-14T15:00:00Z", "09:00-17:00", nil, "in_progress", 0.25},
+		{"defaultWindow", "2025-05-14T15:00:00Z", "", nil, "in_progress", 0.25},
+		{"customWindow", "2025-05-14T16:00:00Z", "10:00-14:00", nil, "in_progress", 0.5},
+		{"closing", "2025-05-14T21:00:00Z", "09:00-17:00", nil, "after", 1},
+		{"weekend", "2025-05-17T15:00:00Z", "09:00-17:00", nil, "no_business_day", 0},
+		{"holiday", "2025-05-14T15:00:00Z", "09:00-17:00", []string{"2025-05-14"}, "no_business_day", 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := NewTimeServer("UTC")
+			now, _ := time.Parse(time.RFC3339, tc.now)
+			ts.forTesting_SetNowFunc(func() time.Time { return now })
+			res, err := ts.BusinessDayProgress("America/New_York", tc.window, tc.holidays, "")
+			if err != nil {
+				t.Fatalf("BusinessDayProgress error: %v", err)
+			}
+			if res.Status != tc.status || res.Fraction != tc.fraction {
+				t.Errorf("got %s %v, want %s %v", res.Status, res.Fraction, tc.status, tc.fraction)
+			}
+			if (res.WindowStart == nil) != (tc.status == "no_business_day") {
+				t.Errorf("window_start = %v for status %s", res.WindowStart, res.Status)
+			}
+		})
+	}
+
+	ts := NewTimeServer("UTC")
+	if _, err := ts.BusinessDayProgress("UTC", "22:00-06:00", nil, ""); err == nil {
+		t.Error("expected error for wrapping window")
+	}
+}
+
+func TestObservedHoliday(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	cases := []struct {
+		name, date, country, policy string
+		holiday, substitute         bool
+		observed                    []string // names observed on date
+		movedAway                   int
+	}{
+		// 2021: Christmas Saturday, Boxing Day Sunday -> Mon 27 and Tue 28.
+		{"ukChristmasSaturday", "2021-12-25", "GB", "", false, false, nil, 1},
+		{"ukChristmasSubstitute", "2021-12-27", "UK", "", true, true, []string{"Christmas Day"}, 0},
+		{"ukBoxingSubstitute", "2021-12-28", "GB", "", true, true, []string{"Boxing Day"}, 0},
+		// 2022: Christmas Sunday, Boxing Day Monday -> Christmas moves to Tuesday.
+		{"ukBoxingActualMonday", "2022-12-26", "GB", "", true, false, []string{"Boxing Day"}, 0},
+		{"ukChristmasCascade", "2022-12-27", "GB", "", true, true, []string{"Christmas Day"}, 0},
+		{"ukOrdinaryWeekday", "2022-12-28", "GB", "", false, false, nil, 0},
+		// US: Saturday New Year's Day 2022 is observed Friday 2021-12-31.
+		{"usNewYearPreviousYear", "2021-12-31", "US", "", true, true, []string{"New Year's Day"}, 0},
+		{"usIndependenceSunday", "2021-07-05", "US", "", true, true, []string{"Independence Day"}, 0},
+		{"usJuneteenthBefore2021", "2020-06-19", "US", "", false, false, nil, 0},
+		{"policyNone", "2021-12-25", "GB", "none", true, false, []string{"Christmas Day"}, 0},
+		{"irelandStPatrick", "2024-03-18", "IE", "", true, true, []string{"Saint Patrick's Day"}, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.ObservedHoliday(tc.date, tc.country, tc.policy)
+			if err != nil {
+				t.Fatalf("ObservedHoliday error: %v", err)
+			}
+			if res.IsHoliday != tc.holiday || res.Substitute != tc.substitute || len(res.MovedAway) != tc.movedAway {
+				t.Errorf("got holiday=%v substitute=%v moved=%d, want %v %v %d", res.IsHoliday, res.Substitute, len(res.MovedAway), tc.holiday, tc.substitute, tc.movedAway)
+			}
+			if len(res.Observed) != len(tc.observed) {
+				t.Fatalf("observed = %+v, want %v", res.Observed, tc.observed)
+			}
+			for i, n := range tc.observed {
+				if res.Observed[i].Name != n {
+					t.Errorf("observed[%d] = %s, want %s", i, res.Observed[i].Name, n)
+				}
+			}
+		})
+	}
+
+	if _, err := ts.ObservedHoliday("2021-12-25", "FR", ""); err == nil {
+		t.Error("expected error for unsupported country")
+	}
+	if _, err := ts.ObservedHoliday("2021-12-25", "GB", "previous_weekday"); err == nil {
+		t.Error("expected error for unknown policy")
+	}
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 15, 0, 0, 0, time.UTC) }) // Wednesday
+
+	cases := []struct {
+		name, base string
+		n          int
+		tz         string
+		holidays   []string
+		want       string
+	}{
+		{"fridayPlusOne", "2025-05-16T09:30:00Z", 1, "UTC", nil, "2025-05-19T09:30:00Z"},
+		{"mondayMinusOne", "2025-05-19T09:30:00Z", -1, "UTC", nil, "2025-05-16T09:30:00Z"},
+		{"fiveFromNow", "", 5, "UTC", nil, "2025-05-21T15:00:00Z"},
+		{"zero", "2025-05-17T10:00:00Z", 0, "UTC", nil, "2025-05-17T10:00:00Z"},
+		{"saturdayPlusOne", "2025-05-17T10:00:00Z", 1, "UTC", nil, "2025-05-19T10:00:00Z"},
+		{"skipsHoliday", "2025-05-23T09:00:00Z", 1, "UTC", []string{"2025-05-26"}, "2025-05-27T09:00:00Z"},
+		{"backOverHoliday", "2025-05-27T09:00:00Z", -2, "UTC", []string{"2025-05-26"}, "2025-05-22T09:00:00Z"},
+		// Wall-clock time survives the New York spring-forward weekend.
+		{"acrossDST", "2025-03-07T09:00:00-05:00", 1, "America/New_York", nil, "2025-03-10T09:00:00-04:00"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.AddBusinessDays(tc.base, tc.n, tc.tz, tc.holidays)
+			if err != nil {
+				t.Fatalf("AddBusinessDays error: %v", err)
+			}
+			if res.Datetime != tc.want {
+				t.Errorf("AddBusinessDays(%s, %d) = %s, want %s", tc.base, tc.n, res.Datetime, tc.want)
+			}
+		})
+	}
+
+	if _, err := ts.AddBusinessDays("", 1, "UTC", []string{"26/05/2025"}); err == nil {
+		t.Error("expected error for malformed holiday")
+	}
+	if _, err := ts.AddBusinessDays("", 1_000_000, "UTC", nil); err == nil {
+		t.Error("expected error for out-of-range n")
+	}
+}
@@ -0,0 +1,1511 @@
+// calendar.go
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+/* ----- data types ----- */
+
+type PeriodCountResult struct {
+	Period       string  `json:"period"`
+	FullPeriods  int     `json:"full_periods"`
+	Fraction     float64 `json:"fraction"`
+	Total        float64 `json:"total"`
+	PartialStart string  `json:"partial_start"`
+	PartialEnd   string  `json:"partial_end"`
+	Reversed     bool    `json:"reversed"`
+}
+
+type MidnightsResult struct {
+	Timezone         string     `json:"timezone"`
+	Now              TimeResult `json:"now"`
+	PreviousMidnight TimeResult `json:"previous_midnight"`
+	PreviousUnix     int64      `json:"previous_unix"`
+	NextMidnight     TimeResult `json:"next_midnight"`
+	NextUnix         int64      `json:"next_unix"`
+	DayLengthHours   float64    `json:"day_length_hours"`
+}
+
+type DayFractionResult struct {
+	Timezone       string     `json:"timezone"`
+	Fraction       float64    `json:"fraction"`
+	Now            TimeResult `json:"now"`
+	DayLengthHours float64    `json:"day_length_hours"`
+	Target         TimeResult `json:"target"`
+	WallClock      string     `json:"wall_clock"`
+	Passed         bool       `json:"passed"`
+	Delta          string     `json:"delta"` // time until the target, or since it if passed
+}
+
+type SpannedDate struct {
+	Date         string  `json:"date"`
+	CoveredHours float64 `json:"covered_hours"`
+	DayHours     float64 `json:"day_hours"`
+}
+
+type DatesSpannedResult struct {
+	Timezone string        `json:"timezone"`
+	Start    TimeResult    `json:"start"`
+	End      TimeResult    `json:"end"`
+	Count    int           `json:"count"`
+	Dates    []SpannedDate `json:"dates"`
+}
+
+type IntervalPerDayResult struct {
+	Timezone     string             `json:"timezone"`
+	Start        TimeResult         `json:"start"`
+	End          TimeResult         `json:"end"`
+	TotalMinutes float64            `json:"total_minutes"`
+	Minutes      map[string]float64 `json:"minutes"` // local date -> minutes
+}
+
+type WeekNumber struct {
+	System    string `json:"system"`
+	Year      int    `json:"year"`
+	Week      int    `json:"week"`
+	WeekStart string `json:"week_start"`
+	WeekEnd   string `json:"week_end"`
+	Rule      string `json:"rule"`
+}
+
+type WeekNumberSystemsResult struct {
+	Date    string       `json:"date"`
+	Weekday string       `json:"weekday"`
+	Systems []WeekNumber `json:"systems"`
+	Agree   bool         `json:"agree"`
+}
+
+type DateDiffResult struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	Days      int    `json:"days"`
+	Weeks     int    `json:"weeks"`
+	SameDate  bool   `json:"same_date"`
+}
+
+type Friday13Result struct {
+	Today         string   `json:"today"`
+	Next          string   `json:"next"`
+	DaysUntil     int      `json:"days_until"`
+	IsToday       bool     `json:"is_today"`
+	ThisYear      []string `json:"this_year"`
+	CountThisYear int      `json:"count_this_year"`
+}
+
+type FromISOWeekResult struct {
+	ISO         string     `json:"iso"`
+	Date        string     `json:"date"`
+	Result      TimeResult `json:"result"`
+	WeeksInYear int        `json:"weeks_in_year"`
+}
+
+type BillingDaysResult struct {
+	PeriodStart  string  `json:"period_start"`
+	PeriodEnd    string  `json:"period_end"`
+	Convention   string  `json:"convention"`
+	Days         int     `json:"days"`
+	ActualDays   int     `json:"actual_days"`
+	Basis        int     `json:"basis"`
+	YearFraction float64 `json:"year_fraction"`
+}
+
+type NextRenewalResult struct {
+	Anchor       TimeResult  `json:"anchor"`
+	Cycle        string      `json:"cycle"`
+	Policy       string      `json:"policy"`
+	Reference    TimeResult  `json:"reference"`
+	CycleNumber  int         `json:"cycle_number"`
+	CycleStart   *TimeResult `json:"cycle_start,omitempty"`
+	CycleEnd     *TimeResult `json:"cycle_end,omitempty"`
+	NextRenewal  TimeResult  `json:"next_renewal"`
+	DaysUntil    int         `json:"days_until"`
+	AnchorDay    int         `json:"anchor_day"`
+	RenewalDay   int         `json:"renewal_day"`
+	ClampedToEnd bool        `json:"clamped_to_month_end"`
+}
+
+type Milestone struct {
+	Kind      string `json:"kind"` // "day_count", "anniversary" or "fun"
+	Label     string `json:"label"`
+	Date      string `json:"date"`
+	DaysSince int    `json:"days_since_start"`
+	DaysUntil int    `json:"days_until"`
+}
+
+type MilestoneResult struct {
+	Start       string      `json:"start"`
+	Today       string      `json:"today"`
+	DaysElapsed int         `json:"days_elapsed"`
+	Milestones  []Milestone `json:"milestones"`
+}
+
+type TermSpec struct {
+	Name  string `json:"name"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type ActiveTerm struct {
+	Name          string `json:"name"`
+	Start         string `json:"start"`
+	End           string `json:"end"`
+	LengthDays    int    `json:"length_days"`
+	DayOfTerm     int    `json:"day_of_term"`
+	DaysRemaining int    `json:"days_remaining"`
+}
+
+type UpcomingTerm struct {
+	Name      string `json:"name"`
+	Start     string `json:"start"`
+	End       string `json:"end"`
+	DaysUntil int    `json:"days_until"`
+}
+
+type AcademicTermResult struct {
+	Date     string        `json:"date"`
+	Status   string        `json:"status"` // "in_term", "break", "before_terms" or "after_terms"
+	Current  []ActiveTerm  `json:"current,omitempty"`
+	Overlap  bool          `json:"overlap"`
+	Next     *UpcomingTerm `json:"next,omitempty"`
+	Previous string        `json:"previous,omitempty"` // last term to end before a break
+}
+
+type NthWeekdayResult struct {
+	Description  string     `json:"description"`
+	Occurrence   TimeResult `json:"occurrence"`
+	Date         string     `json:"date"`
+	TimeUntil    string     `json:"time_until"`
+	SecondsUntil float64    `json:"seconds_until"`
+	DaysUntil    int        `json:"days_until"`
+	RolledOver   bool       `json:"rolled_over"` // the current month's occurrence had passed or does not exist
+}
+
+type RangeBreakdownResult struct {
+	Start     string         `json:"start"`
+	End       string         `json:"end"`
+	TotalDays int            `json:"total_days"`
+	Weekdays  int            `json:"weekdays"`
+	Weekends  int            `json:"weekends"`
+	ByWeekday map[string]int `json:"by_weekday"`
+	Inclusive string         `json:"inclusive"`
+}
+
+type DayInfo struct {
+	Date            string `json:"date"`
+	Timezone        string `json:"timezone"`
+	Weekday         string `json:"weekday"`
+	WeekdayNumber   int    `json:"weekday_number"` // ISO 8601: Monday=1 ... Sunday=7
+	DayOfYear       int    `json:"day_of_year"`
+	ISOWeek         int    `json:"iso_week"`
+	ISOYear         int    `json:"iso_year"`
+	Quarter         int    `json:"quarter"`
+	IsWeekend       bool   `json:"is_weekend"`
+	DaysLeftInMonth int    `json:"days_left_in_month"` // excluding date itself
+}
+
+/* ----- helpers ----- */
+
+// renewalCycles maps billing cycle names to calendar periods.
+var renewalCycles = map[string]string{"weekly": "week", "monthly": "month", "quarterly": "quarter", "annual": "year", "yearly": "year"}
+
+// maxRenewalCycles bounds how many cycles NextRenewal will step through to
+// reach the reference; 5000 weekly cycles is nearly a century.
+const maxRenewalCycles = 5000
+
+// isLastOfFebruary reports whether d is February 28 (or 29 in leap years).
+func isLastOfFebruary(d time.Time) bool {
+	return d.Month() == time.February && d.Day() == daysIn(d.Year(), time.February)
+}
+
+// days30360 counts days between two dates under 30/360. The US (bond
+// basis) rules are: if both dates are the last day of February, D2 becomes
+// 30; if D1 is the last day of February, D1 becomes 30; if D2 is 31 and D1
+// is 30 or 31, D2 becomes 30; if D1 is 31, D1 becomes 30. The European
+// variant (30E/360) simply caps both days at 30.
+func days30360(a, b time.Time, european bool) int {
+	d1, d2 := a.Day(), b.Day()
+	if european {
+		d1, d2 = min(d1, 30), min(d2, 30)
+	} else {
+		if isLastOfFebruary(a) && isLastOfFebruary(b) {
+			d2 = 30
+		}
+		if isLastOfFebruary(a) {
+			d1 = 30
+		}
+		if d2 == 31 && d1 >= 30 {
+			d2 = 30
+		}
+		if d1 == 31 {
+			d1 = 30
+		}
+	}
+	return 360*(b.Year()-a.Year()) + 30*(int(b.Month())-int(a.Month())) + d2 - d1
+}
+
+// weekdayNames maps English day names and abbreviations to weekdays.
+var weekdayNames = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// parseWeekday reads a day name or an ISO weekday number (1 = Monday .. 7 =
+// Sunday).
+func parseWeekday(s string) (time.Weekday, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if wd, ok := weekdayNames[s]; ok {
+		return wd, nil
+	}
+	if n, err := atoiStrict(s); err == nil && n >= 1 && n <= 7 {
+		return time.Weekday(n % 7), nil
+	}
+	return 0, fmt.Errorf("invalid weekday: %s", s)
+}
+
+// isoWeeksIn returns the number of ISO weeks (52 or 53) in an ISO week-year;
+// December 28 always falls in the year's last week.
+func isoWeeksIn(year int) int {
+	_, w := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return w
+}
+
+var isoWeekRe = regexp.MustCompile(`^(\d{4})-?W(\d{2})(?:-?([1-7]))?$`)
+
+// maxSpannedDates bounds how many dates DatesSpanned will list.
+const maxSpannedDates = 3660
+
+// addMonthsClamped adds n months to tm, clamping the day to the end of the
+// target month instead of overflowing (Jan 31 + 1 month = Feb 28/29).
+func addMonthsClamped(tm time.Time, n int) time.Time {
+	first := time.Date(tm.Year(), tm.Month()+time.Month(n), 1, tm.Hour(), tm.Minute(), tm.Second(), tm.Nanosecond(), tm.Location())
+	d := tm.Day()
+	if last := daysIn(first.Year(), first.Month()); d > last {
+		d = last
+	}
+	return first.AddDate(0, 0, d-1)
+}
+
+// daysIn returns the number of days in the given month.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// periodMonths maps calendar period names to a length in months; weeks are
+// reported as zero months and handled separately.
+var periodMonths = map[string]int{"week": 0, "month": 1, "quarter": 3, "year": 12}
+
+// periodBoundary returns the start of the k-th period after anchor. Month
+// based periods are computed from the anchor each time and clamped, so an
+// anchor on the 31st returns to the 31st in long months.
+func periodBoundary(anchor time.Time, period string, k int) time.Time {
+	if period == "week" {
+		return anchor.AddDate(0, 0, 7*k)
+	}
+	return addMonthsClamped(anchor, periodMonths[period]*k)
+}
+
+// funDayCounts are the novelty day counts Milestone considers, ascending:
+// repdigits and the leading digits of pi.
+var funDayCounts = []struct {
+	days  int
+	label string
+}{
+	{314, "π × 100 days"}, {1111, "1111 days"}, {2222, "2222 days"}, {3141, "π × 1000 days"},
+	{3333, "3333 days"}, {4444, "4444 days"}, {5555, "5555 days"}, {6666, "6666 days"},
+	{7777, "7777 days"}, {8888, "8888 days"}, {9999, "9999 days"}, {11111, "11111 days"},
+	{22222, "22222 days"}, {31415, "π × 10000 days"}, {33333, "33333 days"},
+}
+
+// nextMultiple returns the smallest positive multiple of n that is >= x.
+func nextMultiple(x, n int) int {
+	if x <= 0 {
+		return n
+	}
+	return (x + n - 1) / n * n
+}
+
+// parseMonth reads an English month name, a three-letter abbreviation or a
+// number 1-12.
+func parseMonth(s string) (time.Month, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if n, err := atoiStrict(s); err == nil && n >= 1 && n <= 12 {
+		return time.Month(n), nil
+	}
+	for m := time.January; m <= time.December; m++ {
+		name := strings.ToLower(m.String())
+		if s == name || s == name[:3] {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid month: %s", s)
+}
+
+// nthWeekday returns the date of the n-th wd in the given month, counting
+// from the end when n is -1. ok is false when the month has no such day
+// (a 5th Monday in a month with four).
+func nthWeekday(year int, month time.Month, wd time.Weekday, n int, loc *time.Location) (time.Time, bool) {
+	if n == -1 {
+		last := time.Date(year, month, daysIn(year, month), 0, 0, 0, 0, loc)
+		return last.AddDate(0, 0, -mod(int(last.Weekday())-int(wd), 7)), true
+	}
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	d := first.AddDate(0, 0, mod(int(wd)-int(first.Weekday()), 7)+7*(n-1))
+	return d, d.Month() == month
+}
+
+// ordinal renders n as "1st", "2nd", ... with -1 as "last".
+func ordinal(n int) string {
+	if n == -1 {
+		return "last"
+	}
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+/* ----- core methods ----- */
+
+// PeriodCount counts how many whole periods fit between start and end and
+// expresses the remainder as a fraction of the partial period that follows
+// the last whole one, so unequal month lengths are prorated by their own
+// length. Reversed ranges are measured from end to start and flagged.
+func (t *TimeServer) PeriodCount(start, end, period, tz string) (PeriodCountResult, error) {
+	period = strings.ToLower(strings.TrimSpace(period))
+	if _, ok := periodMonths[period]; !ok {
+		return PeriodCountResult{}, fmt.Errorf("period must be week, month, quarter or year: %s", period)
+	}
+	_, loc, err := t.location(tz)
+	if err != nil {
+		return PeriodCountResult{}, err
+	}
+	from, err := t.parseDateTime(start, loc)
+	if err != nil {
+		return PeriodCountResult{}, err
+	}
+	until, err := t.parseDateTime(end, loc)
+	if err != nil {
+		return PeriodCountResult{}, err
+	}
+
+	res := PeriodCountResult{Period: period}
+	if until.Before(from) {
+		from, until = until, from
+		res.Reversed = true
+	}
+	k := 0
+	for !periodBoundary(from, period, k+1).After(until) {
+		k++
+	}
+	pStart := periodBoundary(from, period, k)
+	pEnd := periodBoundary(from, period, k+1)
+	res.FullPeriods = k
+	res.Fraction = float64(until.Sub(pStart)) / float64(pEnd.Sub(pStart))
+	res.Total = float64(k) + res.Fraction
+	res.PartialStart = pStart.Format(time.RFC3339)
+	res.PartialEnd = pEnd.Format(time.RFC3339)
+	if res.Reversed {
+		res.FullPeriods, res.Fraction, res.Total = -res.FullPeriods, -res.Fraction, -res.Total
+	}
+	return res, nil
+}
+
+// Midnights returns the local midnights bounding the current day in tz. The
+// next midnight is built with time.Date rather than by adding 24 hours, so
+// on a DST change day it is 23 or 25 hours after the previous one.
+func (t *TimeServer) Midnights(tz string) (MidnightsResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return MidnightsResult{}, err
+	}
+	now := t.nowFunc().In(loc)
+	prev := startOfDay(now)
+	next := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, loc)
+	return MidnightsResult{
+		Timezone:         tz,
+		Now:              makeTimeResult(tz, now),
+		PreviousMidnight: makeTimeResult(tz, prev),
+		PreviousUnix:     prev.Unix(),
+		NextMidnight:     makeTimeResult(tz, next),
+		NextUnix:         next.Unix(),
+		DayLengthHours:   next.Sub(prev).Hours(),
+	}, nil
+}
+
+// TimeToDayFraction places fraction of today's local day in tz and says how
+// far the injected now is from it. The fraction is of elapsed seconds between
+// the bounding midnights, not of the wall clock: on a 23-hour spring-forward
+// day 0.5 is 11h30m after midnight, which reads 12:30 once the clocks have
+// jumped, and 1.0 is always the next midnight.
+func (t *TimeServer) TimeToDayFraction(fraction float64, tz, format string) (DayFractionResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return DayFractionResult{}, err
+	}
+	if math.IsNaN(fraction) || fraction < 0 || fraction > 1 {
+		return DayFractionResult{}, fmt.Errorf("fraction must be between 0 and 1")
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return DayFractionResult{}, err
+	}
+	now := t.nowFunc().In(loc)
+	prev := startOfDay(now)
+	next := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, loc)
+	length := next.Sub(prev)
+	target := prev.Add(time.Duration(math.Round(fraction*float64(length/time.Second))) * time.Second)
+
+	delta := target.Sub(now)
+	res := DayFractionResult{
+		Timezone:       tz,
+		Fraction:       fraction,
+		Now:            makeTimeResult(tz, now),
+		DayLengthHours: length.Hours(),
+		Target:         makeTimeResult(tz, target),
+		WallClock:      target.Format("15:04:05"),
+		Passed:         delta < 0,
+	}
+	if delta < 0 {
+		delta = -delta
+	}
+	res.Delta = render(delta)
+	return res, nil
+}
+
+// DatesSpanned lists the local calendar dates touched by [start, end) in tz
+// with how much of each date the interval covers. The end is exclusive, so
+// an interval ending exactly at midnight does not touch the next date; a
+// zero-length interval touches the date it sits on. Day boundaries come from
+// time.Date, so DST dates report their true 23 or 25 hour length.
+func (t *TimeServer) DatesSpanned(start, end, tz string) (DatesSpannedResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return DatesSpannedResult{}, err
+	}
+	from, err := t.parseDateTime(start, loc)
+	if err != nil {
+		return DatesSpannedResult{}, err
+	}
+	until, err := t.parseDateTime(end, loc)
+	if err != nil {
+		return DatesSpannedResult{}, err
+	}
+	if until.Before(from) {
+		return DatesSpannedResult{}, fmt.Errorf("start must not be after end")
+	}
+
+	res := DatesSpannedResult{Timezone: tz, Start: makeTimeResult(tz, from), End: makeTimeResult(tz, until)}
+	for d := startOfDay(from); d.Before(until) || len(res.Dates) == 0; {
+		next := time.Date(d.Year(), d.Month(), d.Day()+1, 0, 0, 0, 0, loc)
+		if len(res.Dates) == maxSpannedDates {
+			return DatesSpannedResult{}, fmt.Errorf("interval spans more than %d dates", maxSpannedDates)
+		}
+		lo, hi := d, next
+		if from.After(lo) {
+			lo = from
+		}
+		if until.Before(hi) {
+			hi = until
+		}
+		res.Dates = append(res.Dates, SpannedDate{
+			Date:         d.Format("2006-01-02"),
+			CoveredHours: hi.Sub(lo).Hours(),
+			DayHours:     next.Sub(d).Hours(),
+		})
+		d = next
+	}
+	res.Count = len(res.Dates)
+	return res, nil
+}
+
+// IntervalPerDay allocates [start, end) to the local calendar dates it
+// covers in tz, in minutes. Dates are cut at real local midnights, so a DST
+// date holds 23 or 25 hours and the per-date minutes always sum to the
+// elapsed total. Dates the interval covers for no time are omitted.
+func (t *TimeServer) IntervalPerDay(start, end, tz string) (IntervalPerDayResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return IntervalPerDayResult{}, err
+	}
+	from, err := t.parseDateTime(start, loc)
+	if err != nil {
+		return IntervalPerDayResult{}, err
+	}
+	until, err := t.parseDateTime(end, loc)
+	if err != nil {
+		return IntervalPerDayResult{}, err
+	}
+	if until.Before(from) {
+		return IntervalPerDayResult{}, fmt.Errorf("start must not be after end")
+	}
+
+	res := IntervalPerDayResult{
+		Timezone:     tz,
+		Start:        makeTimeResult(tz, from),
+		End:          makeTimeResult(tz, until),
+		TotalMinutes: until.Sub(from).Minutes(),
+		Minutes:      map[string]float64{},
+	}
+	for d := startOfDay(from.In(loc)); d.Before(until); {
+		next := time.Date(d.Year(), d.Month(), d.Day()+1, 0, 0, 0, 0, loc)
+		if len(res.Minutes) == maxSpannedDates {
+			return IntervalPerDayResult{}, fmt.Errorf("interval spans more than %d dates", maxSpannedDates)
+		}
+		lo, hi := d, next
+		if from.After(lo) {
+			lo = from
+		}
+		if until.Before(hi) {
+			hi = until
+		}
+		if hi.After(lo) {
+			res.Minutes[d.Format("2006-01-02")] = hi.Sub(lo).Minutes()
+		}
+		d = next
+	}
+	return res, nil
+}
+
+// WeekNumberSystems numbers the week containing datetime under three common
+// conventions. ISO 8601 weeks start on Monday and week 1 holds the year's
+// first Thursday, so early January can belong to the previous ISO year. US
+// weeks (Excel WEEKNUM type 1) start on Sunday with week 1 containing Jan 1,
+// and are cut at the year boundaries. Simple weeks count 7-day blocks from
+// Jan 1. Agree reports whether all three give the same year and week.
+func (t *TimeServer) WeekNumberSystems(datetime, tz string) (WeekNumberSystemsResult, error) {
+	_, loc, err := t.location(tz)
+	if err != nil {
+		return WeekNumberSystemsResult{}, err
+	}
+	tm, err := t.parseDateTime(datetime, loc)
+	if err != nil {
+		return WeekNumberSystemsResult{}, err
+	}
+	d := time.Date(tm.Year(), tm.Month(), tm.Day(), 0, 0, 0, 0, time.UTC)
+	jan1 := time.Date(d.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	dec31 := time.Date(d.Year(), time.December, 31, 0, 0, 0, 0, time.UTC)
+	yday := d.YearDay() - 1
+	date := func(x time.Time) string { return x.Format("2006-01-02") }
+	clamp := func(x time.Time) time.Time {
+		if x.Before(jan1) {
+			return jan1
+		}
+		if x.After(dec31) {
+			return dec31
+		}
+		return x
+	}
+
+	isoYear, isoWeek := d.ISOWeek()
+	monday := d.AddDate(0, 0, -mod(int(d.Weekday())-int(time.Monday), 7))
+	sunday := d.AddDate(0, 0, -int(d.Weekday()))
+	simpleStart := jan1.AddDate(0, 0, yday/7*7)
+
+	res := WeekNumberSystemsResult{Date: date(d), Weekday: d.Weekday().String()}
+	res.Systems = []WeekNumber{
+		{"iso8601", isoYear, isoWeek, date(monday), date(monday.AddDate(0, 0, 6)),
+			"weeks start Monday; week 1 contains the first Thursday of the year"},
+		{"us", d.Year(), (yday+int(jan1.Weekday()))/7 + 1, date(clamp(sunday)), date(clamp(sunday.AddDate(0, 0, 6))),
+			"weeks start Sunday; week 1 contains January 1"},
+		{"simple", d.Year(), yday/7 + 1, date(simpleStart), date(clamp(simpleStart.AddDate(0, 0, 6))),
+			"week 1 is January 1-7, week 2 January 8-14, and so on"},
+	}
+	res.Agree = true
+	for _, w := range res.Systems[1:] {
+		if w.Year != res.Systems[0].Year || w.Week != res.Systems[0].Week {
+			res.Agree = false
+		}
+	}
+	return res, nil
+}
+
+// DateDiff counts the calendar days from start's local date to end's local
+// date in tz, ignoring the time of day: 23:00 to 01:00 the next morning is
+// one day. The count is negative when end's date is earlier.
+func (t *TimeServer) DateDiff(start, end, tz string) (DateDiffResult, error) {
+	_, loc, err := t.location(tz)
+	if err != nil {
+		return DateDiffResult{}, err
+	}
+	from, err := t.parseDateTime(start, loc)
+	if err != nil {
+		return DateDiffResult{}, err
+	}
+	until, err := t.parseDateTime(end, loc)
+	if err != nil {
+		return DateDiffResult{}, err
+	}
+	days := daysBetween(from, until)
+	return DateDiffResult{
+		StartDate: from.Format("2006-01-02"),
+		EndDate:   until.Format("2006-01-02"),
+		Days:      days,
+		Weeks:     days / 7,
+		SameDate:  days == 0,
+	}, nil
+}
+
+// NextFriday13 scans forward month by month from today's local date in tz
+// for a 13th that falls on a Friday; today counts if it qualifies. It also
+// lists every Friday the 13th of the current year. A match exists within
+// 14 months of any date, so the scan always terminates quickly.
+func (t *TimeServer) NextFriday13(tz string) (Friday13Result, error) {
+	_, loc, err := t.location(tz)
+	if err != nil {
+		return Friday13Result{}, err
+	}
+	today := startOfDay(t.nowFunc().In(loc))
+	res := Friday13Result{Today: today.Format("2006-01-02")}
+	for m := time.January; m <= time.December; m++ {
+		if d := time.Date(today.Year(), m, 13, 0, 0, 0, 0, loc); d.Weekday() == time.Friday {
+			res.ThisYear = append(res.ThisYear, d.Format("2006-01-02"))
+		}
+	}
+	res.CountThisYear = len(res.ThisYear)
+
+	d := time.Date(today.Year(), today.Month(), 13, 0, 0, 0, 0, loc)
+	if d.Before(today) {
+		d = d.AddDate(0, 1, 0)
+	}
+	for d.Weekday() != time.Friday {
+		d = d.AddDate(0, 1, 0)
+	}
+	res.Next = d.Format("2006-01-02")
+	res.DaysUntil = daysBetween(today, d)
+	res.IsToday = res.DaysUntil == 0
+	return res, nil
+}
+
+// FromISOWeek is the inverse of time.ISOWeek: it returns the instant at
+// clock on the given weekday of ISO week week in weekYear, in tz. Week 1 is
+// the week containing January 4, so it may start in late December. Week 53
+// is rejected in 52-week years. When iso is set ("2025-W20-3") it supplies
+// the year, week and (optionally) weekday instead.
+func (t *TimeServer) FromISOWeek(weekYear, week int, weekday, clock, iso, tz string) (FromISOWeekResult, error) {
+	if iso != "" {
+		m := isoWeekRe.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(iso)))
+		if m == nil {
+			return FromISOWeekResult{}, fmt.Errorf("iso week must look like 2025-W20-3: %s", iso)
+		}
+		weekYear, _ = atoiStrict(m[1])
+		week, _ = atoiStrict(m[2])
+		if m[3] != "" {
+			weekday = m[3]
+		}
+	}
+	if weekday == "" {
+		weekday = "1"
+	}
+	wd, err := parseWeekday(weekday)
+	if err != nil {
+		return FromISOWeekResult{}, err
+	}
+	if weekYear < 1 || weekYear > 9999 {
+		return FromISOWeekResult{}, fmt.Errorf("week year out of range: %d", weekYear)
+	}
+	weeks := isoWeeksIn(weekYear)
+	if week < 1 || week > weeks {
+		return FromISOWeekResult{}, fmt.Errorf("week %d out of range: %d has %d ISO weeks", week, weekYear, weeks)
+	}
+	if clock == "" {
+		clock = "00:00"
+	}
+	c, err := parseClock(clock)
+	if err != nil {
+		return FromISOWeekResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return FromISOWeekResult{}, err
+	}
+
+	jan4 := time.Date(weekYear, time.January, 4, 0, 0, 0, 0, loc)
+	week1 := jan4.AddDate(0, 0, -mod(int(jan4.Weekday())-int(time.Monday), 7))
+	isoDay := mod(int(wd)-int(time.Monday), 7) + 1
+	d := week1.AddDate(0, 0, 7*(week-1)+isoDay-1)
+	at := atClock(d, c)
+	return FromISOWeekResult{
+		ISO:         fmt.Sprintf("%04d-W%02d-%d", weekYear, week, isoDay),
+		Date:        d.Format("2006-01-02"),
+		Result:      makeTimeResult(tz, at),
+		WeeksInYear: weeks,
+	}, nil
+}
+
+// BillingDays counts the days between two local dates under a financial
+// day-count convention: "actual" (Actual/365 Fixed), "actual/360", "30/360"
+// (US bond basis) or "30e/360" (Eurobond). The year fraction is the day
+// count over the convention's basis.
+func (t *TimeServer) BillingDays(start, end, convention, tz string) (BillingDaysResult, error) {
+	convention = strings.ToLower(strings.TrimSpace(convention))
+	if convention == "" {
+		convention = "actual"
+	}
+	_, loc, err := t.location(tz)
+	if err != nil {
+		return BillingDaysResult{}, err
+	}
+	from, err := t.parseDateTime(start, loc)
+	if err != nil {
+		return BillingDaysResult{}, err
+	}
+	until, err := t.parseDateTime(end, loc)
+	if err != nil {
+		return BillingDaysResult{}, err
+	}
+
+	res := BillingDaysResult{
+		PeriodStart: from.Format("2006-01-02"),
+		PeriodEnd:   until.Format("2006-01-02"),
+		Convention:  convention,
+		ActualDays:  daysBetween(from, until),
+	}
+	switch convention {
+	case "actual", "actual/365":
+		res.Days, res.Basis = res.ActualDays, 365
+	case "actual/360":
+		res.Days, res.Basis = res.ActualDays, 360
+	case "30/360":
+		res.Days, res.Basis = days30360(from, until, false), 360
+	case "30e/360":
+		res.Days, res.Basis = days30360(from, until, true), 360
+	default:
+		return BillingDaysResult{}, fmt.Errorf("convention must be actual, actual/360, 30/360 or 30e/360: %s", convention)
+	}
+	res.YearFraction = float64(res.Days) / float64(res.Basis)
+	return res, nil
+}
+
+// NextRenewal returns the first renewal strictly after the reference instant
+// for a subscription that started at anchor. Under the default "preserve"
+// policy every renewal is computed from the anchor, so a plan started on the
+// 31st renews on Feb 28 and is back on the 31st in March. Under "sticky"
+// each renewal is computed from the previous one, so the clamped day sticks
+// (31st, 28th, 28th, ...). A reference before the anchor yields the anchor.
+func (t *TimeServer) NextRenewal(anchor, cycle, policy, reference, tz string) (NextRenewalResult, error) {
+	cycle = strings.ToLower(strings.TrimSpace(cycle))
+	period, ok := renewalCycles[cycle]
+	if !ok {
+		return NextRenewalResult{}, fmt.Errorf("cycle must be weekly, monthly, quarterly or annual: %s", cycle)
+	}
+	policy = strings.ToLower(strings.TrimSpace(policy))
+	if policy == "" {
+		policy = "preserve"
+	}
+	if policy != "preserve" && policy != "sticky" {
+		return NextRenewalResult{}, fmt.Errorf("policy must be preserve or sticky: %s", policy)
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return NextRenewalResult{}, err
+	}
+	start, err := t.parseDateTime(anchor, loc)
+	if err != nil {
+		return NextRenewalResult{}, err
+	}
+	ref, err := t.parseDateTime(reference, loc)
+	if err != nil {
+		return NextRenewalResult{}, err
+	}
+
+	prev, next, k := start, start, 0
+	for !next.After(ref) {
+		if k == maxRenewalCycles {
+			return NextRenewalResult{}, fmt.Errorf("reference is more than %d cycles after the anchor", maxRenewalCycles)
+		}
+		k++
+		prev = next
+		if policy == "sticky" {
+			next = periodBoundary(prev, period, 1)
+		} else {
+			next = periodBoundary(start, period, k)
+		}
+	}
+
+	res := NextRenewalResult{
+		Anchor:      makeTimeResult(tz, start),
+		Cycle:       cycle,
+		Policy:      policy,
+		Reference:   makeTimeResult(tz, ref),
+		CycleNumber: k,
+		NextRenewal: makeTimeResult(tz, next),
+		DaysUntil:   daysBetween(ref, next),
+		AnchorDay:   start.Day(),
+		RenewalDay:  next.Day(),
+	}
+	// Only a renewal pulled back to the last day of a shorter month counts
+	// as clamped; a sticky 28th in April is a regular renewal day.
+	res.ClampedToEnd = period != "week" && next.Day() != start.Day() && next.Day() == daysIn(next.Year(), next.Month())
+	if k > 0 {
+		cs, ce := makeTimeResult(tz, prev), makeTimeResult(tz, next)
+		res.CycleStart, res.CycleEnd = &cs, &ce
+	}
+	return res, nil
+}
+
+// Milestone lists the upcoming milestones of a start date as seen from
+// today's local date in tz: the next multiple of 1000 and of 10000 days, the
+// next whole-year anniversary (Feb 29 starts clamp to Feb 28) and the next
+// novelty day count from funDayCounts. A milestone falling today counts as
+// upcoming. The list is ordered by days until each milestone.
+func (t *TimeServer) Milestone(start, tz string) (MilestoneResult, error) {
+	_, loc, err := t.location(tz)
+	if err != nil {
+		return MilestoneResult{}, err
+	}
+	from, err := t.parseDateTime(start, loc)
+	if err != nil {
+		return MilestoneResult{}, err
+	}
+	from = startOfDay(from)
+	today := startOfDay(t.nowFunc().In(loc))
+	elapsed := daysBetween(from, today)
+	if elapsed < 0 {
+		return MilestoneResult{}, fmt.Errorf("start must not be in the future: %s", from.Format("2006-01-02"))
+	}
+
+	res := MilestoneResult{Start: from.Format("2006-01-02"), Today: today.Format("2006-01-02"), DaysElapsed: elapsed}
+	add := func(kind, label string, d time.Time) {
+		res.Milestones = append(res.Milestones, Milestone{
+			Kind:      kind,
+			Label:     label,
+			Date:      d.Format("2006-01-02"),
+			DaysSince: daysBetween(from, d),
+			DaysUntil: daysBetween(today, d),
+		})
+	}
+
+	thousands, tenThousands := nextMultiple(elapsed, 1000), nextMultiple(elapsed, 10000)
+	if thousands != tenThousands {
+		add("day_count", fmt.Sprintf("%d days", thousands), from.AddDate(0, 0, thousands))
+	}
+	add("day_count", fmt.Sprintf("%d days", tenThousands), from.AddDate(0, 0, tenThousands))
+
+	years := today.Year() - from.Year()
+	if years < 1 || addMonthsClamped(from, 12*years).Before(today) {
+		years++
+	}
+	suffix := "s"
+	if years == 1 {
+		suffix = ""
+	}
+	add("anniversary", fmt.Sprintf("%d year%s", years, suffix), addMonthsClamped(from, 12*years))
+
+	for _, f := range funDayCounts {
+		if f.days >= elapsed {
+			add("fun", f.label, from.AddDate(0, 0, f.days))
+			break
+		}
+	}
+
+	sort.SliceStable(res.Milestones, func(i, j int) bool { return res.Milestones[i].DaysUntil < res.Milestones[j].DaysUntil })
+	return res, nil
+}
+
+// AcademicTerm places datetime's local date in a set of terms whose start
+// and end dates are both inclusive. Overlapping terms (a summer session
+// inside a long semester) are all reported as current, ordered by start.
+// Outside every term the status distinguishes a break between terms from
+// dates before the first or after the last, and the next term to start is
+// given whenever there is one.
+func (t *TimeServer) AcademicTerm(datetime, tz string, terms []TermSpec) (AcademicTermResult, error) {
+	if len(terms) == 0 {
+		return AcademicTermResult{}, fmt.Errorf("terms must not be empty")
+	}
+	_, loc, err := t.location(tz)
+	if err != nil {
+		return AcademicTermResult{}, err
+	}
+	at, err := t.parseDateTime(datetime, loc)
+	if err != nil {
+		return AcademicTermResult{}, err
+	}
+	today := startOfDay(at)
+
+	type span struct {
+		name       string
+		start, end time.Time
+	}
+	spans := make([]span, 0, len(terms))
+	for _, tm := range terms {
+		if strings.TrimSpace(tm.Name) == "" {
+			return AcademicTermResult{}, fmt.Errorf("every term needs a name")
+		}
+		start, err := t.parseDateTime(tm.Start, loc)
+		if err != nil {
+			return AcademicTermResult{}, err
+		}
+		end, err := t.parseDateTime(tm.End, loc)
+		if err != nil {
+			return AcademicTermResult{}, err
+		}
+		start, end = startOfDay(start), startOfDay(end)
+		if end.Before(start) {
+			return AcademicTermResult{}, fmt.Errorf("term %s ends before it starts", tm.Name)
+		}
+		spans = append(spans, span{tm.Name, start, end})
+	}
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+
+	res := AcademicTermResult{Date: today.Format("2006-01-02")}
+	var lastEnd time.Time
+	for _, sp := range spans {
+		switch {
+		case !today.Before(sp.start) && !today.After(sp.end):
+			res.Current = append(res.Current, ActiveTerm{
+				Name:          sp.name,
+				Start:         sp.start.Format("2006-01-02"),
+				End:           sp.end.Format("2006-01-02"),
+				LengthDays:    daysBetween(sp.start, sp.end) + 1,
+				DayOfTerm:     daysBetween(sp.start, today) + 1,
+				DaysRemaining: daysBetween(today, sp.end),
+			})
+		case sp.start.After(today):
+			if res.Next == nil {
+				res.Next = &UpcomingTerm{
+					Name:      sp.name,
+					Start:     sp.start.Format("2006-01-02"),
+					End:       sp.end.Format("2006-01-02"),
+					DaysUntil: daysBetween(today, sp.start),
+				}
+			}
+		case sp.end.After(lastEnd):
+			lastEnd, res.Previous = sp.end, sp.name
+		}
+	}
+	res.Overlap = len(res.Current) > 1
+	switch {
+	case len(res.Current) > 0:
+		res.Status, res.Previous = "in_term", ""
+	case res.Previous == "":
+		res.Status = "before_terms"
+	case res.Next == nil:
+		res.Status = "after_terms"
+	default:
+		res.Status = "break"
+	}
+	return res, nil
+}
+
+// TimeUntilNthWeekday finds the next occurrence strictly after now of the
+// n-th weekday (1-5, or -1 for the last) at clock, either of every month or
+// only of month when one is given ("the 3rd Thursday of November"). When
+// this month's occurrence has passed, or the month has no n-th such day, the
+// scan rolls forward to the next qualifying month.
+func (t *TimeServer) TimeUntilNthWeekday(n int, weekday, month, clock, tz, format string) (NthWeekdayResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return NthWeekdayResult{}, err
+	}
+	if n != -1 && (n < 1 || n > 5) {
+		return NthWeekdayResult{}, fmt.Errorf("occurrence must be 1-5 or -1 for the last: %d", n)
+	}
+	wd, err := parseWeekday(weekday)
+	if err != nil {
+		return NthWeekdayResult{}, err
+	}
+	var only time.Month
+	if month != "" {
+		if only, err = parseMonth(month); err != nil {
+			return NthWeekdayResult{}, err
+		}
+	}
+	if clock == "" {
+		clock = "00:00"
+	}
+	c, err := parseClock(clock)
+	if err != nil {
+		return NthWeekdayResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return NthWeekdayResult{}, err
+	}
+
+	now := t.nowFunc().In(loc)
+	// A 5th weekday occurs in some month of every year, so 24 months is
+	// ample. A fixed month is scanned year by year instead: a 5th weekday of
+	// February needs a leap year starting on that weekday, which can take a
+	// full 28-year calendar cycle to come round.
+	first, step, limit, horizon := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc), 1, 24, "24 months"
+	if only != 0 {
+		first, step, limit, horizon = time.Date(now.Year(), only, 1, 0, 0, 0, 0, loc), 12, 29, "28 years"
+	}
+	for i := 0; i < limit; i++ {
+		m := first.AddDate(0, i*step, 0)
+		d, ok := nthWeekday(m.Year(), m.Month(), wd, n, loc)
+		if !ok {
+			continue
+		}
+		at := atClock(d, c)
+		if !at.After(now) {
+			continue
+		}
+		until := at.Sub(now)
+		return NthWeekdayResult{
+			Description:  fmt.Sprintf("%s %s of %s %d", ordinal(n), wd, m.Month(), m.Year()),
+			Occurrence:   makeTimeResult(tz, at),
+			Date:         d.Format("2006-01-02"),
+			TimeUntil:    render(until),
+			SecondsUntil: until.Seconds(),
+			DaysUntil:    daysBetween(now, at),
+			RolledOver:   m.Year() != now.Year() || m.Month() != now.Month(),
+		}, nil
+	}
+	return NthWeekdayResult{}, fmt.Errorf("no %s %s found in the next %s", ordinal(n), wd, horizon)
+}
+
+// RangeBreakdown counts each weekday among the local calendar dates from
+// start to end, both dates included, and totals them as weekdays (Monday to
+// Friday) and weekend days. Whole weeks contribute one of each day, so only
+// the leftover days are walked and long ranges cost nothing extra.
+func (t *TimeServer) RangeBreakdown(start, end, tz string) (RangeBreakdownResult, error) {
+	_, loc, err := t.location(tz)
+	if err != nil {
+		return RangeBreakdownResult{}, err
+	}
+	from, err := t.parseDateTime(start, loc)
+	if err != nil {
+		return RangeBreakdownResult{}, err
+	}
+	until, err := t.parseDateTime(end, loc)
+	if err != nil {
+		return RangeBreakdownResult{}, err
+	}
+	n := daysBetween(from, until) + 1
+	if n < 1 {
+		return RangeBreakdownResult{}, fmt.Errorf("end must not be before start")
+	}
+
+	counts := [7]int{}
+	for wd := range counts {
+		counts[wd] = n / 7
+	}
+	for i := 0; i < n%7; i++ {
+		counts[(int(from.Weekday())+i)%7]++
+	}
+	res := RangeBreakdownResult{
+		Start:     from.Format("2006-01-02"),
+		End:       until.Format("2006-01-02"),
+		TotalDays: n,
+		ByWeekday: make(map[string]int, 7),
+		Inclusive: "both start and end dates are counted",
+	}
+	for wd, c := range counts {
+		res.ByWeekday[strings.ToLower(time.Weekday(wd).String())] = c
+		if wd == int(time.Saturday) || wd == int(time.Sunday) {
+			res.Weekends += c
+		} else {
+			res.Weekdays += c
+		}
+	}
+	return res, nil
+}
+
+// DayInfo describes the local calendar date of date in tz. Near New Year
+// the ISO year can differ from the calendar year: 2024-12-30 is in week 1
+// of 2025, and 2027-01-01 in week 53 of 2026.
+func (t *TimeServer) DayInfo(date, tz string) (DayInfo, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return DayInfo{}, err
+	}
+	tm, err := t.parseDateTime(date, loc)
+	if err != nil {
+		return DayInfo{}, err
+	}
+	d := time.Date(tm.Year(), tm.Month(), tm.Day(), 0, 0, 0, 0, time.UTC)
+	isoYear, isoWeek := d.ISOWeek()
+	monthEnd := time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+	return DayInfo{
+		Date:            d.Format("2006-01-02"),
+		Timezone:        tz,
+		Weekday:         d.Weekday().String(),
+		WeekdayNumber:   mod(int(d.Weekday())-1, 7) + 1,
+		DayOfYear:       d.YearDay(),
+		ISOWeek:         isoWeek,
+		ISOYear:         isoYear,
+		Quarter:         (int(d.Month())-1)/3 + 1,
+		IsWeekend:       d.Weekday() == time.Saturday || d.Weekday() == time.Sunday,
+		DaysLeftInMonth: monthEnd.Day() - d.Day(),
+	}, nil
+}
+
+/* ----- tools ----- */
+
+func registerCalendarTools(s *server.MCPServer, ts *TimeServer) {
+	periods := mcp.NewTool(
+		"period_count",
+		mcp.WithDescription("Count full weeks, months, quarters or years between two instants plus the fractional remainder of the partial period."),
+		mcp.WithString("start", mcp.Required()),
+		mcp.WithString("end", mcp.Required()),
+		mcp.WithString("period", mcp.Required(), mcp.Enum("week", "month", "quarter", "year")),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(periods, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start, err := r.RequireString("start")
+		if err != nil {
+			return errorResult(err)
+		}
+		end, err := r.RequireString("end")
+		if err != nil {
+			return errorResult(err)
+		}
+		period, err := r.RequireString("period")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.PeriodCount(start, end, period, r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	midnights := mcp.NewTool(
+		"midnights",
+		mcp.WithDescription("The most recent and the next local midnight in a timezone, as RFC3339 and Unix seconds."),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(midnights, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := ts.Midnights(r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	dayFraction := mcp.NewTool(
+		"time_to_day_fraction",
+		mcp.WithDescription("The instant at a given fraction of today's local day (0.25 is 06:00 on a 24-hour day) and the time until it, or since it passed. Fractions are of elapsed time, so DST days map nonlinearly to the wall clock."),
+		mcp.WithNumber("fraction", mcp.Required(), mcp.Description("Fraction of the day, 0.0-1.0.")),
+		mcp.WithString("timezone"),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(dayFraction, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		fraction, err := r.RequireFloat("fraction")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.TimeToDayFraction(fraction, r.GetString("timezone", ""), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	spanned := mcp.NewTool(
+		"dates_spanned",
+		mcp.WithDescription("The distinct local calendar dates an interval touches, with the hours covered on each. The end is exclusive: 23:30-00:30 spans two dates, 23:00-00:00 one."),
+		mcp.WithString("start", mcp.Required()),
+		mcp.WithString("end", mcp.Required()),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(spanned, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start, err := r.RequireString("start")
+		if err != nil {
+			return errorResult(err)
+		}
+		end, err := r.RequireString("end")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.DatesSpanned(start, end, r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	perDay := mcp.NewTool(
+		"interval_per_day",
+		mcp.WithDescription("Minutes of an interval falling on each local calendar date, for per-day cost or usage allocation. DST dates are cut at real midnights, so the minutes sum to the total."),
+		mcp.WithString("start", mcp.Required()),
+		mcp.WithString("end", mcp.Required()),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(perDay, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start, err := r.RequireString("start")
+		if err != nil {
+			return errorResult(err)
+		}
+		end, err := r.RequireString("end")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.IntervalPerDay(start, end, r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	weeks := mcp.NewTool(
+		"week_number_systems",
+		mcp.WithDescription("Week number of a date under ISO 8601, US (Sunday start, week 1 contains Jan 1) and simple (week 1 = Jan 1-7) conventions, side by side."),
+		mcp.WithString("datetime", mcp.Description("Defaults to now.")),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(weeks, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := ts.WeekNumberSystems(r.GetString("datetime", ""), r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	dateDiff := mcp.NewTool(
+		"date_diff",
+		mcp.WithDescription("Signed number of calendar days between two dates, ignoring the time of day: 2025-03-01 23:00 to 2025-03-02 01:00 is 1 day."),
+		mcp.WithString("start", mcp.Required()),
+		mcp.WithString("end", mcp.Required()),
+		mcp.WithString("timezone", mcp.Description("Zone whose calendar dates are compared.")),
+	)
+
+	s.AddTool(dateDiff, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start, err := r.RequireString("start")
+		if err != nil {
+			return errorResult(err)
+		}
+		end, err := r.RequireString("end")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.DateDiff(start, end, r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	friday13 := mcp.NewTool(
+		"next_friday_13",
+		mcp.WithDescription("The next Friday the 13th from today, plus every Friday the 13th in the current year."),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(friday13, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := ts.NextFriday13(r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	fromWeek := mcp.NewTool(
+		"from_iso_week",
+		mcp.WithDescription("Resolve an ISO week reference (week-year, week, weekday, e.g. 2025-W20-3) to a concrete instant. Rejects week 53 in 52-week years."),
+		mcp.WithNumber("week_year"),
+		mcp.WithNumber("week"),
+		mcp.WithString("weekday", mcp.Description("Day name or ISO number 1 (Monday) to 7 (Sunday). Default Monday.")),
+		mcp.WithString("time", mcp.Description("Local time of day HH:MM (default 00:00).")),
+		mcp.WithString("iso", mcp.Description("Alternative to the fields above, e.g. 2025-W20-3.")),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(fromWeek, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := ts.FromISOWeek(r.GetInt("week_year", 0), r.GetInt("week", 0), r.GetString("weekday", ""),
+			r.GetString("time", ""), r.GetString("iso", ""), r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	billing := mcp.NewTool(
+		"billing_days",
+		mcp.WithDescription("Days to bill between two dates under a day-count convention: actual (Actual/365), actual/360, 30/360 (US bond basis) or 30e/360, with the year fraction."),
+		mcp.WithString("period_start", mcp.Required()),
+		mcp.WithString("period_end", mcp.Required()),
+		mcp.WithString("convention", mcp.Enum("actual", "actual/360", "30/360", "30e/360")),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(billing, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start, err := r.RequireString("period_start")
+		if err != nil {
+			return errorResult(err)
+		}
+		end, err := r.RequireString("period_end")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.BillingDays(start, end, r.GetString("convention", "actual"), r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	renewal := mcp.NewTool(
+		"next_renewal",
+		mcp.WithDescription("Next renewal of a subscription after a reference time, with the current billing cycle. Monthly plans anchored on the 29th-31st clamp in short months and, by default, return to the anchor day afterwards."),
+		mcp.WithString("anchor", mcp.Required(), mcp.Description("Subscription start.")),
+		mcp.WithString("cycle", mcp.Required(), mcp.Enum("weekly", "monthly", "quarterly", "annual")),
+		mcp.WithString("policy", mcp.Enum("preserve", "sticky"), mcp.Description("preserve (default) returns to the anchor day after a short month; sticky keeps the clamped day.")),
+		mcp.WithString("reference", mcp.Description("Defaults to now.")),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(renewal, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		anchor, err := r.RequireString("anchor")
+		if err != nil {
+			return errorResult(err)
+		}
+		cycle, err := r.RequireString("cycle")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.NextRenewal(anchor, cycle, r.GetString("policy", ""), r.GetString("reference", ""), r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	milestone := mcp.NewTool(
+		"milestone",
+		mcp.WithDescription("Upcoming milestones since a start date (relationship, employment, birth): the next 1000 and 10000 day counts, the next whole-year anniversary and the next novelty day count such as π × 1000 days, ranked by days until each."),
+		mcp.WithString("start", mcp.Required()),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(milestone, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start, err := r.RequireString("start")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.Milestone(start, r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	term := mcp.NewTool(
+		"academic_term",
+		mcp.WithDescription("Which academic term a date falls in, with day of term and days remaining, or the break it is in and the next term to start. Term dates are inclusive; overlapping terms are all reported."),
+		mcp.WithString("datetime", mcp.Description("Defaults to now.")),
+		mcp.WithString("timezone"),
+		mcp.WithArray("terms", mcp.Required(), mcp.Items(map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"name":  map[string]any{"type": "string"},
+				"start": map[string]any{"type": "string", "description": "First day, YYYY-MM-DD"},
+				"end":   map[string]any{"type": "string", "description": "Last day, YYYY-MM-DD"},
+			},
+			"required": []string{"name", "start", "end"},
+		})),
+	)
+
+	s.AddTool(term, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			Datetime string     `json:"datetime"`
+			Timezone string     `json:"timezone"`
+			Terms    []TermSpec `json:"terms"`
+		}
+		if err := r.BindArguments(&args); err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.AcademicTerm(args.Datetime, args.Timezone, args.Terms)
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	nth := mcp.NewTool(
+		"time_until_nth_weekday",
+		mcp.WithDescription("Time until the next occurrence of the n-th weekday of a month, e.g. the 2nd Tuesday of every month or the 4th Thursday of November, rolling forward when this month's has passed."),
+		mcp.WithNumber("occurrence", mcp.Required(), mcp.Description("1-5, or -1 for the last.")),
+		mcp.WithString("weekday", mcp.Required()),
+		mcp.WithString("month", mcp.Description("Restrict to one month (name or 1-12). Defaults to every month.")),
+		mcp.WithString("time", mcp.Description("Local time of day HH:MM (default 00:00).")),
+		mcp.WithString("timezone"),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(nth, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		n, err := r.RequireInt("occurrence")
+		if err != nil {
+			return errorResult(err)
+		}
+		weekday, err := r.RequireString("weekday")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.TimeUntilNthWeekday(n, weekday, r.GetString("month", ""), r.GetString("time", ""), r.GetString("timezone", ""), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	breakdown := mcp.NewTool(
+		"range_breakdown",
+		mcp.WithDescription("How many Mondays, Tuesdays, ... fall in a date range (both ends included), with weekday and weekend totals."),
+		mcp.WithString("start", mcp.Required()),
+		mcp.WithString("end", mcp.Required()),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(breakdown, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start, err := r.RequireString("start")
+		if err != nil {
+			return errorResult(err)
+		}
+		end, err := r.RequireString("end")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.RangeBreakdown(start, end, r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	dayInfo := mcp.NewTool(
+		"get_day_info",
+		mcp.WithDescription("Calendar facts about a date: weekday name and ISO number, day of year, ISO week and week-year, quarter, weekend flag and days left in the month."),
+		mcp.WithString("date", mcp.Description("RFC3339 or YYYY-MM-DD; defaults to today in timezone.")),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(dayInfo, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := ts.DayInfo(r.GetString("date", ""), r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+}
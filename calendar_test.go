@@ -0,0 +1,623 @@
+// calendar_test.go
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPeriodCount(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	cases := []struct {
+		name      string
+		start     string
+		end       string
+		period    string
+		wantFull  int
+		wantTotal float64
+	}{
+		{"exactMonths", "2025-01-15", "2025-04-15", "month", 3, 3},
+		// 14 days into a 28-day February is half a month.
+		{"halfFebruary", "2025-01-01", "2025-02-15", "month", 1, 1.5},
+		// Anchored on the 31st the partial period runs Feb 28 to Mar 31.
+		{"clampedAnchor", "2025-01-31", "2025-03-14", "month", 1, 1 + 14.0/31},
+		{"quarterAndHalf", "2025-01-01", "2025-05-16 12:00", "quarter", 1, 1 + 45.5/91},
+		{"shorterThanPeriod", "2025-01-01", "2025-01-04 12:00", "week", 0, 0.5},
+		{"reversed", "2026-01-01", "2025-01-01", "year", -1, -1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.PeriodCount(tc.start, tc.end, tc.period, "UTC")
+			if err != nil {
+				t.Fatalf("PeriodCount error: %v", err)
+			}
+			if res.FullPeriods != tc.wantFull || math.Abs(res.Total-tc.wantTotal) > 1e-9 {
+				t.Errorf("got full=%d total=%v, want full=%d total=%v", res.FullPeriods, res.Total, tc.wantFull, tc.wantTotal)
+			}
+		})
+	}
+
+	if _, err := ts.PeriodCount("2025-01-01", "2025-02-01", "fortnight", "UTC"); err == nil {
+		t.Errorf("expected error for unsupported period, got nil")
+	}
+}
+
+func TestAddMonthsClamped(t *testing.T) {
+	jan31 := time.Date(2024, time.January, 31, 10, 0, 0, 0, time.UTC)
+	if got := addMonthsClamped(jan31, 1); got.Month() != time.February || got.Day() != 29 {
+		t.Errorf("Jan 31 + 1 month = %v, want Feb 29 (leap year)", got)
+	}
+	if got := addMonthsClamped(jan31, 2); got.Month() != time.March || got.Day() != 31 {
+		t.Errorf("Jan 31 + 2 months = %v, want Mar 31", got)
+	}
+}
+
+func TestMidnights(t *testing.T) {
+	locNY, _ := time.LoadLocation("America/New_York")
+	ts := NewTimeServer("UTC")
+
+	// 2025-03-09 is the spring-forward day in New York: 23 hours long.
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 3, 9, 15, 0, 0, 0, locNY) })
+	res, err := ts.Midnights("America/New_York")
+	if err != nil {
+		t.Fatalf("Midnights error: %v", err)
+	}
+	if res.PreviousMidnight.Datetime != "2025-03-09T00:00:00-05:00" || res.NextMidnight.Datetime != "2025-03-10T00:00:00-04:00" {
+		t.Errorf("got %s .. %s, want local midnights on 03-09 and 03-10", res.PreviousMidnight.Datetime, res.NextMidnight.Datetime)
+	}
+	if res.DayLengthHours != 23 || res.NextUnix-res.PreviousUnix != 23*3600 {
+		t.Errorf("expected a 23-hour day, got %v hours", res.DayLengthHours)
+	}
+
+	// Exactly at midnight the previous midnight is now.
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC) })
+	res, err = ts.Midnights("UTC")
+	if err != nil {
+		t.Fatalf("Midnights error: %v", err)
+	}
+	if res.PreviousMidnight.Datetime != "2025-06-01T00:00:00Z" || res.NextMidnight.Datetime != "2025-06-02T00:00:00Z" {
+		t.Errorf("got %s .. %s at midnight", res.PreviousMidnight.Datetime, res.NextMidnight.Datetime)
+	}
+}
+
+func TestDatesSpanned(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	cases := []struct {
+		name       string
+		start, end string
+		wantCount  int
+		wantFirst  string
+	}{
+		{"acrossMidnight", "2025-05-14 23:30", "2025-05-15 00:30", 2, "2025-05-14"},
+		{"tenMinutes", "2025-05-14 10:00", "2025-05-14 10:10", 1, "2025-05-14"},
+		{"endsAtMidnight", "2025-05-14 23:00", "2025-05-15 00:00", 1, "2025-05-14"},
+		{"instant", "2025-05-14 10:00", "2025-05-14 10:00", 1, "2025-05-14"},
+		{"threeDays", "2025-05-14 12:00", "2025-05-16 12:00", 3, "2025-05-14"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.DatesSpanned(tc.start, tc.end, "America/New_York")
+			if err != nil {
+				t.Fatalf("DatesSpanned error: %v", err)
+			}
+			if res.Count != tc.wantCount || res.Dates[0].Date != tc.wantFirst {
+				t.Errorf("got %d dates starting %s, want %d starting %s", res.Count, res.Dates[0].Date, tc.wantCount, tc.wantFirst)
+			}
+		})
+	}
+
+	// New York's spring-forward date is 23 hours long.
+	res, err := ts.DatesSpanned("2025-03-08 12:00", "2025-03-10 12:00", "America/New_York")
+	if err != nil {
+		t.Fatalf("DatesSpanned error: %v", err)
+	}
+	if res.Dates[1].Date != "2025-03-09" || res.Dates[1].DayHours != 23 || res.Dates[1].CoveredHours != 23 {
+		t.Errorf("DST date = %+v, want 2025-03-09 fully covered at 23h", res.Dates[1])
+	}
+
+	if _, err := ts.DatesSpanned("2025-05-15", "2025-05-14", "UTC"); err == nil {
+		t.Errorf("expected error when start is after end, got nil")
+	}
+}
+
+func TestWeekNumberSystems(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	cases := []struct {
+		date                   string
+		iso, us, simple        int
+		wantISOYear            int
+		wantUSStart, wantUSEnd string
+	}{
+		// Wednesday: ISO week 1 of 2025 began on Monday 2024-12-30.
+		{"2025-01-01", 1, 1, 1, 2025, "2025-01-01", "2025-01-04"},
+		{"2024-12-30", 1, 53, 53, 2025, "2024-12-29", "2024-12-31"},
+		// Friday 2021-01-01 still belongs to ISO week 53 of 2020.
+		{"2021-01-01", 53, 1, 1, 2020, "2021-01-01", "2021-01-02"},
+		{"2021-01-03", 53, 2, 1, 2020, "2021-01-03", "2021-01-09"},
+		{"2025-05-14", 20, 20, 20, 2025, "2025-05-11", "2025-05-17"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.date, func(t *testing.T) {
+			res, err := ts.WeekNumberSystems(tc.date, "UTC")
+			if err != nil {
+				t.Fatalf("WeekNumberSystems error: %v", err)
+			}
+			iso, us, simple := res.Systems[0], res.Systems[1], res.Systems[2]
+			if iso.Week != tc.iso || iso.Year != tc.wantISOYear || us.Week != tc.us || simple.Week != tc.simple {
+				t.Errorf("got iso %d-W%d us %d simple %d, want iso %d-W%d us %d simple %d",
+					iso.Year, iso.Week, us.Week, simple.Week, tc.wantISOYear, tc.iso, tc.us, tc.simple)
+			}
+			if us.WeekStart != tc.wantUSStart || us.WeekEnd != tc.wantUSEnd {
+				t.Errorf("US week = %s..%s, want %s..%s", us.WeekStart, us.WeekEnd, tc.wantUSStart, tc.wantUSEnd)
+			}
+		})
+	}
+}
+
+func TestDateDiff(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	cases := []struct {
+		start, end string
+		tz         string
+		want       int
+	}{
+		{"2025-03-01 23:00", "2025-03-02 01:00", "UTC", 1},
+		{"2025-03-02 01:00", "2025-03-01 23:00", "UTC", -1},
+		{"2025-03-01 00:00", "2025-03-01 23:59", "UTC", 0},
+		{"2024-02-28", "2024-03-01", "UTC", 2},
+		// The same instants fall on one Tokyo date.
+		{"2025-03-01T23:00:00Z", "2025-03-02T01:00:00Z", "Asia/Tokyo", 0},
+		// Spring-forward day still counts as one date boundary.
+		{"2025-03-08 12:00", "2025-03-10 12:00", "America/New_York", 2},
+	}
+	for _, tc := range cases {
+		res, err := ts.DateDiff(tc.start, tc.end, tc.tz)
+		if err != nil {
+			t.Fatalf("DateDiff(%q, %q) error: %v", tc.start, tc.end, err)
+		}
+		if res.Days != tc.want {
+			t.Errorf("DateDiff(%q, %q, %s) = %d, want %d", tc.start, tc.end, tc.tz, res.Days, tc.want)
+		}
+	}
+
+	if _, err := ts.DateDiff("not a date", "2025-03-01", "UTC"); err == nil {
+		t.Errorf("expected error for an invalid date, got nil")
+	}
+}
+
+func TestNextFriday13(t *testing.T) {
+	cases := []struct {
+		now       time.Time
+		wantNext  string
+		wantCount int
+	}{
+		// 2025 has June 13 only; 2026 has February, March and November.
+		{time.Date(2025, 5, 14, 12, 0, 0, 0, time.UTC), "2025-06-13", 1},
+		{time.Date(2025, 6, 14, 12, 0, 0, 0, time.UTC), "2026-02-13", 1},
+		{time.Date(2026, 3, 13, 8, 0, 0, 0, time.UTC), "2026-03-13", 3},
+	}
+	for _, tc := range cases {
+		ts := NewTimeServer("UTC")
+		ts.forTesting_SetNowFunc(func() time.Time { return tc.now })
+		res, err := ts.NextFriday13("UTC")
+		if err != nil {
+			t.Fatalf("NextFriday13 error: %v", err)
+		}
+		if res.Next != tc.wantNext || res.CountThisYear != tc.wantCount {
+			t.Errorf("from %s: next %s (%d this year), want %s (%d)", tc.now.Format("2006-01-02"), res.Next, res.CountThisYear, tc.wantNext, tc.wantCount)
+		}
+	}
+}
+
+func TestFromISOWeek(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	cases := []struct {
+		iso      string
+		year     int
+		week     int
+		weekday  string
+		wantDate string
+	}{
+		{"2025-W20-3", 0, 0, "", "2025-05-14"},
+		{"", 2025, 1, "monday", "2024-12-30"},
+		{"", 2020, 53, "friday", "2021-01-01"},
+		{"2026W537", 0, 0, "", "2027-01-03"},
+		{"", 2021, 52, "7", "2022-01-02"},
+	}
+	for _, tc := range cases {
+		res, err := ts.FromISOWeek(tc.year, tc.week, tc.weekday, "09:30", tc.iso, "UTC")
+		if err != nil {
+			t.Fatalf("FromISOWeek(%q, %d, %d) error: %v", tc.iso, tc.year, tc.week, err)
+		}
+		if res.Date != tc.wantDate {
+			t.Errorf("FromISOWeek(%q, %d, %d, %s) = %s, want %s", tc.iso, tc.year, tc.week, tc.weekday, res.Date, tc.wantDate)
+		}
+	}
+
+	// Round-trip every day of a few years through time.ISOWeek.
+	for d := time.Date(2019, 12, 25, 0, 0, 0, 0, time.UTC); d.Year() < 2027; d = d.AddDate(0, 0, 1) {
+		y, w := d.ISOWeek()
+		res, err := ts.FromISOWeek(y, w, d.Weekday().String(), "", "", "UTC")
+		if err != nil || res.Date != d.Format("2006-01-02") {
+			t.Fatalf("round trip of %s via %d-W%02d gave %s (%v)", d.Format("2006-01-02"), y, w, res.Date, err)
+		}
+	}
+
+	if _, err := ts.FromISOWeek(2025, 53, "1", "", "", "UTC"); err == nil {
+		t.Errorf("expected error for week 53 of 2025, got nil")
+	}
+}
+
+func TestBillingDays(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	cases := []struct {
+		start, end, convention string
+		want                   int
+	}{
+		{"2025-01-15", "2025-02-15", "actual", 31},
+		{"2025-01-15", "2025-02-15", "30/360", 30},
+		{"2025-01-31", "2025-03-31", "30/360", 60},
+		{"2025-01-30", "2025-03-31", "30/360", 60},
+		{"2025-01-29", "2025-03-31", "30/360", 62},
+		{"2025-01-29", "2025-03-31", "30e/360", 61},
+		// Last day of February counts as the 30th.
+		{"2025-02-28", "2025-03-31", "30/360", 30},
+		{"2024-02-29", "2025-02-28", "30/360", 360},
+		{"2024-02-28", "2024-03-31", "30/360", 33},
+		{"2025-01-01", "2025-07-01", "actual/360", 181},
+	}
+	for _, tc := range cases {
+		res, err := ts.BillingDays(tc.start, tc.end, tc.convention, "UTC")
+		if err != nil {
+			t.Fatalf("BillingDays error: %v", err)
+		}
+		if res.Days != tc.want {
+			t.Errorf("BillingDays(%s, %s, %s) = %d, want %d", tc.start, tc.end, tc.convention, res.Days, tc.want)
+		}
+	}
+
+	res, _ := ts.BillingDays("2025-01-01", "2025-07-01", "actual/360", "UTC")
+	if math.Abs(res.YearFraction-181.0/360) > 1e-12 {
+		t.Errorf("actual/360 year fraction = %v, want %v", res.YearFraction, 181.0/360)
+	}
+	if _, err := ts.BillingDays("2025-01-01", "2025-02-01", "bogus", "UTC"); err == nil {
+		t.Errorf("expected error for an unknown convention, got nil")
+	}
+}
+
+func TestNextRenewal(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	cases := []struct {
+		name, anchor, cycle, policy, ref string
+		want, wantCycleStart             string
+		clamped                          bool
+	}{
+		{"clampsFebruary", "2025-01-31", "monthly", "", "2025-02-10", "2025-02-28T00:00:00Z", "2025-01-31T00:00:00Z", true},
+		{"restoresAnchorDay", "2025-01-31", "monthly", "preserve", "2025-03-01", "2025-03-31T00:00:00Z", "2025-02-28T00:00:00Z", false},
+		{"stickyKeepsClamp", "2025-01-31", "monthly", "sticky", "2025-03-01", "2025-03-28T00:00:00Z", "2025-02-28T00:00:00Z", false},
+		{"stickyAprilNotClamped", "2025-01-31", "monthly", "sticky", "2025-04-05", "2025-04-28T00:00:00Z", "2025-03-28T00:00:00Z", false},
+		{"onRenewalDay", "2025-01-15", "monthly", "", "2025-03-15", "2025-04-15T00:00:00Z", "2025-03-15T00:00:00Z", false},
+		{"leapAnnual", "2024-02-29", "annual", "", "2025-01-01", "2025-02-28T00:00:00Z", "2024-02-29T00:00:00Z", true},
+		{"weekly", "2025-05-01", "weekly", "", "2025-05-14", "2025-05-15T00:00:00Z", "2025-05-08T00:00:00Z", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.NextRenewal(tc.anchor, tc.cycle, tc.policy, tc.ref, "UTC")
+			if err != nil {
+				t.Fatalf("NextRenewal error: %v", err)
+			}
+			if res.NextRenewal.Datetime != tc.want || res.CycleStart == nil || res.CycleStart.Datetime != tc.wantCycleStart {
+				t.Errorf("got next %s cycle start %v, want %s from %s", res.NextRenewal.Datetime, res.CycleStart, tc.want, tc.wantCycleStart)
+			}
+			if res.ClampedToEnd != tc.clamped {
+				t.Errorf("clamped_to_month_end = %v, want %v", res.ClampedToEnd, tc.clamped)
+			}
+		})
+	}
+
+	before, err := ts.NextRenewal("2025-06-01", "monthly", "", "2025-05-01", "UTC")
+	if err != nil {
+		t.Fatalf("NextRenewal error: %v", err)
+	}
+	if before.NextRenewal.Datetime != "2025-06-01T00:00:00Z" || before.CycleStart != nil {
+		t.Errorf("reference before anchor = %+v, want the anchor and no cycle", before)
+	}
+}
+
+func TestMilestone(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 12, 0, 0, 0, time.UTC) })
+
+	// 2022-08-01 to 2025-05-14 is 1017 days.
+	res, err := ts.Milestone("2022-08-01", "UTC")
+	if err != nil {
+		t.Fatalf("Milestone error: %v", err)
+	}
+	want := []struct{ label, date string }{
+		{"3 years", "2025-08-01"},
+		{"1111 days", "2025-08-16"},
+		{"2000 days", "2028-01-22"},
+		{"10000 days", "2049-12-17"},
+	}
+	if res.DaysElapsed != 1017 || len(res.Milestones) != len(want) {
+		t.Fatalf("got %d days elapsed and %+v", res.DaysElapsed, res.Milestones)
+	}
+	for i, w := range want {
+		if m := res.Milestones[i]; m.Label != w.label || m.Date != w.date {
+			t.Errorf("milestone %d = %+v, want %s on %s", i, m, w.label, w.date)
+		}
+	}
+
+	leap, err := ts.Milestone("2024-02-29", "UTC")
+	if err != nil {
+		t.Fatalf("Milestone error: %v", err)
+	}
+	for _, m := range leap.Milestones {
+		if m.Kind == "anniversary" && m.Date != "2026-02-28" {
+			t.Errorf("leap-day anniversary = %+v, want 2026-02-28", m)
+		}
+	}
+
+	if _, err := ts.Milestone("2025-06-01", "UTC"); err == nil {
+		t.Errorf("expected error for a future start, got nil")
+	}
+}
+
+func TestAcademicTerm(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	terms := []TermSpec{
+		{"Spring", "2025-01-13", "2025-05-09"},
+		{"Fall", "2025-08-25", "2025-12-12"},
+		{"Summer I", "2025-05-19", "2025-06-27"},
+		{"Summer Intensive", "2025-06-16", "2025-07-25"},
+	}
+	cases := []struct {
+		name, date, status string
+		current            []string
+		next, previous     string
+	}{
+		{"firstDay", "2025-01-13", "in_term", []string{"Spring"}, "Summer I", ""},
+		{"break", "2025-05-12", "break", nil, "Summer I", "Spring"},
+		{"overlap", "2025-06-20", "in_term", []string{"Summer I", "Summer Intensive"}, "Fall", ""},
+		{"before", "2025-01-01", "before_terms", nil, "Spring", ""},
+		{"after", "2025-12-20", "after_terms", nil, "", "Fall"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.AcademicTerm(tc.date, "UTC", terms)
+			if err != nil {
+				t.Fatalf("AcademicTerm error: %v", err)
+			}
+			var current []string
+			for _, c := range res.Current {
+				current = append(current, c.Name)
+			}
+			next := ""
+			if res.Next != nil {
+				next = res.Next.Name
+			}
+			if res.Status != tc.status || strings.Join(current, ",") != strings.Join(tc.current, ",") || next != tc.next || res.Previous != tc.previous {
+				t.Errorf("got %+v (next %q)", res, next)
+			}
+		})
+	}
+
+	res, err := ts.AcademicTerm("2025-01-13", "UTC", terms)
+	if err != nil {
+		t.Fatalf("AcademicTerm error: %v", err)
+	}
+	if c := res.Current[0]; c.DayOfTerm != 1 || c.DaysRemaining != 116 || c.LengthDays != 117 {
+		t.Errorf("first day of spring = %+v", c)
+	}
+
+	if _, err := ts.AcademicTerm("", "UTC", []TermSpec{{"Bad", "2025-05-01", "2025-04-01"}}); err == nil {
+		t.Errorf("expected error for a term ending before it starts, got nil")
+	}
+}
+
+func TestTimeUntilNthWeekday(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	// Wednesday, May 14 2025.
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 12, 0, 0, 0, time.UTC) })
+
+	cases := []struct {
+		name                 string
+		n                    int
+		weekday, month, time string
+		want                 string
+		rolled               bool
+	}{
+		{"laterToday", 2, "wednesday", "", "18:00", "2025-05-14T18:00:00Z", false},
+		{"passedRollsOver", 2, "tuesday", "", "", "2025-06-10T00:00:00Z", true},
+		{"specificMonth", 3, "thursday", "november", "", "2025-11-20T00:00:00Z", true},
+		{"last", -1, "fri", "", "", "2025-05-30T00:00:00Z", false},
+		{"fifthSkipsShortMonths", 5, "monday", "", "", "2025-06-30T00:00:00Z", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.TimeUntilNthWeekday(tc.n, tc.weekday, tc.month, tc.time, "UTC", "")
+			if err != nil {
+				t.Fatalf("TimeUntilNthWeekday error: %v", err)
+			}
+			if res.Occurrence.Datetime != tc.want || res.RolledOver != tc.rolled {
+				t.Errorf("got %s (rolled %v), want %s (rolled %v)", res.Occurrence.Datetime, res.RolledOver, tc.want, tc.rolled)
+			}
+		})
+	}
+
+	// From December 2025 the next November with five Fridays is 2029,
+	// beyond the 24-month scan used when no month is given.
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC) })
+	res, err := ts.TimeUntilNthWeekday(5, "friday", "november", "", "UTC", "")
+	if err != nil || res.Date != "2029-11-30" {
+		t.Errorf("5th Friday of November = %s, %v; want 2029-11-30", res.Date, err)
+	}
+	// A 5th Sunday in February needs a leap year starting on Sunday: 2032.
+	res, err = ts.TimeUntilNthWeekday(5, "sunday", "february", "", "UTC", "")
+	if err != nil || res.Date != "2032-02-29" {
+		t.Errorf("5th Sunday of February = %s, %v; want 2032-02-29", res.Date, err)
+	}
+
+	if _, err := ts.TimeUntilNthWeekday(6, "monday", "", "", "UTC", ""); err == nil {
+		t.Errorf("expected error for a 6th weekday, got nil")
+	}
+}
+
+func TestRangeBreakdown(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	// May 2025 starts on a Thursday: five Thursdays, Fridays and Saturdays.
+	res, err := ts.RangeBreakdown("2025-05-01", "2025-05-31", "UTC")
+	if err != nil {
+		t.Fatalf("RangeBreakdown error: %v", err)
+	}
+	if res.TotalDays != 31 || res.Weekdays != 22 || res.Weekends != 9 ||
+		res.ByWeekday["thursday"] != 5 || res.ByWeekday["saturday"] != 5 || res.ByWeekday["sunday"] != 4 {
+		t.Errorf("May 2025 = %+v", res)
+	}
+	sum := 0
+	for _, c := range res.ByWeekday {
+		sum += c
+	}
+	if sum != res.TotalDays {
+		t.Errorf("per-weekday counts sum to %d, want %d", sum, res.TotalDays)
+	}
+
+	// The same instant pair gives different dates in different zones.
+	single, err := ts.RangeBreakdown("2025-05-17T23:30:00Z", "2025-05-17T23:30:00Z", "Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("RangeBreakdown error: %v", err)
+	}
+	if single.TotalDays != 1 || single.ByWeekday["sunday"] != 1 {
+		t.Errorf("single Tokyo day = %+v", single)
+	}
+
+	if _, err := ts.RangeBreakdown("2025-05-02", "2025-05-01", "UTC"); err == nil {
+		t.Errorf("expected error for a reversed range, got nil")
+	}
+}
+
+func TestIntervalPerDay(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	cases := []struct {
+		name, start, end, tz string
+		total                float64
+		minutes              map[string]float64
+	}{
+		{"overnightShift", "2025-05-14T22:00:00", "2025-05-15T06:00:00", "Europe/London", 480,
+			map[string]float64{"2025-05-14": 120, "2025-05-15": 360}},
+		// The New York spring-forward date has only 23 hours.
+		{"springForward", "2025-03-08T12:00:00", "2025-03-10T12:00:00", "America/New_York", 47 * 60,
+			map[string]float64{"2025-03-08": 720, "2025-03-09": 23 * 60, "2025-03-10": 720}},
+		// The fall-back date has 25 hours.
+		{"fallBack", "2025-11-02T00:00:00", "2025-11-03T00:00:00", "America/New_York", 25 * 60,
+			map[string]float64{"2025-11-02": 25 * 60}},
+		{"empty", "2025-05-14T10:00:00Z", "2025-05-14T10:00:00Z", "UTC", 0, map[string]float64{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.IntervalPerDay(tc.start, tc.end, tc.tz)
+			if err != nil {
+				t.Fatalf("IntervalPerDay error: %v", err)
+			}
+			if res.TotalMinutes != tc.total {
+				t.Errorf("total = %v, want %v", res.TotalMinutes, tc.total)
+			}
+			if len(res.Minutes) != len(tc.minutes) {
+				t.Fatalf("minutes = %v, want %v", res.Minutes, tc.minutes)
+			}
+			var sum float64
+			for d, m := range tc.minutes {
+				if res.Minutes[d] != m {
+					t.Errorf("minutes[%s] = %v, want %v", d, res.Minutes[d], m)
+				}
+				sum += res.Minutes[d]
+			}
+			if sum != res.TotalMinutes {
+				t.Errorf("per-day sum = %v, total = %v", sum, res.TotalMinutes)
+			}
+		})
+	}
+
+	if _, err := ts.IntervalPerDay("2025-05-15T00:00:00Z", "2025-05-14T00:00:00Z", "UTC"); err == nil {
+		t.Error("expected error when end is before start")
+	}
+}
+
+func TestTimeToDayFraction(t *testing.T) {
+	cases := []struct {
+		name, now, tz string
+		fraction      float64
+		target, wall  string
+		passed        bool
+		delta         string
+		dayHours      float64
+	}{
+		{"quarter", "2025-05-14T03:00:00Z", "UTC", 0.25, "2025-05-14T06:00:00Z", "06:00:00", false, "3h0m0s", 24},
+		{"passed", "2025-05-14T18:00:00Z", "UTC", 0.5, "2025-05-14T12:00:00Z", "12:00:00", true, "6h0m0s", 24},
+		{"endOfDay", "2025-05-14T18:00:00Z", "UTC", 1, "2025-05-15T00:00:00Z", "00:00:00", false, "6h0m0s", 24},
+		// New York's spring-forward day lasts 23 hours: half of it is 11h30m
+		// after midnight, reading 12:30 on the jumped clock.
+		{"springForward", "2025-03-09T12:00:00Z", "America/New_York", 0.5, "2025-03-09T12:30:00-04:00", "12:30:00", false, "4h30m0s", 23},
+		{"fallBack", "2025-11-02T12:00:00Z", "America/New_York", 0.5, "2025-11-02T11:30:00-05:00", "11:30:00", false, "4h30m0s", 25},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := NewTimeServer("UTC")
+			now, _ := time.Parse(time.RFC3339, tc.now)
+			ts.forTesting_SetNowFunc(func() time.Time { return now })
+			res, err := ts.TimeToDayFraction(tc.fraction, tc.tz, "")
+			if err != nil {
+				t.Fatalf("TimeToDayFraction error: %v", err)
+			}
+			if res.Target.Datetime != tc.target || res.WallClock != tc.wall || res.Passed != tc.passed || res.Delta != tc.delta || res.DayLengthHours != tc.dayHours {
+				t.Errorf("got %s (%s) passed=%v delta=%s day=%vh, want %s (%s) passed=%v delta=%s day=%vh",
+					res.Target.Datetime, res.WallClock, res.Passed, res.Delta, res.DayLengthHours,
+					tc.target, tc.wall, tc.passed, tc.delta, tc.dayHours)
+			}
+		})
+	}
+
+	ts := NewTimeServer("UTC")
+	if _, err := ts.TimeToDayFraction(1.5, "UTC", ""); err == nil {
+		t.Error("expected error for fraction above 1")
+	}
+}
+
+func TestDayInfo(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 23, 30, 0, 0, time.UTC) })
+
+	cases := []struct {
+		name, date, tz string
+		want           DayInfo
+	}{
+		{"midMay", "2025-05-14", "UTC", DayInfo{"2025-05-14", "UTC", "Wednesday", 3, 134, 20, 2025, 2, false, 17}},
+		{"dec31InNextISOYear", "2024-12-31", "UTC", DayInfo{"2024-12-31", "UTC", "Tuesday", 2, 366, 1, 2025, 4, false, 0}},
+		{"dec31Week52", "2023-12-31", "UTC", DayInfo{"2023-12-31", "UTC", "Sunday", 7, 365, 52, 2023, 4, true, 0}},
+		{"jan1InPrevISOYear", "2027-01-01", "UTC", DayInfo{"2027-01-01", "UTC", "Friday", 5, 1, 53, 2026, 1, false, 30}},
+		{"week53", "2020-12-31", "UTC", DayInfo{"2020-12-31", "UTC", "Thursday", 4, 366, 53, 2020, 4, false, 0}},
+		{"leapDay", "2024-02-29", "UTC", DayInfo{"2024-02-29", "UTC", "Thursday", 4, 60, 9, 2024, 1, false, 0}},
+		// 23:30Z on the 14th is already the 15th in Tokyo.
+		{"todayInZone", "", "Asia/Tokyo", DayInfo{"2025-05-15", "Asia/Tokyo", "Thursday", 4, 135, 20, 2025, 2, false, 16}},
+		{"rfc3339LocalDate", "2025-03-01T02:00:00Z", "America/New_York", DayInfo{"2025-02-28", "America/New_York", "Friday", 5, 59, 9, 2025, 1, false, 0}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ts.DayInfo(tc.date, tc.tz)
+			if err != nil {
+				t.Fatalf("DayInfo(%q) error: %v", tc.date, err)
+			}
+			if got != tc.want {
+				t.Errorf("DayInfo(%q, %s) = %+v, want %+v", tc.date, tc.tz, got, tc.want)
+			}
+		})
+	}
+
+	if _, err := ts.DayInfo("not a date", "UTC"); err == nil {
+		t.Error("expected error for invalid date")
+	}
+}
@@ -0,0 +1,434 @@
+// clock.go
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+/* ----- data types ----- */
+
+type DriftCorrectResult struct {
+	Offset          string     `json:"offset"`
+	OffsetSeconds   float64    `json:"offset_seconds"`
+	DriftSecPerDay  float64    `json:"drift_seconds_per_day"`
+	DriftPPM        float64    `json:"drift_ppm"`
+	Direction       string     `json:"direction"` // "fast", "slow" or "accurate"
+	LastSync        string     `json:"last_sync"`
+	DeviceReading   string     `json:"device_reading"`
+	CorrectedTime   TimeResult `json:"corrected_time"`
+	CorrectionDelta string     `json:"correction_delta"`
+}
+
+type ClockSkewResult struct {
+	ReferenceTime    string  `json:"reference_time"`
+	ServerTime       string  `json:"server_time"`
+	SkewMilliseconds int64   `json:"skew_ms"` // server minus reference
+	SkewSeconds      float64 `json:"skew_seconds"`
+	Skew             string  `json:"skew"`
+	Magnitude        string  `json:"magnitude"`
+	Tolerance        string  `json:"tolerance"`
+	Assessment       string  `json:"assessment"` // "in sync", "ahead" or "behind"
+}
+
+type PalindromeTimesResult struct {
+	Timezone  string   `json:"timezone"`
+	Format    string   `json:"format"`
+	Start     string   `json:"start"`
+	End       string   `json:"end"`
+	Count     int      `json:"count"`
+	Times     []string `json:"times"`
+	Truncated bool     `json:"truncated,omitempty"`
+}
+
+type ClockPatternResult struct {
+	Pattern  string     `json:"pattern"`
+	Timezone string     `json:"timezone"`
+	From     TimeResult `json:"from"`
+	Display  string     `json:"display"`
+	Next     TimeResult `json:"next"`
+	UTC      string     `json:"utc"`
+	Wait     string     `json:"wait"`
+}
+
+/* ----- helpers ----- */
+
+// parseInstant reads an RFC3339 timestamp, which must carry its own offset.
+func parseInstant(name, s string) (time.Time, error) {
+	tm, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be an RFC3339 timestamp: %s", name, s)
+	}
+	return tm, nil
+}
+
+// isPalindrome reports whether s reads the same in both directions.
+func isPalindrome(s string) bool {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		if s[i] != s[j] {
+			return false
+		}
+	}
+	return true
+}
+
+/* ----- core methods ----- */
+
+// DriftCorrect models a clock that was set exactly elapsed ago and now reads
+// measured when the trusted time is trueTime. Drift is assumed linear, so
+// the device runs at a constant rate relative to true time; any later device
+// reading (deviceTime, default measured) is mapped back to true time by
+// undoing that rate from the last sync. Positive drift means the clock runs
+// fast.
+func (t *TimeServer) DriftCorrect(measured, trueTime, elapsed, deviceTime, tz, format string) (DriftCorrectResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return DriftCorrectResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return DriftCorrectResult{}, err
+	}
+	m, err := parseInstant("measured_time", measured)
+	if err != nil {
+		return DriftCorrectResult{}, err
+	}
+	truth, err := parseInstant("true_time", trueTime)
+	if err != nil {
+		return DriftCorrectResult{}, err
+	}
+	cd, _, err := parseDurationSpec(elapsed)
+	if err != nil {
+		return DriftCorrectResult{}, err
+	}
+	span, err := cd.fixed()
+	if err != nil {
+		return DriftCorrectResult{}, err
+	}
+	if span <= 0 {
+		return DriftCorrectResult{}, fmt.Errorf("elapsed must be positive")
+	}
+	device := m
+	if deviceTime != "" {
+		if device, err = parseInstant("device_time", deviceTime); err != nil {
+			return DriftCorrectResult{}, err
+		}
+	}
+
+	offset := m.Sub(truth)
+	rate := offset.Seconds() / span.Seconds() // device seconds gained per true second
+	sync := truth.Add(-span)
+	if 1+rate <= 0 {
+		// The device reading did not advance (or ran backwards) since the
+		// sync, so no linear rate maps it back to true time.
+		return DriftCorrectResult{}, fmt.Errorf("measured_time must be after the last sync (%s)", sync.Format(time.RFC3339))
+	}
+	corrected := sync.Add(time.Duration(float64(device.Sub(sync)) / (1 + rate)))
+
+	res := DriftCorrectResult{
+		Offset:          render(offset),
+		OffsetSeconds:   offset.Seconds(),
+		DriftSecPerDay:  rate * 86400,
+		DriftPPM:        rate * 1e6,
+		Direction:       "accurate",
+		LastSync:        sync.In(loc).Format(time.RFC3339),
+		DeviceReading:   device.In(loc).Format(time.RFC3339Nano),
+		CorrectedTime:   makeTimeResult(tz, corrected.In(loc)),
+		CorrectionDelta: render(corrected.Sub(device).Round(time.Millisecond)),
+	}
+	switch {
+	case math.Abs(offset.Seconds()) < 1e-3:
+	case offset > 0:
+		res.Direction = "fast"
+	default:
+		res.Direction = "slow"
+	}
+	return res, nil
+}
+
+// ClockSkew compares the server's now against an authoritative reference
+// instant. Skew is server minus reference, so a positive skew means the
+// server clock is ahead. Skews within tolerance (default 1s) are reported
+// as in sync.
+func (t *TimeServer) ClockSkew(reference, tolerance, format string) (ClockSkewResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return ClockSkewResult{}, err
+	}
+	ref, err := parseInstant("reference_time", reference)
+	if err != nil {
+		return ClockSkewResult{}, err
+	}
+	if tolerance == "" {
+		tolerance = "1s"
+	}
+	cd, _, err := parseDurationSpec(tolerance)
+	if err != nil {
+		return ClockSkewResult{}, err
+	}
+	tol, err := cd.fixed()
+	if err != nil {
+		return ClockSkewResult{}, err
+	}
+	if tol < 0 {
+		return ClockSkewResult{}, fmt.Errorf("tolerance must not be negative")
+	}
+
+	now := t.nowFunc()
+	skew := now.Sub(ref)
+	magnitude := skew.Abs()
+	res := ClockSkewResult{
+		ReferenceTime:    ref.Format(time.RFC3339Nano),
+		ServerTime:       now.In(ref.Location()).Format(time.RFC3339Nano),
+		SkewMilliseconds: skew.Milliseconds(),
+		SkewSeconds:      skew.Seconds(),
+		Skew:             render(skew),
+		Magnitude:        render(magnitude),
+		Tolerance:        render(tol),
+		Assessment:       "in sync",
+	}
+	switch {
+	case magnitude <= tol:
+	case skew > 0:
+		res.Assessment = "ahead"
+	default:
+		res.Assessment = "behind"
+	}
+	return res, nil
+}
+
+// maxPalindromeRange bounds the span PalindromeTimes will scan, and
+// maxPalindromeTimes how many matches it lists.
+const (
+	maxPalindromeRange = 366 * day
+	maxPalindromeTimes = 1000
+)
+
+// PalindromeTimes lists the instants in [start, end] whose local clock
+// reading in tz is a palindrome in the given format ("HH:MM" or
+// "HH:MM:SS"). The scan steps through real instants, so readings skipped by
+// a spring-forward gap never appear and those repeated by a fall-back
+// change appear twice.
+func (t *TimeServer) PalindromeTimes(start, end, tz, format string) (PalindromeTimesResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return PalindromeTimesResult{}, err
+	}
+	if format == "" {
+		format = "HH:MM"
+	}
+	var layout string
+	switch strings.ToUpper(strings.TrimSpace(format)) {
+	case "HH:MM":
+		layout, format = "15:04", "HH:MM"
+	case "HH:MM:SS":
+		layout, format = "15:04:05", "HH:MM:SS"
+	default:
+		return PalindromeTimesResult{}, fmt.Errorf("format must be HH:MM or HH:MM:SS: %s", format)
+	}
+	from, err := t.parseDateTime(start, loc)
+	if err != nil {
+		return PalindromeTimesResult{}, err
+	}
+	to, err := t.parseDateTime(end, loc)
+	if err != nil {
+		return PalindromeTimesResult{}, err
+	}
+	if to.Before(from) {
+		return PalindromeTimesResult{}, fmt.Errorf("end must not be before start")
+	}
+	if to.Sub(from) > maxPalindromeRange {
+		return PalindromeTimesResult{}, fmt.Errorf("range must be at most %d days", maxPalindromeRange/day)
+	}
+
+	res := PalindromeTimesResult{
+		Timezone: tz,
+		Format:   format,
+		Start:    from.In(loc).Format(time.RFC3339),
+		End:      to.In(loc).Format(time.RFC3339),
+		Times:    []string{},
+	}
+	record := func(tm time.Time) {
+		res.Count++
+		if len(res.Times) < maxPalindromeTimes {
+			res.Times = append(res.Times, tm.Format(time.RFC3339))
+		} else {
+			res.Truncated = true
+		}
+	}
+	// Walk minute by minute. For HH:MM:SS the only candidate second in a
+	// minute is the reversed hour, so seconds never need stepping.
+	for m := from.Truncate(time.Minute); !m.After(to); m = m.Add(time.Minute) {
+		local := m.In(loc)
+		if layout == "15:04" {
+			if !m.Before(from) && isPalindrome(local.Format(layout)) {
+				record(local)
+			}
+			continue
+		}
+		hh := local.Format("15")
+		sec := int(hh[1]-'0')*10 + int(hh[0]-'0')
+		if sec >= 60 {
+			continue
+		}
+		at := local.Add(time.Duration(sec) * time.Second)
+		if !at.Before(from) && !at.After(to) && isPalindrome(at.Format(layout)) {
+			record(at)
+		}
+	}
+	return res, nil
+}
+
+// clockPatternHorizon bounds how far ahead NextClockPattern scans. Every
+// HH:MM reading recurs daily, so three days covers a reading lost to a DST
+// gap on the first.
+const clockPatternHorizon = 3 * day
+
+// NextClockPattern returns the first whole minute at or after now whose
+// HH:MM reading in tz matches pattern, a regular expression that must match
+// the whole display (so "1:11" does not also match 11:11). The scan
+// steps through real instants, so minutes skipped by spring-forward are
+// never reported.
+func (t *TimeServer) NextClockPattern(pattern, tz, format string) (ClockPatternResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return ClockPatternResult{}, err
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return ClockPatternResult{}, fmt.Errorf("invalid pattern: %w", err)
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return ClockPatternResult{}, err
+	}
+	now := t.nowFunc().In(loc)
+
+	m := now.Truncate(time.Minute)
+	if m.Before(now) {
+		m = m.Add(time.Minute)
+	}
+	for limit := now.Add(clockPatternHorizon); !m.After(limit); m = m.Add(time.Minute) {
+		local := m.In(loc)
+		if display := local.Format("15:04"); re.MatchString(display) {
+			return ClockPatternResult{
+				Pattern:  pattern,
+				Timezone: tz,
+				From:     makeTimeResult(tz, now),
+				Display:  display,
+				Next:     makeTimeResult(tz, local),
+				UTC:      m.UTC().Format(time.RFC3339),
+				Wait:     render(m.Sub(now)),
+			}, nil
+		}
+	}
+	return ClockPatternResult{}, fmt.Errorf("no HH:MM reading matches %q within %s", pattern, clockPatternHorizon)
+}
+
+/* ----- tools ----- */
+
+func registerClockTools(s *server.MCPServer, ts *TimeServer) {
+	drift := mcp.NewTool(
+		"drift_correct",
+		mcp.WithDescription("Estimate a drifting clock's rate from one comparison against a trusted time and correct a device reading by linear extrapolation."),
+		mcp.WithString("measured_time", mcp.Required(), mcp.Description("What the device showed (RFC3339).")),
+		mcp.WithString("true_time", mcp.Required(), mcp.Description("Trusted time at the same moment (RFC3339).")),
+		mcp.WithString("elapsed", mcp.Required(), mcp.Description("Time since the device was last set, e.g. 30d or P2W.")),
+		mcp.WithString("device_time", mcp.Description("A later device reading to correct. Defaults to measured_time.")),
+		mcp.WithString("timezone"),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(drift, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		measured, err := r.RequireString("measured_time")
+		if err != nil {
+			return errorResult(err)
+		}
+		trueTime, err := r.RequireString("true_time")
+		if err != nil {
+			return errorResult(err)
+		}
+		elapsed, err := r.RequireString("elapsed")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.DriftCorrect(measured, trueTime, elapsed, r.GetString("device_time", ""), r.GetString("timezone", ""), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	skew := mcp.NewTool(
+		"clock_skew",
+		mcp.WithDescription("Skew between the server clock and an authoritative reference time in signed milliseconds, assessed as in sync, ahead or behind."),
+		mcp.WithString("reference_time", mcp.Required(), mcp.Description("Trusted current time (RFC3339), e.g. from NTP.")),
+		mcp.WithString("tolerance", mcp.Description("Largest skew still reported as in sync (default 1s).")),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(skew, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		reference, err := r.RequireString("reference_time")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.ClockSkew(reference, r.GetString("tolerance", ""), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	palindrome := mcp.NewTool(
+		"palindrome_times",
+		mcp.WithDescription("Count and list the local wall-clock instants in a range whose time reads as a palindrome (e.g. 12:21), skipping readings lost to a DST gap."),
+		mcp.WithString("start", mcp.Required()),
+		mcp.WithString("end", mcp.Required()),
+		mcp.WithString("timezone"),
+		mcp.WithString("format", mcp.Description("HH:MM (default) or HH:MM:SS.")),
+	)
+
+	s.AddTool(palindrome, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start, err := r.RequireString("start")
+		if err != nil {
+			return errorResult(err)
+		}
+		end, err := r.RequireString("end")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.PalindromeTimes(start, end, r.GetString("timezone", ""), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	pattern := mcp.NewTool(
+		"next_clock_pattern",
+		mcp.WithDescription("Next local minute whose HH:MM display matches a regular expression, e.g. 11:11 or (00|11|22):(00|11|22|33|44|55). Minutes skipped by DST are never reported."),
+		mcp.WithString("pattern", mcp.Required(), mcp.Description("Regular expression matched against the whole HH:MM display.")),
+		mcp.WithString("timezone"),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(pattern, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		p, err := r.RequireString("pattern")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.NextClockPattern(p, r.GetString("timezone", ""), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+}
@@ -0,0 +1,158 @@
+// clock_test.go
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDriftCorrect(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	// 10 days after sync the device is 20s fast: 2 s/day.
+	res, err := ts.DriftCorrect("2025-05-11T00:00:20Z", "2025-05-11T00:00:00Z", "10d", "", "UTC", "")
+	if err != nil {
+		t.Fatalf("DriftCorrect error: %v", err)
+	}
+	if math.Abs(res.DriftSecPerDay-2) > 1e-9 || res.Direction != "fast" || res.LastSync != "2025-05-01T00:00:00Z" {
+		t.Errorf("fast clock = %+v", res)
+	}
+	if res.CorrectedTime.Datetime != "2025-05-11T00:00:00Z" {
+		t.Errorf("corrected measured reading = %s, want the true time", res.CorrectedTime.Datetime)
+	}
+
+	// Five days later the device reads 30s ahead; undoing 2 s/day recovers the true time.
+	later, err := ts.DriftCorrect("2025-05-11T00:00:20Z", "2025-05-11T00:00:00Z", "10d", "2025-05-16T00:00:30Z", "UTC", "")
+	if err != nil {
+		t.Fatalf("DriftCorrect error: %v", err)
+	}
+	if later.CorrectedTime.Datetime != "2025-05-16T00:00:00Z" || later.CorrectionDelta != "-30s" {
+		t.Errorf("later reading = %+v", later)
+	}
+
+	slow, err := ts.DriftCorrect("2025-05-10T23:59:55Z", "2025-05-11T00:00:00Z", "P5D", "", "UTC", "")
+	if err != nil {
+		t.Fatalf("DriftCorrect error: %v", err)
+	}
+	if math.Abs(slow.DriftSecPerDay+1) > 1e-9 || slow.Direction != "slow" {
+		t.Errorf("slow clock = %+v", slow)
+	}
+
+	if _, err := ts.DriftCorrect("2025-05-11T00:00:20Z", "2025-05-11T00:00:00Z", "0s", "", "UTC", ""); err == nil {
+		t.Errorf("expected error for a zero elapsed period, got nil")
+	}
+	if _, err := ts.DriftCorrect("2025-05-11 00:00", "2025-05-11T00:00:00Z", "1d", "", "UTC", ""); err == nil {
+		t.Errorf("expected error for a zone-less measured_time, got nil")
+	}
+	// The device reads the sync instant itself: it stopped, so 1+rate is 0.
+	if _, err := ts.DriftCorrect("2025-05-10T00:00:00Z", "2025-05-11T00:00:00Z", "1d", "", "UTC", ""); err == nil {
+		t.Errorf("expected error for a stopped clock, got nil")
+	}
+	if _, err := ts.DriftCorrect("2025-05-09T00:00:00Z", "2025-05-11T00:00:00Z", "1d", "", "UTC", ""); err == nil {
+		t.Errorf("expected error for a clock running backwards, got nil")
+	}
+}
+
+func TestClockSkew(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 12, 0, 0, 0, time.UTC) })
+
+	cases := []struct {
+		name, reference, tolerance, want string
+		wantMS                           int64
+	}{
+		{"ahead", "2025-05-14T11:59:57.500Z", "", "ahead", 2500},
+		{"behind", "2025-05-14T14:00:05+02:00", "", "behind", -5000},
+		{"withinTolerance", "2025-05-14T12:00:00.400Z", "", "in sync", -400},
+		{"tightTolerance", "2025-05-14T12:00:00.400Z", "100ms", "behind", -400},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.ClockSkew(tc.reference, tc.tolerance, "")
+			if err != nil {
+				t.Fatalf("ClockSkew error: %v", err)
+			}
+			if res.Assessment != tc.want || res.SkewMilliseconds != tc.wantMS {
+				t.Errorf("got %s at %dms, want %s at %dms", res.Assessment, res.SkewMilliseconds, tc.want, tc.wantMS)
+			}
+		})
+	}
+
+	if _, err := ts.ClockSkew("2025-05-14 12:00", "", ""); err == nil {
+		t.Errorf("expected error for a zone-less reference, got nil")
+	}
+}
+
+func TestPalindromeTimes(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	cases := []struct {
+		name, start, end, tz, format string
+		count                        int
+		first                        string
+	}{
+		{"fullDayMinutes", "2025-05-14T00:00:00Z", "2025-05-14T23:59:59Z", "UTC", "HH:MM", 16, "2025-05-14T00:00:00Z"},
+		{"fullDaySeconds", "2025-05-14T00:00:00Z", "2025-05-14T23:59:59Z", "UTC", "HH:MM:SS", 96, "2025-05-14T00:00:00Z"},
+		{"partial", "2025-05-14T12:00:00Z", "2025-05-14T13:45:00Z", "UTC", "", 2, "2025-05-14T12:21:00Z"},
+		{"secondsInsideMinute", "2025-05-14T12:22:10Z", "2025-05-14T12:22:40Z", "UTC", "HH:MM:SS", 1, "2025-05-14T12:22:21Z"},
+		// 02:20 is skipped on spring-forward day.
+		{"springForward", "2025-03-09T00:00:00-05:00", "2025-03-09T23:59:59-04:00", "America/New_York", "HH:MM", 15, "2025-03-09T00:00:00-05:00"},
+		// 01:10 happens twice on fall-back day.
+		{"fallBack", "2025-11-02T00:00:00-04:00", "2025-11-02T23:59:59-05:00", "America/New_York", "HH:MM", 17, "2025-11-02T00:00:00-04:00"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.PalindromeTimes(tc.start, tc.end, tc.tz, tc.format)
+			if err != nil {
+				t.Fatalf("PalindromeTimes error: %v", err)
+			}
+			if res.Count != tc.count || len(res.Times) != tc.count {
+				t.Fatalf("count = %d (%d listed), want %d: %v", res.Count, len(res.Times), tc.count, res.Times)
+			}
+			if res.Times[0] != tc.first {
+				t.Errorf("first = %s, want %s", res.Times[0], tc.first)
+			}
+		})
+	}
+
+	if _, err := ts.PalindromeTimes("2025-05-14T00:00:00Z", "2025-05-15T00:00:00Z", "UTC", "MM:SS"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestNextClockPattern(t *testing.T) {
+	cases := []struct {
+		name, now, pattern, tz, next, wait string
+	}{
+		{"elevenEleven", "2025-05-14T09:30:00Z", "11:11", "UTC", "2025-05-14T11:11:00Z", "1h41m0s"},
+		{"tomorrow", "2025-05-14T11:12:00Z", "11:11", "UTC", "2025-05-15T11:11:00Z", "23h59m0s"},
+		{"atNow", "2025-05-14T11:11:00Z", "11:11", "UTC", "2025-05-14T11:11:00Z", "0s"},
+		{"roundsUpToMinute", "2025-05-14T11:11:30Z", "11:1[12]", "UTC", "2025-05-14T11:12:00Z", "30s"},
+		{"repeatedDigits", "2025-05-14T12:00:00Z", "(00|11|22):(00|11|22|33|44|55)", "UTC", "2025-05-14T22:00:00Z", "10h0m0s"},
+		// 02:22 is skipped in New York on 2025-03-09, so the next is a day later.
+		{"skipsGap", "2025-03-09T06:00:00Z", "02:22", "America/New_York", "2025-03-10T02:22:00-04:00", "24h22m0s"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := NewTimeServer("UTC")
+			now, _ := time.Parse(time.RFC3339, tc.now)
+			ts.forTesting_SetNowFunc(func() time.Time { return now })
+			res, err := ts.NextClockPattern(tc.pattern, tc.tz, "")
+			if err != nil {
+				t.Fatalf("NextClockPattern error: %v", err)
+			}
+			if res.Next.Datetime != tc.next || res.Wait != tc.wait {
+				t.Errorf("got %s after %s, want %s after %s", res.Next.Datetime, res.Wait, tc.next, tc.wait)
+			}
+		})
+	}
+
+	ts := NewTimeServer("UTC")
+	if _, err := ts.NextClockPattern("25:00", "UTC", ""); err == nil {
+		t.Error("expected error for a pattern that never matches")
+	}
+	if _, err := ts.NextClockPattern("([", "UTC", ""); err == nil {
+		t.Error("expected error for an invalid pattern")
+	}
+}
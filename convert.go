@@ -0,0 +1,609 @@
+// convert.go
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+/* ----- data types ----- */
+
+type ChainStop struct {
+	Timezone          string      `json:"timezone"`
+	Time              *TimeResult `json:"time,omitempty"`
+	DeltaFromPrevious string      `json:"delta_from_previous,omitempty"`
+	DeltaFromOrigin   string      `json:"delta_from_origin,omitempty"`
+	Error             string      `json:"error,omitempty"`
+}
+
+type ConvertChainResult struct {
+	Instant string      `json:"instant"`
+	Stops   []ChainStop `json:"stops"`
+}
+
+type ConvertedTimestamp struct {
+	Input  string      `json:"input"`
+	Time   *TimeResult `json:"time,omitempty"`
+	Offset string      `json:"offset,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+type ConvertTimestampsResult struct {
+	Timezone   string               `json:"timezone"`
+	Converted  int                  `json:"converted"`
+	Failed     int                  `json:"failed"`
+	Timestamps []ConvertedTimestamp `json:"timestamps"`
+}
+
+type CommuteShiftResult struct {
+	Departure       TimeResult `json:"departure"`
+	Arrival         TimeResult `json:"arrival"`
+	ArrivalAtHome   TimeResult `json:"arrival_home_time"`
+	Duration        string     `json:"duration"`
+	ClockShift      string     `json:"clock_shift"`
+	ApparentElapsed string     `json:"apparent_elapsed"`
+}
+
+type FormatAllResult struct {
+	Timezone string            `json:"timezone"`
+	Offset   string            `json:"offset"`
+	IsDST    bool              `json:"is_dst"`
+	Formats  map[string]string `json:"formats"`
+}
+
+type ConvertEpochResult struct {
+	Value         float64 `json:"value"`
+	FromEpoch     string  `json:"from_epoch"`
+	ToEpoch       string  `json:"to_epoch"`
+	Result        float64 `json:"result"`
+	OffsetSeconds int64   `json:"offset_seconds"` // added to value to get result
+	UnixSeconds   float64 `json:"unix_seconds"`
+	Instant       string  `json:"instant,omitempty"`
+	Note          string  `json:"note"`
+}
+
+type JDToLocalResult struct {
+	JulianDate string     `json:"julian_date"`
+	UTC        string     `json:"utc"`
+	Local      TimeResult `json:"local"`
+	LocalExact string     `json:"local_exact"`
+	UnixNanos  int64      `json:"unix_nanos"`
+}
+
+type TimeDifferenceResult struct {
+	At                string       `json:"at"`
+	From              MemberOffset `json:"from"`
+	To                MemberOffset `json:"to"`
+	Difference        string       `json:"difference"` // positive when to is ahead of from
+	DifferenceSeconds int          `json:"difference_seconds"`
+}
+
+/* ----- helpers ----- */
+
+// epochOffsets gives each epoch's origin in Unix seconds. J2000 is taken as
+// 2000-01-01T12:00:00Z and GPS time as starting 1980-01-06T00:00:00Z; the
+// offsets are constants, so leap seconds (GPS runs 18s ahead of UTC since
+// 2017) and the TT-UTC difference are deliberately ignored.
+var epochOffsets = map[string]int64{
+	"unix":  0,
+	"j2000": 946728000,
+	"gps":   315964800,
+	"ntp":   -2208988800,
+}
+
+// epochNote documents the constant-offset model used by ConvertEpoch.
+const epochNote = "constant offsets between epoch origins; leap seconds and TT-UTC are not applied"
+
+// maxExactEpochSeconds is 2^53, the largest magnitude at which every whole
+// number of seconds is still exactly representable as a float64.
+const maxExactEpochSeconds = 1 << 53
+
+/* ----- core methods ----- */
+
+// ConvertChain renders one instant in each zone of a chain. The time is
+// read in the first zone; each later stop reports its offset difference from
+// the previous valid stop and from the origin. An invalid zone is reported
+// on its own stop and skipped when computing later deltas.
+func (t *TimeServer) ConvertChain(zones []string, when string) (ConvertChainResult, error) {
+	if len(zones) == 0 {
+		return ConvertChainResult{}, fmt.Errorf("zones must not be empty")
+	}
+	_, originLoc, err := t.location(zones[0])
+	if err != nil {
+		return ConvertChainResult{}, fmt.Errorf("origin zone: %w", err)
+	}
+	instant, err := t.parseDateTime(when, originLoc)
+	if err != nil {
+		return ConvertChainResult{}, err
+	}
+
+	res := ConvertChainResult{Instant: instant.UTC().Format(time.RFC3339)}
+	_, originOff := instant.Zone()
+	prevOff := originOff
+	for i, z := range zones {
+		name, loc, err := t.location(z)
+		if err != nil {
+			res.Stops = append(res.Stops, ChainStop{Timezone: z, Error: err.Error()})
+			continue
+		}
+		local := instant.In(loc)
+		tr := makeTimeResult(name, local)
+		stop := ChainStop{Timezone: name, Time: &tr}
+		_, off := local.Zone()
+		if i > 0 {
+			stop.DeltaFromPrevious = formatHourDiff(off - prevOff)
+			stop.DeltaFromOrigin = formatHourDiff(off - originOff)
+		}
+		prevOff = off
+		res.Stops = append(res.Stops, stop)
+	}
+	return res, nil
+}
+
+// ConvertTimestamps converts a batch of RFC3339 timestamps to one zone,
+// loading the target location once. Entries that fail to parse carry their
+// error inline; output order matches input order.
+func (t *TimeServer) ConvertTimestamps(timestamps []string, tz string) (ConvertTimestampsResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return ConvertTimestampsResult{}, err
+	}
+	res := ConvertTimestampsResult{Timezone: tz, Timestamps: make([]ConvertedTimestamp, 0, len(timestamps))}
+	for _, in := range timestamps {
+		entry := ConvertedTimestamp{Input: in}
+		tm, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(in))
+		if err != nil {
+			entry.Error = fmt.Sprintf("invalid RFC3339 timestamp: %s", in)
+			res.Failed++
+		} else {
+			local := tm.In(loc)
+			tr := makeTimeResult(tz, local)
+			_, off := local.Zone()
+			entry.Time, entry.Offset = &tr, formatOffset(off)
+			res.Converted++
+		}
+		res.Timestamps = append(res.Timestamps, entry)
+	}
+	return res, nil
+}
+
+// CommuteShift follows a commute that leaves home at departure (local to
+// homeTZ; a bare HH:MM means today) and lasts duration, and renders the
+// arrival on the work zone's clock. ClockShift is the offset change between
+// the two endpoints, so ApparentElapsed (arrival wall time minus departure
+// wall time) equals the duration plus the shift.
+func (t *TimeServer) CommuteShift(homeTZ, workTZ, departure, duration, format string) (CommuteShiftResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return CommuteShiftResult{}, err
+	}
+	homeTZ, homeLoc, err := t.location(homeTZ)
+	if err != nil {
+		return CommuteShiftResult{}, fmt.Errorf("home zone: %w", err)
+	}
+	workTZ, workLoc, err := t.location(workTZ)
+	if err != nil {
+		return CommuteShiftResult{}, fmt.Errorf("work zone: %w", err)
+	}
+	var leave time.Time
+	if c, err := parseClock(departure); err == nil {
+		leave = atClock(t.nowFunc().In(homeLoc), c)
+	} else if leave, err = t.parseDateTime(departure, homeLoc); err != nil {
+		return CommuteShiftResult{}, err
+	}
+	cd, _, err := parseDurationSpec(duration)
+	if err != nil {
+		return CommuteShiftResult{}, err
+	}
+	trip, err := cd.fixed()
+	if err != nil {
+		return CommuteShiftResult{}, err
+	}
+	if trip < 0 {
+		return CommuteShiftResult{}, fmt.Errorf("commute duration must not be negative")
+	}
+
+	arrive := leave.Add(trip).In(workLoc)
+	_, homeOff := leave.Zone()
+	_, workOff := arrive.Zone()
+	shift := time.Duration(workOff-homeOff) * time.Second
+	return CommuteShiftResult{
+		Departure:       makeTimeResult(homeTZ, leave),
+		Arrival:         makeTimeResult(workTZ, arrive),
+		ArrivalAtHome:   makeTimeResult(homeTZ, arrive.In(homeLoc)),
+		Duration:        render(trip),
+		ClockShift:      formatHourDiff(workOff - homeOff),
+		ApparentElapsed: render(trip + shift),
+	}, nil
+}
+
+// FormatAll renders a single parsed instant in many common layouts at once
+// so the caller can pick whichever it needs without another round trip.
+func (t *TimeServer) FormatAll(datetime, tz string) (FormatAllResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return FormatAllResult{}, err
+	}
+	tm, err := t.parseDateTime(datetime, loc)
+	if err != nil {
+		return FormatAllResult{}, err
+	}
+	_, off := tm.Zone()
+	return FormatAllResult{
+		Timezone: tz,
+		Offset:   formatOffset(off),
+		IsDST:    tm.IsDST(),
+		Formats: map[string]string{
+			"rfc3339":     tm.Format(time.RFC3339),
+			"rfc3339nano": tm.Format(time.RFC3339Nano),
+			"rfc1123":     tm.Format(time.RFC1123),
+			"rfc1123z":    tm.Format(time.RFC1123Z),
+			"iso_date":    tm.Format("2006-01-02"),
+			"us_date":     tm.Format("01/02/2006"),
+			"eu_date":     tm.Format("02/01/2006"),
+			"unix":        strconv.FormatInt(tm.Unix(), 10),
+			"unix_millis": strconv.FormatInt(tm.UnixMilli(), 10),
+			"kitchen":     tm.Format(time.Kitchen),
+			"clock_24h":   tm.Format("15:04"),
+			"utc":         tm.UTC().Format(time.RFC3339),
+			"human":       tm.Format("Monday, January 2, 2006 at 3:04 PM MST"),
+		},
+	}, nil
+}
+
+// ConvertEpoch re-expresses value, in seconds since fromEpoch, as seconds
+// since toEpoch. This is plain arithmetic on the epoch origins, so whole
+// second values convert exactly and round-trip; magnitudes beyond 2^53
+// seconds are rejected because float64 can no longer hold them exactly.
+func (t *TimeServer) ConvertEpoch(value float64, fromEpoch, toEpoch string) (ConvertEpochResult, error) {
+	fromEpoch = strings.ToLower(strings.TrimSpace(fromEpoch))
+	toEpoch = strings.ToLower(strings.TrimSpace(toEpoch))
+	from, ok := epochOffsets[fromEpoch]
+	if !ok {
+		return ConvertEpochResult{}, fmt.Errorf("from_epoch must be unix, j2000, gps or ntp: %s", fromEpoch)
+	}
+	to, ok := epochOffsets[toEpoch]
+	if !ok {
+		return ConvertEpochResult{}, fmt.Errorf("to_epoch must be unix, j2000, gps or ntp: %s", toEpoch)
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return ConvertEpochResult{}, fmt.Errorf("value must be a finite number")
+	}
+	unix := value + float64(from)
+	result := value + float64(from-to)
+	for _, v := range []float64{value, unix, result} {
+		if math.Abs(v) > maxExactEpochSeconds {
+			return ConvertEpochResult{}, fmt.Errorf("value out of range: magnitudes above 2^53 seconds lose precision")
+		}
+	}
+
+	res := ConvertEpochResult{
+		Value:         value,
+		FromEpoch:     fromEpoch,
+		ToEpoch:       toEpoch,
+		Result:        result,
+		OffsetSeconds: from - to,
+		UnixSeconds:   unix,
+		Note:          epochNote,
+	}
+	sec, frac := math.Modf(unix)
+	if tm := time.Unix(int64(sec), int64(frac*1e9)).UTC(); tm.Year() >= 1 && tm.Year() <= 9999 {
+		res.Instant = tm.Format(time.RFC3339Nano)
+	}
+	return res, nil
+}
+
+// TimeDifference reports how far tzB's clock is ahead of tzA's at the
+// instant at (default now), formatted like ConvertTime's time_difference.
+// The answer depends on the date whenever only one zone is on DST, e.g.
+// New York and London differ by 4h for a few weeks each spring and autumn.
+func (t *TimeServer) TimeDifference(tzA, tzB, at string) (TimeDifferenceResult, error) {
+	tm, err := t.parseDateTime(at, time.UTC)
+	if err != nil {
+		return TimeDifferenceResult{}, err
+	}
+	var sides [2]MemberOffset
+	for i, z := range []string{tzA, tzB} {
+		name, loc, err := t.location(z)
+		if err != nil {
+			return TimeDifferenceResult{}, err
+		}
+		local := tm.In(loc)
+		_, off := local.Zone()
+		_, jan := time.Date(tm.Year(), time.January, 1, 0, 0, 0, 0, loc).Zone()
+		_, jul := time.Date(tm.Year(), time.July, 1, 0, 0, 0, 0, loc).Zone()
+		sides[i] = MemberOffset{Timezone: name, Offset: formatOffset(off), OffsetSeconds: off, IsDST: local.IsDST(), ObservesDST: jan != jul}
+	}
+	diff := sides[1].OffsetSeconds - sides[0].OffsetSeconds
+	return TimeDifferenceResult{
+		At:                tm.UTC().Format(time.RFC3339),
+		From:              sides[0],
+		To:                sides[1],
+		Difference:        formatHourDiff(diff),
+		DifferenceSeconds: diff,
+	}, nil
+}
+
+// EpochToTime renders a raw Unix timestamp, counted in unit ("s", "ms",
+// "us" or "ns"; default "s"), as a TimeResult in tz. Negative values are
+// instants before 1970.
+func (t *TimeServer) EpochToTime(epoch int64, unit, tz string) (TimeResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return TimeResult{}, err
+	}
+	var tm time.Time
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "", "s":
+		tm = time.Unix(epoch, 0)
+	case "ms":
+		tm = time.UnixMilli(epoch)
+	case "us":
+		tm = time.UnixMicro(epoch)
+	case "ns":
+		tm = time.Unix(0, epoch)
+	default:
+		return TimeResult{}, fmt.Errorf("unit must be s, ms, us or ns: %s", unit)
+	}
+	return makeTimeResult(tz, tm.In(loc)), nil
+}
+
+// unixEpochJDHalfDays is the Julian Date of 1970-01-01T00:00:00Z, counted
+// in half days so it stays an integer.
+const unixEpochJDHalfDays = 2*2440587 + 1
+
+// JDToLocal converts a Julian Date, given as a decimal string, to the civil
+// instant in tz. The whole and fractional days are read as exact integers
+// and scaled with big.Int, so a value such as 2460000.5000000116 keeps its
+// nanoseconds instead of losing them to float64, which carries only about
+// 20 microseconds of resolution at current Julian Dates. The result is
+// rounded to the nearest nanosecond and must lie within the years Go can
+// hold as Unix nanoseconds (1678-2262).
+func (t *TimeServer) JDToLocal(jd, tz string) (JDToLocalResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return JDToLocalResult{}, err
+	}
+	s := strings.TrimSpace(jd)
+	neg := strings.HasPrefix(s, "-")
+	whole, frac, _ := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+"), ".")
+	digits := whole + frac
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return JDToLocalResult{}, fmt.Errorf("julian_date must be a decimal number: %s", jd)
+	}
+
+	// nanos = (days + frac/10^k) * dayNanos, kept as a fraction over 10^k.
+	dayNanos := big.NewInt(int64(day))
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(len(frac))), nil)
+	num, _ := new(big.Int).SetString(digits, 10)
+	if neg {
+		num.Neg(num)
+	}
+	num.Mul(num, dayNanos)
+	epoch := new(big.Int).Mul(big.NewInt(unixEpochJDHalfDays), dayNanos)
+	epoch.Mul(epoch, scale)
+	num.Sub(num.Mul(num, big.NewInt(2)), epoch) // twice the nanos since the Unix epoch, times 10^k
+	den := new(big.Int).Mul(scale, big.NewInt(2))
+	// Round half away from zero.
+	half := new(big.Int).Quo(den, big.NewInt(2))
+	if num.Sign() < 0 {
+		num.Sub(num, half)
+	} else {
+		num.Add(num, half)
+	}
+	nanos := num.Quo(num, den)
+	if !nanos.IsInt64() {
+		return JDToLocalResult{}, fmt.Errorf("julian_date out of range: %s", jd)
+	}
+
+	tm := time.Unix(0, nanos.Int64()).In(loc)
+	return JDToLocalResult{
+		JulianDate: s,
+		UTC:        tm.UTC().Format(time.RFC3339Nano),
+		Local:      makeTimeResult(tz, tm),
+		LocalExact: tm.Format(time.RFC3339Nano),
+		UnixNanos:  tm.UnixNano(),
+	}, nil
+}
+
+/* ----- tools ----- */
+
+func registerConvertTools(s *server.MCPServer, ts *TimeServer) {
+	chain := mcp.NewTool(
+		"convert_chain",
+		mcp.WithDescription("Show one instant at each zone in an ordered chain with the offset change at every hop."),
+		mcp.WithArray("zones", mcp.Required(), mcp.Items(map[string]any{"type": "string"}), mcp.Description("Ordered zones; the time is read in the first one.")),
+		mcp.WithString("time", mcp.Description("Instant in the first zone (RFC3339, YYYY-MM-DD HH:MM, or natural language). Defaults to now.")),
+	)
+
+	s.AddTool(chain, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		zones, err := r.RequireStringSlice("zones")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.ConvertChain(zones, r.GetString("time", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	batch := mcp.NewTool(
+		"convert_timestamps",
+		mcp.WithDescription("Convert a list of RFC3339 timestamps to one target timezone, with offset and DST status for each. Bad entries are reported inline; order is preserved."),
+		mcp.WithArray("timestamps", mcp.Required(), mcp.Items(map[string]any{"type": "string"})),
+		mcp.WithString("timezone", mcp.Required()),
+	)
+
+	s.AddTool(batch, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		timestamps, err := r.RequireStringSlice("timestamps")
+		if err != nil {
+			return errorResult(err)
+		}
+		tz, err := r.RequireString("timezone")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.ConvertTimestamps(timestamps, tz)
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	commute := mcp.NewTool(
+		"commute_shift",
+		mcp.WithDescription("Arrival time on the work zone's clock for a commute from home, and the net clock shift for cross-border commuters."),
+		mcp.WithString("home_timezone", mcp.Required()),
+		mcp.WithString("work_timezone", mcp.Required()),
+		mcp.WithString("departure", mcp.Required(), mcp.Description("Local departure time at home: HH:MM (today) or a full datetime.")),
+		mcp.WithString("commute_duration", mcp.Required(), mcp.Description("e.g. 45m, PT1H10M")),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(commute, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		home, err := r.RequireString("home_timezone")
+		if err != nil {
+			return errorResult(err)
+		}
+		work, err := r.RequireString("work_timezone")
+		if err != nil {
+			return errorResult(err)
+		}
+		departure, err := r.RequireString("departure")
+		if err != nil {
+			return errorResult(err)
+		}
+		duration, err := r.RequireString("commute_duration")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.CommuteShift(home, work, departure, duration, r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	formatAll := mcp.NewTool(
+		"format_all",
+		mcp.WithDescription("Render one instant in many formats at once: RFC3339, RFC1123, ISO/US/European dates, Unix seconds and millis, kitchen time and a human phrase."),
+		mcp.WithString("datetime", mcp.Description("Defaults to now.")),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(formatAll, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := ts.FormatAll(r.GetString("datetime", ""), r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	epoch := mcp.NewTool(
+		"convert_epoch",
+		mcp.WithDescription("Re-express a seconds count from one epoch (unix, j2000, gps, ntp) relative to another using constant origin offsets, e.g. GPS seconds to Unix seconds. Leap seconds are not applied."),
+		mcp.WithNumber("value", mcp.Required(), mcp.Description("Seconds since from_epoch.")),
+		mcp.WithString("from_epoch", mcp.Required(), mcp.Enum("unix", "j2000", "gps", "ntp")),
+		mcp.WithString("to_epoch", mcp.Required(), mcp.Enum("unix", "j2000", "gps", "ntp")),
+	)
+
+	s.AddTool(epoch, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		value, err := r.RequireFloat("value")
+		if err != nil {
+			return errorResult(err)
+		}
+		from, err := r.RequireString("from_epoch")
+		if err != nil {
+			return errorResult(err)
+		}
+		to, err := r.RequireString("to_epoch")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.ConvertEpoch(value, from, to)
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	jdLocal := mcp.NewTool(
+		"jd_to_local",
+		mcp.WithDescription("Convert a Julian Date to the local civil instant with nanosecond precision. Pass the JD as a decimal string so no digits are lost to floating point."),
+		mcp.WithString("julian_date", mcp.Required(), mcp.Description("Julian Date, e.g. 2460000.5000000116.")),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(jdLocal, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		jd, err := r.RequireString("julian_date")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.JDToLocal(jd, r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	epochTime := mcp.NewTool(
+		"epoch_to_time",
+		mcp.WithDescription("Render a raw Unix timestamp (seconds, milliseconds, microseconds or nanoseconds) as a datetime in a timezone. Negative values are before 1970."),
+		mcp.WithNumber("epoch", mcp.Required(), mcp.Description("Integer timestamp since 1970-01-01T00:00:00Z.")),
+		mcp.WithString("unit", mcp.Description("s (default), ms, us or ns.")),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(epochTime, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			Epoch    *int64 `json:"epoch"`
+			Unit     string `json:"unit"`
+			Timezone string `json:"timezone"`
+		}
+		if err := r.BindArguments(&args); err != nil {
+			return errorResult(err)
+		}
+		if args.Epoch == nil {
+			return errorResult(fmt.Errorf("epoch is required"))
+		}
+		res, err := ts.EpochToTime(*args.Epoch, args.Unit, args.Timezone)
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	difference := mcp.NewTool(
+		"get_time_difference",
+		mcp.WithDescription("How many hours the second timezone is ahead of the first at an instant (default now), with both UTC offsets. The gap can change with the date when only one zone observes DST."),
+		mcp.WithString("from_timezone", mcp.Required()),
+		mcp.WithString("to_timezone", mcp.Required()),
+		mcp.WithString("datetime", mcp.Description("Instant to compare at. Defaults to now.")),
+	)
+
+	s.AddTool(difference, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		from, err := r.RequireString("from_timezone")
+		if err != nil {
+			return errorResult(err)
+		}
+		to, err := r.RequireString("to_timezone")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.TimeDifference(from, to, r.GetString("datetime", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+}
@@ -0,0 +1,526 @@
+// convert_test.go
+package main
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatHourDiff(t *testing.T) {
+	cases := map[int]string{0: "+0h", 9 * 3600: "+9h", -5 * 3600: "-5h", 19800: "+5.5h", -34200: "-9.5h", 20700: "+5.75h"}
+	for sec, want := range cases {
+		if got := formatHourDiff(sec); got != want {
+			t.Errorf("formatHourDiff(%d) = %q, want %q", sec, got, want)
+		}
+	}
+}
+
+func TestConvertChain(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	res, err := ts.ConvertChain([]string{"America/New_York", "Europe/London", "Not/AZone", "Asia/Kolkata"}, "2025-01-15 09:00")
+	if err != nil {
+		t.Fatalf("ConvertChain error: %v", err)
+	}
+	if res.Instant != "2025-01-15T14:00:00Z" || len(res.Stops) != 4 {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if london := res.Stops[1]; london.Time == nil || london.Time.Datetime != "2025-01-15T14:00:00Z" || london.DeltaFromPrevious != "+5h" {
+		t.Errorf("London stop = %+v, want 14:00 and +5h", london)
+	}
+	if bad := res.Stops[2]; bad.Error == "" || bad.Time != nil {
+		t.Errorf("expected a per-stop error for the invalid zone, got %+v", bad)
+	}
+	// The hop after the invalid stop is measured from London.
+	if kolkata := res.Stops[3]; kolkata.DeltaFromPrevious != "+5.5h" || kolkata.DeltaFromOrigin != "+10.5h" {
+		t.Errorf("Kolkata stop = %+v, want +5.5h from London and +10.5h from origin", kolkata)
+	}
+
+	if _, err := ts.ConvertChain([]string{"Not/AZone", "UTC"}, "2025-01-15 09:00"); err == nil {
+		t.Errorf("expected error for an invalid origin zone, got nil")
+	}
+}
+
+func TestConvertTimestamps(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	in := []string{"2025-01-15T12:00:00Z", "not a time", "2025-07-15T12:00:00+02:00", "2025-03-09 10:00"}
+
+	res, err := ts.ConvertTimestamps(in, "America/New_York")
+	if err != nil {
+		t.Fatalf("ConvertTimestamps error: %v", err)
+	}
+	if len(res.Timestamps) != len(in) || res.Converted != 2 || res.Failed != 2 {
+		t.Fatalf("unexpected counts: %+v", res)
+	}
+	want := []struct{ datetime, offset string }{
+		{"2025-01-15T07:00:00-05:00", "-05:00"},
+		{"", ""},
+		{"2025-07-15T06:00:00-04:00", "-04:00"},
+		{"", ""},
+	}
+	for i, w := range want {
+		got := res.Timestamps[i]
+		if got.Input != in[i] {
+			t.Errorf("entry %d input = %q, want %q", i, got.Input, in[i])
+		}
+		if w.datetime == "" {
+			if got.Error == "" || got.Time != nil {
+				t.Errorf("entry %d: expected inline error, got %+v", i, got)
+			}
+			continue
+		}
+		if got.Time == nil || got.Time.Datetime != w.datetime || got.Offset != w.offset {
+			t.Errorf("entry %d = %+v, want %s (%s)", i, got, w.datetime, w.offset)
+		}
+	}
+
+	if _, err := ts.ConvertTimestamps(in, "Not/AZone"); err == nil {
+		t.Errorf("expected error for an invalid target zone, got nil")
+	}
+}
+
+func TestCommuteShift(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 4, 0, 0, 0, time.UTC) })
+
+	// Living in Portugal, working across the border in Spain.
+	res, err := ts.CommuteShift("Europe/Lisbon", "Europe/Madrid", "07:30", "45m", "")
+	if err != nil {
+		t.Fatalf("CommuteShift error: %v", err)
+	}
+	if res.Arrival.Datetime != "2025-05-14T09:15:00+02:00" || res.ClockShift != "+1h" || res.ApparentElapsed != "1h45m0s" {
+		t.Errorf("cross-border commute = %+v", res)
+	}
+
+	same, err := ts.CommuteShift("Europe/Paris", "Europe/Paris", "2025-05-14 08:00", "PT1H", "")
+	if err != nil {
+		t.Fatalf("CommuteShift error: %v", err)
+	}
+	if same.Arrival.Datetime != "2025-05-14T09:00:00+02:00" || same.ClockShift != "+0h" {
+		t.Errorf("same-zone commute = %+v", same)
+	}
+
+	if _, err := ts.CommuteShift("Europe/Lisbon", "Europe/Madrid", "07:30", "1 month", ""); err == nil {
+		t.Errorf("expected error for a calendar commute duration, got nil")
+	}
+}
+
+func TestFormatAll(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	res, err := ts.FormatAll("2025-05-04T17:05:09Z", "America/New_York")
+	if err != nil {
+		t.Fatalf("FormatAll error: %v", err)
+	}
+	if res.Offset != "-04:00" || !res.IsDST {
+		t.Errorf("offset = %s dst=%v, want -04:00 and DST", res.Offset, res.IsDST)
+	}
+	want := map[string]string{
+		"rfc3339":     "2025-05-04T13:05:09-04:00",
+		"rfc1123":     "Sun, 04 May 2025 13:05:09 EDT",
+		"iso_date":    "2025-05-04",
+		"us_date":     "05/04/2025",
+		"eu_date":     "04/05/2025",
+		"unix":        "1746378309",
+		"unix_millis": "1746378309000",
+		"kitchen":     "1:05PM",
+		"human":       "Sunday, May 4, 2025 at 1:05 PM EDT",
+	}
+	for k, v := range want {
+		if got := res.Formats[k]; got != v {
+			t.Errorf("format %s = %q, want %q", k, got, v)
+		}
+	}
+}
+
+func TestConvertEpoch(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	res, err := ts.ConvertEpoch(0, "gps", "unix")
+	if err != nil {
+		t.Fatalf("ConvertEpoch error: %v", err)
+	}
+	if res.Result != 315964800 || res.Instant != "1980-01-06T00:00:00Z" {
+		t.Errorf("GPS origin = %+v, want 315964800 at 1980-01-06", res)
+	}
+
+	ntp, err := ts.ConvertEpoch(1746378309, "unix", "ntp")
+	if err != nil {
+		t.Fatalf("ConvertEpoch error: %v", err)
+	}
+	if ntp.Result != 1746378309+2208988800 {
+		t.Errorf("unix to ntp = %v", ntp.Result)
+	}
+
+	epochs := []string{"unix", "j2000", "gps", "ntp"}
+	for _, a := range epochs {
+		for _, b := range epochs {
+			there, err := ts.ConvertEpoch(1234567890.25, a, b)
+			if err != nil {
+				t.Fatalf("ConvertEpoch %s->%s error: %v", a, b, err)
+			}
+			back, err := ts.ConvertEpoch(there.Result, b, a)
+			if err != nil {
+				t.Fatalf("ConvertEpoch %s->%s error: %v", b, a, err)
+			}
+			if back.Result != 1234567890.25 {
+				t.Errorf("%s->%s->%s = %v, want the original value", a, b, a, back.Result)
+			}
+		}
+	}
+
+	if _, err := ts.ConvertEpoch(1e17, "unix", "gps"); err == nil {
+		t.Errorf("expected error for a value beyond 2^53, got nil")
+	}
+	if _, err := ts.ConvertEpoch(0, "unix", "tai"); err == nil {
+		t.Errorf("expected error for an unknown epoch, got nil")
+	}
+}
+
+func TestJDToLocal(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	cases := []struct {
+		name, jd, tz, utc, local string
+	}{
+		{"unixEpoch", "2440587.5", "UTC", "1970-01-01T00:00:00Z", "1970-01-01T00:00:00Z"},
+		{"j2000", "2451545.0", "UTC", "2000-01-01T12:00:00Z", "2000-01-01T12:00:00Z"},
+		// 1ms = 1/86400000 day = 0.00000001157407407... day.
+		{"oneMillisecond", "2451545.0000000115740740740740741", "UTC", "2000-01-01T12:00:00.001Z", "2000-01-01T12:00:00.001Z"},
+		{"halfSecond", "2460000.500005787037037037", "Asia/Tokyo", "2023-02-25T00:00:00.5Z", "2023-02-25T09:00:00.5+09:00"},
+		{"quarterDay", "2460000.75", "America/New_York", "2023-02-25T06:00:00Z", "2023-02-25T01:00:00-05:00"},
+		{"beforeEpoch", "2440587.25", "UTC", "1969-12-31T18:00:00Z", "1969-12-31T18:00:00Z"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.JDToLocal(tc.jd, tc.tz)
+			if err != nil {
+				t.Fatalf("JDToLocal error: %v", err)
+			}
+			if res.UTC != tc.utc || res.LocalExact != tc.local {
+				t.Errorf("got %s / %s, want %s / %s", res.UTC, res.LocalExact, tc.utc, tc.local)
+			}
+		})
+	}
+
+	// Successive millisecond steps must not drift.
+	for ms := 0; ms < 1000; ms += 37 {
+		num := new(big.Rat).SetFrac64(int64(ms), 86400000)
+		jd := "2451545." + strings.TrimPrefix(num.FloatString(25), "0.")
+		res, err := ts.JDToLocal(jd, "UTC")
+		if err != nil {
+			t.Fatalf("JDToLocal(%s) error: %v", jd, err)
+		}
+		want := time.Date(2000, 1, 1, 12, 0, 0, ms*int(time.Millisecond), time.UTC).UnixNano()
+		if res.UnixNanos != want {
+			t.Errorf("JDToLocal(%s) = %d ns, want %d", jd, res.UnixNanos, want)
+		}
+	}
+
+	for _, bad := range []string{"", "abc", "2451545.0e3", "-", "99999999999"} {
+		if _, err := ts.JDToLocal(bad, "UTC"); err == nil {
+			t.Errorf("JDToLocal(%q): expected error", bad)
+		}
+	}
+}
+
+func TestConvertTimeDayOffset(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2024, 11, 5, 12, 0, 0, 0, time.UTC) })
+
+	cases := []struct {
+		src, hhmm, dst string
+		offset         int
+		change         string
+	}{
+		{"America/Los_Angeles", "15:30", "Asia/Tokyo", 1, "next day"},
+		{"Asia/Tokyo", "08:30", "America/Los_Angeles", -1, "previous day"},
+		{"Europe/London", "12:00", "Europe/Paris", 0, "same day"},
+		{"Pacific/Pago_Pago", "23:00", "Pacific/Kiritimati", 2, "+2 days"},
+	}
+	for _, tc := range cases {
+		res, err := ts.ConvertTime(tc.src, tc.hhmm, tc.dst, "")
+		if err != nil {
+			t.Fatalf("ConvertTime(%s %s -> %s) error: %v", tc.src, tc.hhmm, tc.dst, err)
+		}
+		if res.DayOffset != tc.offset || res.DayChange != tc.change {
+			t.Errorf("ConvertTime(%s %s -> %s) = %d %q, want %d %q", tc.src, tc.hhmm, tc.dst, res.DayOffset, res.DayChange, tc.offset, tc.change)
+		}
+	}
+}
+
+func TestTimeResultUnix(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	now := time.Date(2024, 11, 5, 23, 30, 15, 250*int(time.Millisecond), time.UTC)
+	ts.forTesting_SetNowFunc(func() time.Time { return now })
+
+	cur, err := ts.GetCurrentTime("Asia/Tokyo", "")
+	if err != nil {
+		t.Fatalf("GetCurrentTime error: %v", err)
+	}
+	if cur.UnixSeconds != now.Unix() || cur.UnixMillis != now.UnixMilli() {
+		t.Errorf("GetCurrentTime unix = %d / %d ms, want %d / %d", cur.UnixSeconds, cur.UnixMillis, now.Unix(), now.UnixMilli())
+	}
+
+	conv, err := ts.ConvertTime("America/Los_Angeles", "15:30", "Asia/Tokyo", "")
+	if err != nil {
+		t.Fatalf("ConvertTime error: %v", err)
+	}
+	for _, r := range []TimeResult{conv.Source, conv.Target} {
+		tm, err := time.Parse(time.RFC3339, r.Datetime)
+		if err != nil {
+			t.Fatalf("bad datetime %q: %v", r.Datetime, err)
+		}
+		if r.UnixSeconds != tm.Unix() || r.UnixMillis != tm.UnixMilli() {
+			t.Errorf("%s: unix %d / %d ms does not match %s", r.Timezone, r.UnixSeconds, r.UnixMillis, r.Datetime)
+		}
+	}
+	if conv.Source.UnixSeconds != conv.Target.UnixSeconds {
+		t.Errorf("source and target unix differ: %d vs %d", conv.Source.UnixSeconds, conv.Target.UnixSeconds)
+	}
+}
+
+func TestEpochToTime(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	cases := []struct {
+		name  string
+		epoch int64
+		unit  string
+		tz    string
+		want  string
+		ms    int64
+	}{
+		{"seconds", 1730835045, "", "America/New_York", "2024-11-05T14:30:45-05:00", 1730835045000},
+		{"milliseconds", 1730835045123, "ms", "UTC", "2024-11-05T19:30:45Z", 1730835045123},
+		{"microseconds", 1730835045123456, "us", "Asia/Tokyo", "2024-11-06T04:30:45+09:00", 1730835045123},
+		{"nanoseconds", 1730835045123456789, "NS", "UTC", "2024-11-05T19:30:45Z", 1730835045123},
+		{"preEpoch", -86400, "s", "UTC", "1969-12-31T00:00:00Z", -86400000},
+		{"preEpochMillis", -1500, "ms", "UTC", "1969-12-31T23:59:58Z", -1500},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.EpochToTime(tc.epoch, tc.unit, tc.tz)
+			if err != nil {
+				t.Fatalf("EpochToTime error: %v", err)
+			}
+			if res.Datetime != tc.want || res.UnixMillis != tc.ms {
+				t.Errorf("got %s (%d ms), want %s (%d ms)", res.Datetime, res.UnixMillis, tc.want, tc.ms)
+			}
+		})
+	}
+
+	if _, err := ts.EpochToTime(0, "minutes", "UTC"); err == nil {
+		t.Error("expected error for unknown unit")
+	}
+}
+
+func TestConvertTimeWithDate(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	// Today is in northern winter: New York EST, London GMT.
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC) })
+
+	cases := []struct {
+		name, src, in, dst, source, target, diff string
+	}{
+		{"todayWinter", "America/New_York", "09:00", "Europe/London", "2025-01-15T09:00:00-05:00", "2025-01-15T14:00:00Z", "+5h"},
+		{"summerBothDST", "America/New_York", "2025-07-01 09:00", "Europe/London", "2025-07-01T09:00:00-04:00", "2025-07-01T14:00:00+01:00", "+5h"},
+		// Between the US (Mar 9) and EU (Mar 30) changes the gap is 4h.
+		{"mismatchedDST", "America/New_York", "2025-03-20 09:00", "Europe/London", "2025-03-20T09:00:00-04:00", "2025-03-20T13:00:00Z", "+4h"},
+		{"southernSummer", "Australia/Sydney", "2025-01-20 08:00", "Asia/Tokyo", "2025-01-20T08:00:00+11:00", "2025-01-20T06:00:00+09:00", "-2h"},
+		{"southernWinter", "Australia/Sydney", "2025-07-20 08:00", "Asia/Tokyo", "2025-07-20T08:00:00+10:00", "2025-07-20T07:00:00+09:00", "-1h"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.ConvertTime(tc.src, tc.in, tc.dst, "")
+			if err != nil {
+				t.Fatalf("ConvertTime error: %v", err)
+			}
+			if res.Source.Datetime != tc.source || res.Target.Datetime != tc.target || res.TimeDifference != tc.diff {
+				t.Errorf("got %s -> %s (%s), want %s -> %s (%s)", res.Source.Datetime, res.Target.Datetime, res.TimeDifference, tc.source, tc.target, tc.diff)
+			}
+		})
+	}
+
+	for _, bad := range []string{"2025-13-01 09:00", "2025-07-01 9", "tomorrow 09:00"} {
+		if _, err := ts.ConvertTime("UTC", bad, "UTC", ""); err == nil {
+			t.Errorf("ConvertTime(%q): expected error", bad)
+		}
+	}
+}
+
+func TestTimeDifference(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC) })
+
+	cases := []struct {
+		name, a, b, at, diff string
+		secs                 int
+	}{
+		{"nowTokyoFromNewYork", "America/New_York", "Asia/Tokyo", "", "+14h", 14 * 3600},
+		{"summerTokyoFromNewYork", "America/New_York", "Asia/Tokyo", "2025-07-01T12:00:00Z", "+13h", 13 * 3600},
+		{"winterLondon", "America/New_York", "Europe/London", "2025-01-15T12:00:00Z", "+5h", 5 * 3600},
+		{"springGapLondon", "America/New_York", "Europe/London", "2025-03-20T12:00:00Z", "+4h", 4 * 3600},
+		{"reversed", "Asia/Kolkata", "Europe/London", "2025-01-15T12:00:00Z", "-5.5h", -19800},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.TimeDifference(tc.a, tc.b, tc.at)
+			if err != nil {
+				t.Fatalf("TimeDifference error: %v", err)
+			}
+			if res.Difference != tc.diff || res.DifferenceSeconds != tc.secs {
+				t.Errorf("got %s (%d s), want %s (%d s)", res.Difference, res.DifferenceSeconds, tc.diff, tc.secs)
+			}
+			if res.DifferenceSeconds != res.To.OffsetSeconds-res.From.OffsetSeconds {
+				t.Errorf("difference %d does not match offsets %s and %s", res.DifferenceSeconds, res.From.Offset, res.To.Offset)
+			}
+		})
+	}
+
+	if _, err := ts.TimeDifference("UTC", "Not/AZone", ""); err == nil {
+		t.Error("expected error for unknown timezone")
+	}
+}
+
+func TestOutputFormat(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 15, 4, 5, 0, time.UTC) })
+
+	cases := []struct {
+		format, want string
+		warn         bool
+	}{
+		{"", "2025-05-14T11:04:05-04:00", false},
+		{"RFC3339", "2025-05-14T11:04:05-04:00", false},
+		{"rfc1123", "Wed, 14 May 2025 11:04:05 EDT", false},
+		{"kitchen", "11:04AM", false},
+		{"unix", "1747235045", false},
+		{"date", "2025-05-14", false},
+		{"iso_week", "2025-W20-3", false},
+		{"02 Jan 2006 15:04", "14 May 2025 11:04", false},
+		{"not a layout", "2025-05-14T11:04:05-04:00", true},
+	}
+	for _, tc := range cases {
+		res, err := ts.GetCurrentTime("America/New_York", tc.format)
+		if err != nil {
+			t.Fatalf("GetCurrentTime(%q) error: %v", tc.format, err)
+		}
+		if res.Datetime != tc.want || (res.Warning != "") != tc.warn {
+			t.Errorf("format %q = %q (warning %q), want %q", tc.format, res.Datetime, res.Warning, tc.want)
+		}
+	}
+
+	conv, err := ts.ConvertTime("America/New_York", "2025-05-14 09:00", "Europe/London", "kitchen")
+	if err != nil {
+		t.Fatalf("ConvertTime error: %v", err)
+	}
+	if conv.Source.Datetime != "9:00AM" || conv.Target.Datetime != "2:00PM" {
+		t.Errorf("ConvertTime kitchen = %s -> %s", conv.Source.Datetime, conv.Target.Datetime)
+	}
+}
+
+func TestTimeResultOffset(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 15, 0, 0, 0, time.UTC) })
+
+	cases := []struct{ tz, offset, abbr string }{
+		{"UTC", "+00:00", "UTC"},
+		{"America/New_York", "-04:00", "EDT"},
+		{"Asia/Kolkata", "+05:30", "IST"},
+		{"Australia/Sydney", "+10:00", "AEST"},
+	}
+	for _, tc := range cases {
+		res, err := ts.GetCurrentTime(tc.tz, "")
+		if err != nil {
+			t.Fatalf("GetCurrentTime(%s) error: %v", tc.tz, err)
+		}
+		if res.UTCOffset != tc.offset || res.Abbreviation != tc.abbr {
+			t.Errorf("%s: offset %s %s, want %s %s", tc.tz, res.UTCOffset, res.Abbreviation, tc.offset, tc.abbr)
+		}
+	}
+
+	conv, err := ts.ConvertTime("America/New_York", "2025-01-15 09:00", "Europe/London", "")
+	if err != nil {
+		t.Fatalf("ConvertTime error: %v", err)
+	}
+	if conv.Source.UTCOffset != "-05:00" || conv.Source.Abbreviation != "EST" || conv.Target.UTCOffset != "+00:00" || conv.Target.Abbreviation != "GMT" {
+		t.Errorf("ConvertTime offsets = %s %s -> %s %s", conv.Source.UTCOffset, conv.Source.Abbreviation, conv.Target.UTCOffset, conv.Target.Abbreviation)
+	}
+
+	nat, err := ts.ParseNatural("tomorrow at noon", "America/Los_Angeles", "", "")
+	if err != nil {
+		t.Fatalf("ParseNatural error: %v", err)
+	}
+	if nat.UTCOffset != "-07:00" || nat.Abbreviation != "PDT" {
+		t.Errorf("ParseNatural offset = %s %s, want -07:00 PDT", nat.UTCOffset, nat.Abbreviation)
+	}
+}
+
+func TestConvertTimeMeridiem(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC) })
+
+	cases := []struct {
+		name, in, want string
+	}{
+		{"midnight", "12:00 AM", "2025-01-15T00:00:00Z"},
+		{"noon", "12:00 PM", "2025-01-15T12:00:00Z"},
+		{"lowerPM", "1:05 pm", "2025-01-15T13:05:00Z"},
+		{"noSpace", "2:30PM", "2025-01-15T14:30:00Z"},
+		{"dotted", "11:59 p.m.", "2025-01-15T23:59:00Z"},
+		{"morning", "9:15 am", "2025-01-15T09:15:00Z"},
+		{"withDate", "2025-07-01 2:30 PM", "2025-07-01T14:30:00Z"},
+		{"twentyFourHour", "14:30", "2025-01-15T14:30:00Z"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.ConvertTime("UTC", tc.in, "UTC", "")
+			if err != nil {
+				t.Fatalf("ConvertTime(%q) error: %v", tc.in, err)
+			}
+			if res.Source.Datetime != tc.want {
+				t.Errorf("ConvertTime(%q) = %s, want %s", tc.in, res.Source.Datetime, tc.want)
+			}
+		})
+	}
+
+	for _, bad := range []string{"0:30 AM", "13:00 PM", "12:60 PM", "PM", "2 PM"} {
+		if _, err := ts.ConvertTime("UTC", bad, "UTC", ""); err == nil {
+			t.Errorf("ConvertTime(%q): expected error", bad)
+		}
+	}
+}
+
+func TestConvertTimeSeconds(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC) })
+
+	cases := []struct {
+		name, in, source, target string
+	}{
+		{"withSeconds", "09:30:45", "2025-01-15T09:30:45-05:00", "2025-01-15T23:30:45+09:00"},
+		{"withoutSeconds", "09:30", "2025-01-15T09:30:00-05:00", "2025-01-15T23:30:00+09:00"},
+		{"lastSecond", "23:59:59", "2025-01-15T23:59:59-05:00", "2025-01-16T13:59:59+09:00"},
+		{"withDate", "2025-07-01 09:30:05", "2025-07-01T09:30:05-04:00", "2025-07-01T22:30:05+09:00"},
+		{"meridiem", "9:30:15 PM", "2025-01-15T21:30:15-05:00", "2025-01-16T11:30:15+09:00"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.ConvertTime("America/New_York", tc.in, "Asia/Tokyo", "")
+			if err != nil {
+				t.Fatalf("ConvertTime(%q) error: %v", tc.in, err)
+			}
+			if res.Source.Datetime != tc.source || res.Target.Datetime != tc.target {
+				t.Errorf("ConvertTime(%q) = %s -> %s, want %s -> %s", tc.in, res.Source.Datetime, res.Target.Datetime, tc.source, tc.target)
+			}
+		})
+	}
+
+	for _, bad := range []string{"09:30:60", "09:30:-1", "09:30:xx", "09:30:00:00"} {
+		if _, err := ts.ConvertTime("UTC", bad, "UTC", ""); err == nil {
+			t.Errorf("ConvertTime(%q): expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,910 @@
+// duration.go
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+/* ----- data types ----- */
+
+// calendarDuration is a duration that may contain calendar units. Years,
+// months and days are applied with AddDate so they keep wall-clock time
+// across DST; Clock is applied with Add as elapsed time.
+type calendarDuration struct {
+	Years  int
+	Months int
+	Days   int
+	Clock  time.Duration
+}
+
+type DurationValue struct {
+	Input      string  `json:"input"`
+	Format     string  `json:"format"`
+	Seconds    float64 `json:"seconds"`
+	Normalized string  `json:"normalized"`
+}
+
+type DurationComparison struct {
+	First             DurationValue `json:"first"`
+	Second            DurationValue `json:"second"`
+	Longer            string        `json:"longer"` // "first", "second" or "equal"
+	Equal             bool          `json:"equal"`
+	Difference        string        `json:"difference"`
+	DifferenceSeconds float64       `json:"difference_seconds"`
+}
+
+type ExprStep struct {
+	Term   string     `json:"term"`
+	Result TimeResult `json:"result"`
+}
+
+type TimeExprResult struct {
+	Expression string     `json:"expression"`
+	Anchor     TimeResult `json:"anchor"`
+	Result     TimeResult `json:"result"`
+	Steps      []ExprStep `json:"steps"`
+}
+
+type CountdownResult struct {
+	Target   TimeResult `json:"target"`
+	Now      TimeResult `json:"now"`
+	Prefix   string     `json:"prefix"` // "T-minus" or "T-plus"
+	Display  string     `json:"display"`
+	Seconds  int64      `json:"seconds"` // positive before the target
+	Days     *int64     `json:"days,omitempty"`
+	Launched bool       `json:"launched"`
+}
+
+type WallAfterResult struct {
+	Start           TimeResult  `json:"start"`
+	Duration        string      `json:"duration"`
+	WallClock       TimeResult  `json:"wall_clock"`
+	PhysicalElapsed string      `json:"physical_elapsed"`
+	Absolute        *TimeResult `json:"absolute,omitempty"`
+	DSTShift        string      `json:"dst_shift,omitempty"`
+	Note            string      `json:"note,omitempty"`
+}
+
+type DurationAtAnchorResult struct {
+	Anchor            TimeResult `json:"anchor"`
+	Duration          string     `json:"duration"`
+	End               TimeResult `json:"end"`
+	AbsoluteHours     float64    `json:"absolute_hours"`
+	AbsoluteDays      float64    `json:"absolute_days"`
+	Absolute          string     `json:"absolute"`
+	NominalHours      float64    `json:"nominal_hours"` // months as 30 days, years as 365
+	DifferenceHours   float64    `json:"difference_hours"`
+	ClampedToMonthEnd bool       `json:"clamped_to_month_end"`
+}
+
+type DurationResult struct {
+	Expression string     `json:"expression"`
+	Target     TimeResult `json:"target"`
+	Now        TimeResult `json:"now"`
+	Human      string     `json:"human"`    // "2 days 4 hours", or "... ago" when past
+	Duration   string     `json:"duration"` // signed, in the requested format
+	Seconds    int64      `json:"seconds"`  // negative when the target is past
+	Past       bool       `json:"past"`
+}
+
+/* ----- parsing ----- */
+
+const day = 24 * time.Hour
+
+var (
+	isoDurationRe       = regexp.MustCompile(`^([+-])?P(?:(\d+(?:[.,]\d+)?)Y)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+	isoDurationPrefixRe = regexp.MustCompile(`^[+-]?[Pp][\dTt]`)
+	goDurationRe        = regexp.MustCompile(`^[+-]?(?:\d+(?:\.\d*)?(?:ns|us|µs|ms|s|m|h|d|w))+$`)
+	goPartRe            = regexp.MustCompile(`(\d+(?:\.\d*)?)(ns|us|µs|ms|s|m|h|d|w)`)
+)
+
+// parseDurationSpec parses a Go-style ("90m", "1d12h"), ISO-8601 ("PT1H30M")
+// or natural-language ("an hour and a half") duration and reports which
+// format was recognised.
+func parseDurationSpec(s string) (calendarDuration, string, error) {
+	in := strings.TrimSpace(s)
+	if in == "" {
+		return calendarDuration{}, "", fmt.Errorf("duration must not be empty")
+	}
+	if isoDurationPrefixRe.MatchString(in) {
+		d, err := parseISODuration(strings.ToUpper(in))
+		return d, "iso8601", err
+	}
+	if goDurationRe.MatchString(in) {
+		d, err := parseGoDuration(in)
+		return d, "go", err
+	}
+	d, err := parseNaturalDuration(in)
+	return d, "natural", err
+}
+
+func parseISODuration(s string) (calendarDuration, error) {
+	m := isoDurationRe.FindStringSubmatch(s)
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return calendarDuration{}, fmt.Errorf("invalid ISO-8601 duration: %s", s)
+	}
+	num := func(v string) float64 {
+		if v == "" {
+			return 0
+		}
+		f, _ := strconv.ParseFloat(strings.Replace(v, ",", ".", 1), 64)
+		return f
+	}
+	years, months := num(m[2]), num(m[3])
+	if years != math.Trunc(years) || months != math.Trunc(months) {
+		return calendarDuration{}, fmt.Errorf("fractional years or months are not supported: %s", s)
+	}
+	var d calendarDuration
+	d.Years, d.Months = int(years), int(months)
+	d.addDays(num(m[4])*7 + num(m[5]))
+	d.Clock += time.Duration(num(m[6])*float64(time.Hour) + num(m[7])*float64(time.Minute) + num(m[8])*float64(time.Second))
+	if m[1] == "-" {
+		d = d.negate()
+	}
+	return d, nil
+}
+
+// parseGoDuration accepts time.ParseDuration syntax extended with "d" and "w".
+func parseGoDuration(s string) (calendarDuration, error) {
+	var d calendarDuration
+	for _, p := range goPartRe.FindAllStringSubmatch(s, -1) {
+		switch p[2] {
+		case "d", "w":
+			f, err := strconv.ParseFloat(p[1], 64)
+			if err != nil {
+				return calendarDuration{}, fmt.Errorf("invalid duration: %s", s)
+			}
+			if p[2] == "w" {
+				f *= 7
+			}
+			d.addDays(f)
+		default:
+			c, err := time.ParseDuration(p[0])
+			if err != nil {
+				return calendarDuration{}, fmt.Errorf("invalid duration: %s", s)
+			}
+			d.Clock += c
+		}
+	}
+	if strings.HasPrefix(s, "-") {
+		d = d.negate()
+	}
+	return d, nil
+}
+
+var numberWords = map[string]float64{
+	"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+	"thirteen": 13, "fourteen": 14, "fifteen": 15, "sixteen": 16, "seventeen": 17,
+	"eighteen": 18, "nineteen": 19, "twenty": 20, "thirty": 30, "forty": 40,
+	"fifty": 50, "sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+	"couple": 2, "few": 3,
+}
+
+// durationUnit maps a natural-language unit word to its canonical unit.
+func durationUnit(w string) (string, bool) {
+	switch w {
+	case "ns", "nanosecond", "nanoseconds":
+		return "ns", true
+	case "ms", "millisecond", "milliseconds":
+		return "ms", true
+	case "s", "sec", "secs", "second", "seconds":
+		return "s", true
+	case "m", "min", "mins", "minute", "minutes":
+		return "m", true
+	case "h", "hr", "hrs", "hour", "hours":
+		return "h", true
+	case "d", "day", "days":
+		return "d", true
+	case "w", "wk", "wks", "week", "weeks":
+		return "w", true
+	case "fortnight", "fortnights":
+		return "fortnight", true
+	case "mo", "month", "months":
+		return "mo", true
+	case "y", "yr", "yrs", "year", "years":
+		return "y", true
+	}
+	return "", false
+}
+
+// parseNaturalDuration understands phrases such as "90 minutes",
+// "2 days and 3 hours", "half an hour" or "an hour and a half". A trailing
+// fraction with no unit ("and a half") applies to the preceding unit; any
+// other number without a unit ("2 hours 30") is rejected.
+func parseNaturalDuration(s string) (calendarDuration, error) {
+	var d calendarDuration
+	fields := strings.Fields(strings.NewReplacer(",", " ", "-", " ").Replace(strings.ToLower(s)))
+	var (
+		pending     float64
+		havePending bool
+		article     bool // pending only came from "a"/"an"
+		fraction    bool // pending only came from "half"/"quarter"
+		lastUnit    string
+		sign        = 1.0
+		span        float64 // running fixed length in ns, to catch overflow
+	)
+	sizes := map[string]time.Duration{
+		"ns": time.Nanosecond, "ms": time.Millisecond, "s": time.Second, "m": time.Minute,
+		"h": time.Hour, "d": day, "w": 7 * day, "fortnight": 14 * day,
+	}
+	apply := func(v float64, unit string) error {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return fmt.Errorf("duration must be a finite number: %s", s)
+		}
+		v *= sign
+		if size, ok := sizes[unit]; ok {
+			span += v * float64(size)
+			if math.Abs(span) >= math.MaxInt64 {
+				return fmt.Errorf("duration out of range: %s", s)
+			}
+		} else if math.Abs(v) > math.MaxInt32 {
+			return fmt.Errorf("duration out of range: %s", s)
+		}
+		switch unit {
+		case "ns":
+			d.Clock += time.Duration(v)
+		case "ms":
+			d.Clock += time.Duration(v * float64(time.Millisecond))
+		case "s":
+			d.Clock += time.Duration(v * float64(time.Second))
+		case "m":
+			d.Clock += time.Duration(v * float64(time.Minute))
+		case "h":
+			d.Clock += time.Duration(v * float64(time.Hour))
+		case "d":
+			d.addDays(v)
+		case "w":
+			d.addDays(v * 7)
+		case "fortnight":
+			d.addDays(v * 14)
+		case "mo", "y":
+			if v != math.Trunc(v) {
+				return fmt.Errorf("fractional months or years are not supported: %s", s)
+			}
+			if unit == "mo" {
+				d.Months += int(v)
+			} else {
+				d.Years += int(v)
+			}
+		}
+		return nil
+	}
+	matched := false
+	for _, w := range fields {
+		switch {
+		case w == "and" || w == "of" || w == "plus":
+			continue
+		case w == "minus" || w == "negative":
+			sign = -1
+			continue
+		case w == "a" || w == "an":
+			if !havePending {
+				pending, havePending, article = 1, true, true
+			}
+			continue
+		case w == "half" || w == "quarter":
+			frac := 0.5
+			if w == "quarter" {
+				frac = 0.25
+			}
+			if havePending && !article {
+				pending += frac
+			} else {
+				pending, havePending, article, fraction = frac, true, false, true
+			}
+			continue
+		}
+		if v, err := strconv.ParseFloat(w, 64); err == nil {
+			pending, havePending, article, fraction = v, true, false, false
+			continue
+		}
+		if v, ok := numberWords[w]; ok {
+			if havePending && !article && pending >= 20 && v < 10 {
+				pending += v
+			} else {
+				pending, havePending, article, fraction = v, true, false, false
+			}
+			continue
+		}
+		unit, ok := durationUnit(strings.TrimSuffix(w, "."))
+		if !ok {
+			return calendarDuration{}, fmt.Errorf("unrecognised duration term %q in %q", w, s)
+		}
+		if !havePending {
+			pending = 1
+		}
+		if err := apply(pending, unit); err != nil {
+			return calendarDuration{}, err
+		}
+		pending, havePending, article, fraction, lastUnit, matched = 0, false, false, false, unit, true
+	}
+	if havePending {
+		if lastUnit == "" || !fraction {
+			return calendarDuration{}, fmt.Errorf("duration %q is missing a unit", s)
+		}
+		if err := apply(pending, lastUnit); err != nil {
+			return calendarDuration{}, err
+		}
+	}
+	if !matched {
+		return calendarDuration{}, fmt.Errorf("could not parse duration: %s", s)
+	}
+	return d, nil
+}
+
+/* ----- calendarDuration helpers ----- */
+
+// addDays adds whole days as calendar days and any fraction as clock time.
+func (d *calendarDuration) addDays(f float64) {
+	whole := math.Trunc(f)
+	d.Days += int(whole)
+	d.Clock += time.Duration((f - whole) * float64(day))
+}
+
+func (d calendarDuration) negate() calendarDuration {
+	return calendarDuration{Years: -d.Years, Months: -d.Months, Days: -d.Days, Clock: -d.Clock}
+}
+
+// fixed converts d to an absolute duration, counting a day as 24 hours.
+// Months and years have no fixed length and are rejected.
+func (d calendarDuration) fixed() (time.Duration, error) {
+	if d.Years != 0 || d.Months != 0 {
+		return 0, fmt.Errorf("months and years have no fixed length; use days or smaller units")
+	}
+	return time.Duration(d.Days)*day + d.Clock, nil
+}
+
+// addTo applies d to tm: calendar units first via AddDate, then clock time.
+func (d calendarDuration) addTo(tm time.Time) time.Time {
+	return tm.AddDate(d.Years, d.Months, d.Days).Add(d.Clock)
+}
+
+// humanDuration spells out |d| in days, hours, minutes and seconds, skipping
+// zero units: "2 days 4 hours", "1 minute 5 seconds". Fractions of a second
+// are dropped and a zero duration is "0 seconds".
+func humanDuration(d time.Duration) string {
+	secs := int64(d / time.Second)
+	if secs < 0 {
+		secs = -secs
+	}
+	var parts []string
+	for _, u := range []struct {
+		name string
+		size int64
+	}{{"day", 86400}, {"hour", 3600}, {"minute", 60}, {"second", 1}} {
+		n := secs / u.size
+		if n == 0 {
+			continue
+		}
+		unit := u.name
+		if n != 1 {
+			unit += "s"
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", n, unit))
+		secs %= u.size
+	}
+	if len(parts) == 0 {
+		return "0 seconds"
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatISODuration renders d as an ISO 8601 duration such as "P1DT2H30M"
+// or "PT0.25S". Days are 24 hours of elapsed time; sub-second precision is
+// kept with trailing zeros trimmed. Zero is "PT0S" and negative durations
+// are prefixed with "-".
+func formatISODuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	h, m := d/time.Hour, d%time.Hour/time.Minute
+	secs, nanos := d%time.Minute/time.Second, d%time.Second
+
+	var b strings.Builder
+	b.WriteString(sign + "P")
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if h == 0 && m == 0 && secs == 0 && nanos == 0 {
+		return b.String()
+	}
+	b.WriteString("T")
+	if h > 0 {
+		fmt.Fprintf(&b, "%dH", h)
+	}
+	if m > 0 {
+		fmt.Fprintf(&b, "%dM", m)
+	}
+	if secs > 0 || nanos > 0 {
+		fmt.Fprintf(&b, "%d", secs)
+		if nanos > 0 {
+			b.WriteString("." + strings.TrimRight(fmt.Sprintf("%09d", nanos), "0"))
+		}
+		b.WriteString("S")
+	}
+	return b.String()
+}
+
+// formatDuration renders d as "go" (default; Duration.String), "human"
+// (humanDuration with a "-" sign when negative) or "iso8601"
+// (formatISODuration).
+func formatDuration(d time.Duration, format string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", "go":
+		return d.String(), nil
+	case "human":
+		if d <= -time.Second {
+			return "-" + humanDuration(d), nil
+		}
+		return humanDuration(d), nil
+	case "iso8601", "iso":
+		return formatISODuration(d), nil
+	}
+	return "", fmt.Errorf("unknown duration format %q (want go, human or iso8601)", format)
+}
+
+// durationFormatter validates format once and returns a renderer for it, so
+// tools reporting several durations fail before doing any work. Every tool
+// that returns a duration takes this format option.
+func durationFormatter(format string) (func(time.Duration) string, error) {
+	if _, err := formatDuration(0, format); err != nil {
+		return nil, err
+	}
+	return func(d time.Duration) string {
+		s, _ := formatDuration(d, format)
+		return s
+	}, nil
+}
+
+/* ----- core methods ----- */
+
+// CompareDurations normalizes two durations given in any supported format
+// and reports which is longer and by how much, rendering the normalized
+// values and the difference in format (see durationFormatter).
+func (t *TimeServer) CompareDurations(a, b, format string) (DurationComparison, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return DurationComparison{}, err
+	}
+	value := func(s string) (DurationValue, time.Duration, error) {
+		cd, format, err := parseDurationSpec(s)
+		if err != nil {
+			return DurationValue{}, 0, err
+		}
+		d, err := cd.fixed()
+		if err != nil {
+			return DurationValue{}, 0, fmt.Errorf("%s: %w", s, err)
+		}
+		return DurationValue{Input: s, Format: format, Seconds: d.Seconds(), Normalized: render(d)}, d, nil
+	}
+	first, da, err := value(a)
+	if err != nil {
+		return DurationComparison{}, err
+	}
+	second, db, err := value(b)
+	if err != nil {
+		return DurationComparison{}, err
+	}
+
+	res := DurationComparison{First: first, Second: second, Longer: "equal", Equal: da == db}
+	diff := da - db
+	switch {
+	case diff > 0:
+		res.Longer = "first"
+	case diff < 0:
+		res.Longer = "second"
+		diff = -diff
+	}
+	res.Difference = render(diff)
+	res.DifferenceSeconds = diff.Seconds()
+	return res, nil
+}
+
+// isOperatorField reports whether an expression field starts a new term:
+// a bare "+"/"-", a signed number such as "+3d" or a signed ISO 8601
+// duration such as "-PT30M".
+func isOperatorField(f string) bool {
+	if f == "+" || f == "-" {
+		return true
+	}
+	if len(f) < 2 || (f[0] != '+' && f[0] != '-') {
+		return false
+	}
+	return (f[1] >= '0' && f[1] <= '9') || isoDurationPrefixRe.MatchString(f)
+}
+
+// EvalTimeExpr evaluates "<anchor> (+|-) <duration> ..." left to right. The
+// anchor is "now", a date/datetime or a natural-language phrase and may be
+// omitted (meaning now). Each term is any duration parseDurationSpec accepts;
+// months and years are applied with AddDate and smaller units with Add.
+func (t *TimeServer) EvalTimeExpr(expr, tz string) (TimeExprResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return TimeExprResult{}, err
+	}
+	fields := strings.Fields(expr)
+	i := 0
+	for i < len(fields) && !isOperatorField(fields[i]) {
+		i++
+	}
+	anchorText := strings.Join(fields[:i], " ")
+	cur, err := t.parseDateTime(anchorText, loc)
+	if err != nil {
+		return TimeExprResult{}, fmt.Errorf("invalid anchor %q: %w", anchorText, err)
+	}
+	res := TimeExprResult{Expression: expr, Anchor: makeTimeResult(tz, cur)}
+
+	for n := 1; i < len(fields); n++ {
+		op := fields[i][:1]
+		termFields := []string{}
+		if rest := fields[i][1:]; rest != "" {
+			termFields = append(termFields, rest)
+		}
+		i++
+		for i < len(fields) && !isOperatorField(fields[i]) {
+			termFields = append(termFields, fields[i])
+			i++
+		}
+		term := strings.Join(termFields, " ")
+		if term == "" {
+			return TimeExprResult{}, fmt.Errorf("term %d: operator %q is missing a duration", n, op)
+		}
+		d, _, err := parseDurationSpec(term)
+		if err != nil {
+			return TimeExprResult{}, fmt.Errorf("term %d (%s %s): %w", n, op, term, err)
+		}
+		if op == "-" {
+			d = d.negate()
+		}
+		cur = d.addTo(cur)
+		res.Steps = append(res.Steps, ExprStep{Term: op + " " + term, Result: makeTimeResult(tz, cur)})
+	}
+	res.Result = makeTimeResult(tz, cur)
+	return res, nil
+}
+
+// CountdownString renders the time to target as "T-minus HH:MM:SS", or
+// "T-plus" from the target instant onwards. Hours run past 24 unless
+// withDays is set, in which case whole days are split off as "Nd ".
+// Fractions of a second are truncated so the display ticks once per second.
+func (t *TimeServer) CountdownString(target, tz string, withDays bool) (CountdownResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return CountdownResult{}, err
+	}
+	at, err := t.parseDateTime(target, loc)
+	if err != nil {
+		return CountdownResult{}, err
+	}
+	now := t.nowFunc().In(loc)
+
+	remaining := at.Sub(now)
+	res := CountdownResult{Target: makeTimeResult(tz, at), Now: makeTimeResult(tz, now), Prefix: "T-minus"}
+	if remaining <= 0 {
+		res.Prefix, res.Launched = "T-plus", true
+	}
+	secs := int64(remaining / time.Second)
+	res.Seconds = secs
+	if secs < 0 {
+		secs = -secs
+	}
+	clock := secs
+	dayPart := ""
+	if withDays {
+		days := secs / 86400
+		res.Days = &days
+		clock = secs % 86400
+		if days > 0 {
+			dayPart = fmt.Sprintf("%dd ", days)
+		}
+	}
+	res.Display = fmt.Sprintf("%s %s%02d:%02d:%02d", res.Prefix, dayPart, clock/3600, clock/60%60, clock%60)
+	return res, nil
+}
+
+// WallAfter advances start by duration with calendar semantics: days and
+// larger units move the local date and keep the wall-clock time, smaller
+// units add elapsed time. When the duration has a fixed length the plain
+// elapsed-time result is returned alongside, with DSTShift giving how far
+// the two disagree because of an offset change in between. Elapsed times
+// are rendered in format (see durationFormatter).
+func (t *TimeServer) WallAfter(start, duration, tz, format string) (WallAfterResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return WallAfterResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return WallAfterResult{}, err
+	}
+	from, err := t.parseDateTime(start, loc)
+	if err != nil {
+		return WallAfterResult{}, err
+	}
+	cd, _, err := parseDurationSpec(duration)
+	if err != nil {
+		return WallAfterResult{}, err
+	}
+
+	wall := cd.addTo(from)
+	res := WallAfterResult{
+		Start:           makeTimeResult(tz, from),
+		Duration:        duration,
+		WallClock:       makeTimeResult(tz, wall),
+		PhysicalElapsed: render(wall.Sub(from)),
+	}
+	if nominal, err := cd.fixed(); err == nil {
+		abs := from.Add(nominal)
+		tr := makeTimeResult(tz, abs)
+		res.Absolute = &tr
+		res.DSTShift = render(wall.Sub(from) - nominal)
+		if wall.Sub(from) != nominal {
+			res.Note = fmt.Sprintf("an offset change makes the wall-clock result %s of physical time instead of %s", render(wall.Sub(from)), render(nominal))
+		}
+	}
+	return res, nil
+}
+
+// AddDuration shifts base (default now) by duration and renders the result
+// in tz. Days and weeks ("2d", "1w", "P3D") are calendar days added with
+// AddDate, so they keep the wall-clock time across a DST change; hours and
+// smaller units are elapsed time. "1d" over a spring-forward night is thus
+// 23 hours of real time, while "24h" lands an hour later on the clock.
+func (t *TimeServer) AddDuration(base, duration, tz string) (TimeResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return TimeResult{}, err
+	}
+	from, err := t.parseDateTime(base, loc)
+	if err != nil {
+		return TimeResult{}, err
+	}
+	cd, _, err := parseDurationSpec(duration)
+	if err != nil {
+		return TimeResult{}, err
+	}
+	return makeTimeResult(tz, cd.addTo(from.In(loc))), nil
+}
+
+// DurationAtAnchor resolves a calendar duration against a concrete anchor
+// and reports how much real time it spans there. Years and months are added
+// with month-end clamping (Jan 31 + 1 month = Feb 28/29), days keep the wall
+// clock time and smaller units are elapsed time, so the absolute length
+// reflects both month lengths and any DST change crossed. The nominal
+// length (30-day months, 365-day years) is given for comparison. Absolute
+// is rendered in format (see durationFormatter).
+func (t *TimeServer) DurationAtAnchor(duration, anchor, tz, format string) (DurationAtAnchorResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return DurationAtAnchorResult{}, err
+	}
+	cd, _, err := parseDurationSpec(duration)
+	if err != nil {
+		return DurationAtAnchorResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return DurationAtAnchorResult{}, err
+	}
+	from, err := t.parseDateTime(anchor, loc)
+	if err != nil {
+		return DurationAtAnchorResult{}, err
+	}
+
+	months := addMonthsClamped(from, cd.Years*12+cd.Months)
+	end := months.AddDate(0, 0, cd.Days).Add(cd.Clock)
+	span := end.Sub(from)
+	nominal := cd.approx()
+	return DurationAtAnchorResult{
+		Anchor:            makeTimeResult(tz, from),
+		Duration:          duration,
+		End:               makeTimeResult(tz, end),
+		AbsoluteHours:     span.Hours(),
+		AbsoluteDays:      span.Hours() / 24,
+		Absolute:          render(span),
+		NominalHours:      nominal.Hours(),
+		DifferenceHours:   (span - nominal).Hours(),
+		ClampedToMonthEnd: months.Day() != from.Day(),
+	}, nil
+}
+
+// DurationUntil resolves expr as ParseNatural does and measures from the
+// injected now to it in elapsed time. A target in the past gives negative
+// Seconds and a Human string ending in "ago". Duration is the signed span
+// in format (see durationFormatter), truncated to whole seconds.
+func (t *TimeServer) DurationUntil(expr, tz, format string) (DurationResult, error) {
+	tz, at, _, err := t.parseNatural(expr, tz, "")
+	if err != nil {
+		return DurationResult{}, err
+	}
+	now := t.nowFunc().In(at.Location())
+
+	d := at.Sub(now)
+	render, err := durationFormatter(format)
+	if err != nil {
+		return DurationResult{}, err
+	}
+	res := DurationResult{
+		Expression: expr,
+		Target:     makeTimeResult(tz, at),
+		Now:        makeTimeResult(tz, now),
+		Human:      humanDuration(d),
+		Duration:   render(d.Truncate(time.Second)),
+		Seconds:    int64(d / time.Second),
+		Past:       d < 0,
+	}
+	if res.Past && res.Seconds != 0 {
+		res.Human += " ago"
+	}
+	return res, nil
+}
+
+/* ----- tools ----- */
+
+func registerDurationTools(s *server.MCPServer, ts *TimeServer) {
+	compare := mcp.NewTool(
+		"compare_durations",
+		mcp.WithDescription("Compare two durations given as Go-style (90m), ISO-8601 (PT1H30M) or natural language (an hour and a half)."),
+		mcp.WithString("first", mcp.Required()),
+		mcp.WithString("second", mcp.Required()),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(compare, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		a, err := r.RequireString("first")
+		if err != nil {
+			return errorResult(err)
+		}
+		b, err := r.RequireString("second")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.CompareDurations(a, b, r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	timeExpr := mcp.NewTool(
+		"time_expr",
+		mcp.WithDescription("Evaluate time arithmetic such as 'now + 3 days - 2 hours' or '2025-01-01 + 1 month'. Terms are applied left to right; months and years keep the wall-clock time."),
+		mcp.WithString("expression", mcp.Required()),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(timeExpr, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		expr, err := r.RequireString("expression")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.EvalTimeExpr(expr, r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	countdown := mcp.NewTool(
+		"countdown_string",
+		mcp.WithDescription("NASA-style countdown to a target: 'T-minus HH:MM:SS' before it and 'T-plus HH:MM:SS' from the target onwards, plus the raw seconds."),
+		mcp.WithString("target", mcp.Required()),
+		mcp.WithString("timezone"),
+		mcp.WithBoolean("include_days", mcp.Description("Split whole days off as 'Nd HH:MM:SS' instead of letting hours exceed 24.")),
+	)
+
+	s.AddTool(countdown, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		target, err := r.RequireString("target")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.CountdownString(target, r.GetString("timezone", ""), r.GetBool("include_days", false))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	wallAfter := mcp.NewTool(
+		"wall_after",
+		mcp.WithDescription("Advance a local datetime by a duration with calendar semantics ('3 days later at the same wall time'), and show the physical elapsed time and the plain elapsed-time result for comparison across DST."),
+		mcp.WithString("start", mcp.Required()),
+		mcp.WithString("duration", mcp.Required(), mcp.Description("e.g. 3 days, P1M, 1d12h")),
+		mcp.WithString("timezone"),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(wallAfter, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start, err := r.RequireString("start")
+		if err != nil {
+			return errorResult(err)
+		}
+		duration, err := r.RequireString("duration")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.WallAfter(start, duration, r.GetString("timezone", ""), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	anchored := mcp.NewTool(
+		"duration_at_anchor",
+		mcp.WithDescription("How many absolute hours a calendar duration such as 1 month spans when applied at a specific anchor, with the resolved end instant. Month-end anchors clamp (Jan 31 + 1 month = Feb 28)."),
+		mcp.WithString("duration", mcp.Required(), mcp.Description("e.g. 1 month, P1M, 2w")),
+		mcp.WithString("anchor", mcp.Description("Defaults to now.")),
+		mcp.WithString("timezone"),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(anchored, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		duration, err := r.RequireString("duration")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.DurationAtAnchor(duration, r.GetString("anchor", ""), r.GetString("timezone", ""), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	addDuration := mcp.NewTool(
+		"add_duration",
+		mcp.WithDescription("Shift a timestamp by a duration such as 3h15m, 2d or 1w. Days and weeks are calendar days that keep the wall-clock time across DST; hours and smaller are elapsed time."),
+		mcp.WithString("base", mcp.Description("Timestamp to shift (RFC3339). Defaults to now.")),
+		mcp.WithString("duration", mcp.Required(), mcp.Description("e.g. 3h15m, -90m, 2d, 1w or P1DT2H.")),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(addDuration, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		duration, err := r.RequireString("duration")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.AddDuration(r.GetString("base", ""), duration, r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	until := mcp.NewTool(
+		"duration_until",
+		mcp.WithDescription("How long from now until a natural-language time such as 'next Friday 5pm', as '2 days 4 hours' plus total seconds. Past times give negative seconds and '... ago'."),
+		mcp.WithString("expression", mcp.Required()),
+		mcp.WithString("timezone"),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(until, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		expr, err := r.RequireString("expression")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.DurationUntil(expr, r.GetString("timezone", ""), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+}
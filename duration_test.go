@@ -0,0 +1,389 @@
+// duration_test.go
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDurationSpec(t *testing.T) {
+	cases := []struct {
+		in         string
+		wantFormat string
+		want       time.Duration
+	}{
+		{"90m", "go", 90 * time.Minute},
+		{"1d12h", "go", 36 * time.Hour},
+		{"2w", "go", 14 * 24 * time.Hour},
+		{"PT1H30M", "iso8601", 90 * time.Minute},
+		{"P1DT2H", "iso8601", 26 * time.Hour},
+		{"PT0.5S", "iso8601", 500 * time.Millisecond},
+		{"an hour and a half", "natural", 90 * time.Minute},
+		{"half an hour", "natural", 30 * time.Minute},
+		{"one and a half hours", "natural", 90 * time.Minute},
+		{"2 days and 3 hours", "natural", 51 * time.Hour},
+		{"twenty-five minutes", "natural", 25 * time.Minute},
+		{"a quarter of an hour", "natural", 15 * time.Minute},
+		{"2 hours and a quarter", "natural", 135 * time.Minute},
+	}
+	for _, tc := range cases {
+		t.Run(tc.in, func(t *testing.T) {
+			cd, format, err := parseDurationSpec(tc.in)
+			if err != nil {
+				t.Fatalf("parseDurationSpec(%q) error: %v", tc.in, err)
+			}
+			if format != tc.wantFormat {
+				t.Errorf("parseDurationSpec(%q) format = %q, want %q", tc.in, format, tc.wantFormat)
+			}
+			got, err := cd.fixed()
+			if err != nil {
+				t.Fatalf("fixed() error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("parseDurationSpec(%q) = %v, want %v", tc.in, got, tc.want)
+			}
+		})
+	}
+
+	for _, bad := range []string{"", "P", "PT", "soon", "3 bananas", "P1.5M", "2 hours 30", "an hour and twenty",
+		"inf hours", "-inf days", "nan minutes", "1e10 hours", "200000000000 days", "5000000000 years"} {
+		if _, _, err := parseDurationSpec(bad); err == nil {
+			t.Errorf("parseDurationSpec(%q) expected error, got nil", bad)
+		}
+	}
+}
+
+func TestCompareDurations(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	res, err := ts.CompareDurations("an hour and a half", "PT1H30M", "")
+	if err != nil {
+		t.Fatalf("CompareDurations error: %v", err)
+	}
+	if !res.Equal || res.Longer != "equal" || res.DifferenceSeconds != 0 {
+		t.Errorf("expected equal durations, got %+v", res)
+	}
+
+	res, err = ts.CompareDurations("90m", "2h", "")
+	if err != nil {
+		t.Fatalf("CompareDurations error: %v", err)
+	}
+	if res.Longer != "second" || res.Difference != "30m0s" {
+		t.Errorf("expected second longer by 30m0s, got longer=%q difference=%q", res.Longer, res.Difference)
+	}
+
+	if _, err := ts.CompareDurations("1 month", "30 days", ""); err == nil {
+		t.Errorf("expected error comparing a calendar month, got nil")
+	}
+	if _, err := ts.CompareDurations("90m", "2h", "roman"); err == nil {
+		t.Errorf("expected error for an unknown format, got nil")
+	}
+}
+
+// The tools that always reported Go durations keep that default and take
+// the same format option as duration_until.
+func TestDurationFormatOption(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 12, 0, 0, 0, time.UTC) })
+
+	for _, tc := range []struct{ format, normalized, difference string }{
+		{"", "1h30m0s", "30m0s"},
+		{"go", "1h30m0s", "30m0s"},
+		{"iso8601", "PT1H30M", "PT30M"},
+		{"human", "1 hour 30 minutes", "30 minutes"},
+	} {
+		res, err := ts.CompareDurations("90m", "2h", tc.format)
+		if err != nil {
+			t.Fatalf("CompareDurations(%q) error: %v", tc.format, err)
+		}
+		if res.First.Normalized != tc.normalized || res.Difference != tc.difference {
+			t.Errorf("format %q: normalized %q, difference %q; want %q, %q", tc.format, res.First.Normalized, res.Difference, tc.normalized, tc.difference)
+		}
+	}
+
+	wall, err := ts.WallAfter("2025-03-08 09:00", "3 days", "America/New_York", "iso8601")
+	if err != nil || wall.PhysicalElapsed != "P2DT23H" || wall.DSTShift != "-PT1H" {
+		t.Errorf("WallAfter iso8601 = %q, %q, %v; want P2DT23H, -PT1H", wall.PhysicalElapsed, wall.DSTShift, err)
+	}
+	anchored, err := ts.DurationAtAnchor("1 month", "2025-02-01", "UTC", "human")
+	if err != nil || anchored.Absolute != "28 days" {
+		t.Errorf("DurationAtAnchor human = %q, %v; want 28 days", anchored.Absolute, err)
+	}
+	diff, err := ts.NaturalDiff("today at 9am", "today at 5pm", "UTC", "iso8601")
+	if err != nil || diff.Difference != "PT8H" {
+		t.Errorf("NaturalDiff iso8601 = %q, %v; want PT8H", diff.Difference, err)
+	}
+	window, err := ts.SameDateWindow([]string{"America/Los_Angeles", "Asia/Tokyo"}, "human")
+	if err != nil || window.Duration != "8 hours" {
+		t.Errorf("SameDateWindow human = %q, %v; want 8 hours", window.Duration, err)
+	}
+	gap, err := ts.EventsOverlap(EventSpec{"2025-05-14 10:00", "1h"}, EventSpec{"2025-05-14 12:15", "1h"}, "UTC", "iso8601")
+	if err != nil || gap.Gap != "PT1H15M" {
+		t.Errorf("EventsOverlap iso8601 gap = %q, %v; want PT1H15M", gap.Gap, err)
+	}
+	pomodoro, err := ts.PomodoroPlan("2025-05-14 09:00", 4, "25m", "5m", "15m", 4, "UTC", "human")
+	if err != nil || pomodoro.WorkTotal != "1 hour 40 minutes" {
+		t.Errorf("PomodoroPlan human work total = %q, %v; want 1 hour 40 minutes", pomodoro.WorkTotal, err)
+	}
+	if _, err := ts.ClockSkew("2025-05-14T12:00:00Z", "", "fortnights"); err == nil {
+		t.Errorf("expected error for an unknown format, got nil")
+	}
+}
+
+func TestEvalTimeExpr(t *testing.T) {
+	locNY, _ := time.LoadLocation("America/New_York")
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 3, 7, 12, 0, 0, 0, locNY) })
+
+	cases := []struct {
+		expr string
+		want string
+	}{
+		{"now + 3 days - 2 hours", "2025-03-10T10:00:00-04:00"},
+		{"2025-01-31 + 1 month", "2025-03-03T00:00:00-05:00"},
+		{"2025-01-01 10:00 +90m -PT30M", "2025-01-01T11:00:00-05:00"},
+		{"+ 1 week", "2025-03-14T12:00:00-04:00"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.expr, func(t *testing.T) {
+			res, err := ts.EvalTimeExpr(tc.expr, "America/New_York")
+			if err != nil {
+				t.Fatalf("EvalTimeExpr(%q) error: %v", tc.expr, err)
+			}
+			if res.Result.Datetime != tc.want {
+				t.Errorf("EvalTimeExpr(%q) = %s, want %s", tc.expr, res.Result.Datetime, tc.want)
+			}
+		})
+	}
+
+	_, err := ts.EvalTimeExpr("now + 3 days - 2 bananas", "UTC")
+	if err == nil || !strings.Contains(err.Error(), "term 2") || !strings.Contains(err.Error(), "bananas") {
+		t.Errorf("expected error pinpointing term 2, got %v", err)
+	}
+}
+
+func TestCountdownString(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 12, 0, 0, 0, time.UTC) })
+
+	cases := []struct {
+		target   string
+		withDays bool
+		want     string
+		seconds  int64
+	}{
+		{"2025-05-14 13:02:03", false, "T-minus 01:02:03", 3723},
+		{"2025-05-16 13:02:03", false, "T-minus 49:02:03", 176523},
+		{"2025-05-16 13:02:03", true, "T-minus 2d 01:02:03", 176523},
+		{"2025-05-14 12:00:00", false, "T-plus 00:00:00", 0},
+		{"2025-05-14 11:59:30", false, "T-plus 00:00:30", -30},
+	}
+	for _, tc := range cases {
+		t.Run(tc.want, func(t *testing.T) {
+			res, err := ts.CountdownString(tc.target, "UTC", tc.withDays)
+			if err != nil {
+				t.Fatalf("CountdownString error: %v", err)
+			}
+			if res.Display != tc.want || res.Seconds != tc.seconds {
+				t.Errorf("CountdownString(%q) = %q (%d s), want %q (%d s)", tc.target, res.Display, res.Seconds, tc.want, tc.seconds)
+			}
+		})
+	}
+}
+
+func TestWallAfter(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	// Three days across the US spring-forward change keeps 09:00 local but
+	// only 71 hours pass.
+	res, err := ts.WallAfter("2025-03-08 09:00", "3 days", "America/New_York", "")
+	if err != nil {
+		t.Fatalf("WallAfter error: %v", err)
+	}
+	if res.WallClock.Datetime != "2025-03-11T09:00:00-04:00" || res.PhysicalElapsed != "71h0m0s" {
+		t.Errorf("wall result = %s after %s, want 2025-03-11T09:00:00-04:00 after 71h0m0s", res.WallClock.Datetime, res.PhysicalElapsed)
+	}
+	if res.Absolute == nil || res.Absolute.Datetime != "2025-03-11T10:00:00-04:00" || res.DSTShift != "-1h0m0s" {
+		t.Errorf("absolute result = %+v shift %s, want 10:00 and -1h0m0s", res.Absolute, res.DSTShift)
+	}
+
+	month, err := ts.WallAfter("2025-10-15 09:00", "1 month", "Europe/Berlin", "")
+	if err != nil {
+		t.Fatalf("WallAfter error: %v", err)
+	}
+	if month.WallClock.Datetime != "2025-11-15T09:00:00+01:00" || month.Absolute != nil {
+		t.Errorf("month result = %s absolute=%v, want 2025-11-15T09:00:00+01:00 and no absolute", month.WallClock.Datetime, month.Absolute)
+	}
+}
+
+func TestDurationAtAnchor(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	cases := []struct {
+		name, duration, anchor string
+		wantEnd                string
+		wantHours              float64
+		clamped                bool
+	}{
+		{"januaryEnd", "1 month", "2025-01-31", "2025-02-28T00:00:00Z", 28 * 24, true},
+		{"april", "P1M", "2025-04-01", "2025-05-01T00:00:00Z", 30 * 24, false},
+		{"leapFebruary", "1 month", "2024-02-01", "2024-03-01T00:00:00Z", 29 * 24, false},
+		{"leapYear", "1 year", "2024-01-01", "2025-01-01T00:00:00Z", 366 * 24, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.DurationAtAnchor(tc.duration, tc.anchor, "UTC", "")
+			if err != nil {
+				t.Fatalf("DurationAtAnchor error: %v", err)
+			}
+			if res.End.Datetime != tc.wantEnd || res.AbsoluteHours != tc.wantHours || res.ClampedToMonthEnd != tc.clamped {
+				t.Errorf("got end %s, %.0fh, clamped %v", res.End.Datetime, res.AbsoluteHours, res.ClampedToMonthEnd)
+			}
+		})
+	}
+
+	// A month across the March DST change in New York loses an hour.
+	dst, err := ts.DurationAtAnchor("1 month", "2025-03-01", "America/New_York", "")
+	if err != nil {
+		t.Fatalf("DurationAtAnchor error: %v", err)
+	}
+	if dst.AbsoluteHours != 31*24-1 || dst.DifferenceHours != 23 {
+		t.Errorf("March in New York = %.0fh (diff %.0fh)", dst.AbsoluteHours, dst.DifferenceHours)
+	}
+}
+
+func TestAddDuration(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 12, 0, 0, 0, time.UTC) })
+
+	cases := []struct {
+		name, base, duration, tz, want string
+	}{
+		{"hoursMinutes", "2025-05-14T09:00:00Z", "3h15m", "UTC", "2025-05-14T12:15:00Z"},
+		{"defaultNow", "", "90m", "UTC", "2025-05-14T13:30:00Z"},
+		{"negative", "2025-05-14T09:00:00Z", "-90m", "UTC", "2025-05-14T07:30:00Z"},
+		{"weeks", "2025-05-14T09:00:00Z", "2w", "UTC", "2025-05-28T09:00:00Z"},
+		// Across the New York spring-forward night a calendar day keeps
+		// 09:00, while 24h of elapsed time reads 10:00.
+		{"calendarDayOverDST", "2025-03-08T09:00:00-05:00", "1d", "America/New_York", "2025-03-09T09:00:00-04:00"},
+		{"elapsedHoursOverDST", "2025-03-08T09:00:00-05:00", "24h", "America/New_York", "2025-03-09T10:00:00-04:00"},
+		{"mixedDaysAndHours", "2025-11-01T09:00:00-04:00", "1d2h", "America/New_York", "2025-11-02T11:00:00-05:00"},
+		{"rendersInZone", "2025-05-14T09:00:00Z", "1h", "Asia/Tokyo", "2025-05-14T19:00:00+09:00"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.AddDuration(tc.base, tc.duration, tc.tz)
+			if err != nil {
+				t.Fatalf("AddDuration error: %v", err)
+			}
+			if res.Datetime != tc.want {
+				t.Errorf("AddDuration(%s, %s) = %s, want %s", tc.base, tc.duration, res.Datetime, tc.want)
+			}
+		})
+	}
+
+	if _, err := ts.AddDuration("2025-05-14T09:00:00Z", "soon", "UTC"); err == nil {
+		t.Error("expected error for unparseable duration")
+	}
+}
+
+func TestDurationUntil(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 12, 30, 0, 0, time.UTC) }) // Wednesday
+
+	cases := []struct {
+		name, expr, tz, human string
+		seconds               int64
+		past                  bool
+	}{
+		{"nextFriday5pm", "next Friday at 5pm", "UTC", "2 days 4 hours 30 minutes", 2*86400 + 4*3600 + 1800, false},
+		{"tomorrowNoon", "tomorrow at noon", "UTC", "23 hours 30 minutes", 23*3600 + 1800, false},
+		{"pastToday", "today at 9am", "UTC", "3 hours 30 minutes ago", -(3*3600 + 1800), true},
+		{"oneOfEach", "tomorrow at 1:31pm", "UTC", "1 day 1 hour 1 minute", 86400 + 3600 + 60, false},
+		// 12:30Z is 08:30 in New York; 5pm local is 8h30m away.
+		{"localZone", "today at 5pm", "America/New_York", "8 hours 30 minutes", 8*3600 + 1800, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.DurationUntil(tc.expr, tc.tz, "")
+			if err != nil {
+				t.Fatalf("DurationUntil(%q) error: %v", tc.expr, err)
+			}
+			if res.Human != tc.human || res.Seconds != tc.seconds || res.Past != tc.past {
+				t.Errorf("DurationUntil(%q) = %q %d past=%v, want %q %d past=%v",
+					tc.expr, res.Human, res.Seconds, res.Past, tc.human, tc.seconds, tc.past)
+			}
+		})
+	}
+
+	// A relative target keeps now's sub-millisecond part, so the span is
+	// exact rather than a few hundred microseconds short.
+	fine := NewTimeServer("UTC")
+	fine.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 12, 30, 0, 123456789, time.UTC) })
+	if res, err := fine.DurationUntil("in 2 hours", "UTC", ""); err != nil || res.Seconds != 7200 {
+		t.Errorf("DurationUntil(in 2 hours) with sub-ms now = %+v, %v; want 7200 seconds", res, err)
+	}
+
+	if got := humanDuration(0); got != "0 seconds" {
+		t.Errorf("humanDuration(0) = %q", got)
+	}
+	if _, err := ts.DurationUntil("gibberish", "UTC", ""); err == nil {
+		t.Error("expected error for unparseable expression")
+	}
+}
+
+func TestFormatISODuration(t *testing.T) {
+	cases := []struct {
+		in   time.Duration
+		want string
+	}{
+		{0, "PT0S"},
+		{24 * time.Hour, "P1D"},
+		{26 * time.Hour, "P1DT2H"},
+		{2*24*time.Hour + 4*time.Hour + 30*time.Minute, "P2DT4H30M"},
+		{90 * time.Minute, "PT1H30M"},
+		{45 * time.Second, "PT45S"},
+		{1500 * time.Millisecond, "PT1.5S"},
+		{250 * time.Millisecond, "PT0.25S"},
+		{time.Nanosecond, "PT0.000000001S"},
+		{24*time.Hour + 5*time.Second, "P1DT5S"},
+		{-90 * time.Minute, "-PT1H30M"},
+		{-(36*time.Hour + 500*time.Millisecond), "-P1DT12H0.5S"},
+	}
+	for _, tc := range cases {
+		if got := formatISODuration(tc.in); got != tc.want {
+			t.Errorf("formatISODuration(%v) = %q, want %q", tc.in, got, tc.want)
+		}
+		// Whatever is emitted must read back through the ISO parser.
+		if cd, err := parseISODuration(strings.TrimPrefix(tc.want, "-")); err != nil {
+			t.Errorf("parseISODuration(%q) error: %v", tc.want, err)
+		} else if d, _ := cd.fixed(); tc.in >= 0 && d != tc.in {
+			t.Errorf("round trip of %q = %v, want %v", tc.want, d, tc.in)
+		}
+	}
+}
+
+func TestDurationUntilFormat(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 12, 30, 0, 0, time.UTC) })
+
+	cases := []struct{ expr, format, want string }{
+		{"next Friday at 5pm", "", "52h30m0s"},
+		{"next Friday at 5pm", "human", "2 days 4 hours 30 minutes"},
+		{"next Friday at 5pm", "iso8601", "P2DT4H30M"},
+		{"today at 9am", "iso8601", "-PT3H30M"},
+		{"today at 9am", "human", "-3 hours 30 minutes"},
+	}
+	for _, tc := range cases {
+		res, err := ts.DurationUntil(tc.expr, "UTC", tc.format)
+		if err != nil {
+			t.Fatalf("DurationUntil(%q, %q) error: %v", tc.expr, tc.format, err)
+		}
+		if res.Duration != tc.want {
+			t.Errorf("DurationUntil(%q, %q).Duration = %q, want %q", tc.expr, tc.format, res.Duration, tc.want)
+		}
+	}
+	if _, err := ts.DurationUntil("tomorrow", "UTC", "cron"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
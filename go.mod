@@ -3,7 +3,7 @@ module time-mcp-server
 go 1.24.3
 
 require (
-	github.com/mark3labs/mcp-go v0.29.0
+	github.com/mark3labs/mcp-go v0.30.0
 	github.com/olebedev/when v1.1.0
 )
 
@@ -5,30 +5,84 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/olebedev/when"
+	"github.com/olebedev/when/rules"
+	brRules "github.com/olebedev/when/rules/br"
 	enRules "github.com/olebedev/when/rules/en"
+	nlRules "github.com/olebedev/when/rules/nl"
+	ruRules "github.com/olebedev/when/rules/ru"
+	zhRules "github.com/olebedev/when/rules/zh"
 )
 
 /* ----- data types ----- */
 
 type TimeResult struct {
-	Timezone string `json:"timezone"`
-	Datetime string `json:"datetime"`
-	IsDST    bool   `json:"is_dst"`
+	Timezone     string `json:"timezone"`
+	Datetime     string `json:"datetime"`
+	IsDST        bool   `json:"is_dst"`
+	UTCOffset    string `json:"utc_offset"`
+	Abbreviation string `json:"abbreviation"`
+	UnixSeconds  int64  `json:"unix"`
+	UnixMillis   int64  `json:"unix_ms"`
+	Warning      string `json:"warning,omitempty"`
+	Error        string `json:"error,omitempty"` // per-entry failure in batch results
+}
+
+// NaturalParseResult is a parsed natural-language time plus the part of the
+// expression the parser recognised. MatchStart and MatchEnd are character
+// (rune) offsets into the expression; MatchEnd is exclusive.
+type NaturalParseResult struct {
+	TimeResult
+	MatchedText string `json:"matched_text"`
+	MatchStart  int    `json:"match_start"`
+	MatchEnd    int    `json:"match_end"`
 }
 
 type TimeConversionResult struct {
 	Source         TimeResult `json:"source"`
 	Target         TimeResult `json:"target"`
 	TimeDifference string     `json:"time_difference"`
+	DayOffset      int        `json:"day_offset"` // target date minus source date
+	DayChange      string     `json:"day_change"` // "previous day", "same day" or "next day"
+}
+
+// TimeError is an error with a stable machine-readable Code. Tool handlers
+// send it to clients as a JSON object so they can branch on the code
+// instead of matching message text.
+type TimeError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error codes carried by TimeError.
+const (
+	ErrUnknownTZ           = "UNKNOWN_TZ"
+	ErrBadTimeFormat       = "BAD_TIME_FORMAT"
+	ErrParseFailed         = "PARSE_FAILED"
+	ErrUnsupportedLanguage = "UNSUPPORTED_LANGUAGE"
+)
+
+func (e *TimeError) Error() string { return e.Message }
+
+// timeErrorf builds a TimeError with a formatted message.
+func timeErrorf(code, format string, args ...any) error {
+	return &TimeError{Code: code, Message: fmt.Sprintf(format, args...)}
 }
 
 /* ----- server ----- */
@@ -42,6 +96,91 @@ type TimeServer struct {
 	localTZ string
 	parser  *when.Parser
 	nowFunc func() time.Time // New field for injectable "now"
+
+	mu      sync.Mutex
+	parsers map[string]*when.Parser // per-language parsers, built on first use
+
+	locMu sync.RWMutex
+	locs  map[string]*time.Location // successfully loaded zones by name
+}
+
+// loadLocation is time.LoadLocation with a per-server cache, so repeated
+// calls for a zone skip re-reading zoneinfo. Friendly names are mapped by
+// resolveZone first; names that are not IANA zones fall back to
+// fixedOffsetZone. Failed lookups are not cached.
+func (t *TimeServer) loadLocation(name string) (*time.Location, error) {
+	t.locMu.RLock()
+	loc, ok := t.locs[name]
+	t.locMu.RUnlock()
+	if ok {
+		return loc, nil
+	}
+	loc, err := time.LoadLocation(resolveZone(name))
+	if err != nil {
+		fixed, ok := fixedOffsetZone(name)
+		if !ok {
+			return nil, err
+		}
+		loc = fixed
+	}
+	t.locMu.Lock()
+	if t.locs == nil {
+		t.locs = make(map[string]*time.Location)
+	}
+	t.locs[name] = loc
+	t.locMu.Unlock()
+	return loc, nil
+}
+
+// parserRules maps the language codes accepted by parse_natural_time to the
+// rule sets of the matching when/rules package.
+var parserRules = map[string][]rules.Rule{
+	"en": enRules.All,
+	"ru": ruRules.All,
+	"br": brRules.All,
+	"zh": zhSafeRules,
+	"nl": nlRules.All,
+}
+
+// zhSafeRules is zhRules.All without ExactMonthDate, whose matcher in
+// when v1.1.0 panics (slice bounds out of range) on every input.
+var zhSafeRules = []rules.Rule{
+	zhRules.Weekday(rules.Override),
+	zhRules.CasualDate(rules.Override),
+	zhRules.CasualTime(rules.Override),
+	zhRules.HourMinute(rules.Override),
+	zhRules.TraditionHour(rules.Override),
+	zhRules.AfterTime(rules.Override),
+}
+
+// parserFor returns the when.Parser for lang ("" means English), building
+// and caching it on first use.
+func (t *TimeServer) parserFor(lang string) (*when.Parser, error) {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == "" || lang == "en" {
+		return t.parser, nil
+	}
+	rs, ok := parserRules[lang]
+	if !ok {
+		codes := make([]string, 0, len(parserRules))
+		for c := range parserRules {
+			codes = append(codes, c)
+		}
+		sort.Strings(codes)
+		return nil, timeErrorf(ErrUnsupportedLanguage, "unsupported language %q (supported: %s)", lang, strings.Join(codes, ", "))
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if p, ok := t.parsers[lang]; ok {
+		return p, nil
+	}
+	p := when.New(nil)
+	p.Add(rs...)
+	if t.parsers == nil {
+		t.parsers = make(map[string]*when.Parser)
+	}
+	t.parsers[lang] = p
+	return p, nil
 }
 
 // NewTimeServer is the constructor for TimeServer
@@ -73,12 +212,56 @@ func detectLocalTZ() string {
 	if name != "" && name != "Local" {
 		return name
 	}
+	sign := '+'
+	if off < 0 {
+		sign, off = '-', -off
+	}
 	h := off / 3600
 	m := (off % 3600) / 60
 	if m == 0 {
-		return fmt.Sprintf("UTC%+d", h)
+		return fmt.Sprintf("UTC%c%d", sign, h)
 	}
-	return fmt.Sprintf("UTC%+d:%02d", h, m)
+	return fmt.Sprintf("UTC%c%d:%02d", sign, h, m)
+}
+
+// utcOffsetRe matches fixed-offset zone specs such as "UTC+5", "UTC-03:30",
+// "GMT+1" or a bare "+05:30", as emitted by detectLocalTZ.
+var utcOffsetRe = regexp.MustCompile(`^(?i:UTC|GMT)?([+-])(\d{1,2})(?::(\d{2}))?$`)
+
+// fixedOffsetZone builds a time.FixedZone for a spec matching utcOffsetRe,
+// named like "UTC+05:30". Offsets beyond ±14:00 are rejected.
+func fixedOffsetZone(spec string) (*time.Location, bool) {
+	m := utcOffsetRe.FindStringSubmatch(strings.TrimSpace(spec))
+	if m == nil {
+		return nil, false
+	}
+	h, _ := strconv.Atoi(m[2])
+	mins := 0
+	if m[3] != "" {
+		mins, _ = strconv.Atoi(m[3])
+	}
+	off := h*3600 + mins*60
+	if mins > 59 || off > 14*3600 {
+		return nil, false
+	}
+	if m[1] == "-" {
+		off = -off
+	}
+	return time.FixedZone("UTC"+formatOffset(off), off), true
+}
+
+// splitMeridiem strips a trailing AM/PM marker ("2:30 PM", "2:30pm",
+// "2:30 p.m.") from s and returns the rest with the marker as "am", "pm",
+// or "" when there is none.
+func splitMeridiem(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	lower := strings.ToLower(s)
+	for _, m := range []string{"a.m.", "p.m.", "am", "pm"} {
+		if strings.HasSuffix(lower, m) {
+			return strings.TrimSpace(s[:len(s)-len(m)]), m[:1] + "m"
+		}
+	}
+	return s, ""
 }
 
 func atoiStrict(s string) (int, error) {
@@ -88,116 +271,349 @@ func atoiStrict(s string) (int, error) {
 	return v, err
 }
 
-/* ----- core methods ----- */
+// formatHourDiff renders an offset difference in seconds as signed hours,
+// e.g. "+9h" or "-5.5h".
+func formatHourDiff(sec int) string {
+	diff := float64(sec) / 3600
+	// Format carefully to avoid excessive precision or trailing zeros
+	if diff == float64(int(diff)) { // Check if it's a whole number
+		return fmt.Sprintf("%+.0fh", diff)
+	}
+	diffStr := fmt.Sprintf("%+.2fh", diff)
+	diffStr = strings.TrimSuffix(diffStr, "h")
+	diffStr = strings.TrimRight(diffStr, "0") // Trim trailing zeros after decimal
+	diffStr = strings.TrimRight(diffStr, ".") // Trim trailing decimal if it became "X."
+	return diffStr + "h"
+}
 
-// GetCurrentTime uses the injectable nowFunc
-func (t *TimeServer) GetCurrentTime(tz string) (TimeResult, error) {
+// outputFormats are the named presets accepted for an output format. Any
+// other value is treated as a Go layout.
+var outputFormats = map[string]string{
+	"rfc3339": time.RFC3339,
+	"rfc1123": time.RFC1123,
+	"kitchen": time.Kitchen,
+	"date":    "2006-01-02",
+}
+
+// formatProbe is an instant with every layout field distinct from Go's
+// reference time, used to tell whether a layout formats anything at all.
+var formatProbe = time.Date(2019, time.August, 17, 13, 45, 30, 0, time.UTC)
+
+// formatTime renders tm in format: a preset name ("unix" and "iso_week"
+// included), a Go layout, or "" for RFC3339. A layout with no recognizable
+// elements, or one whose output cannot be parsed back, falls back to
+// RFC3339 and returns a warning instead of echoing the layout text.
+func formatTime(tm time.Time, format string) (string, string) {
+	switch preset := strings.ToLower(strings.TrimSpace(format)); preset {
+	case "":
+		return tm.Format(time.RFC3339), ""
+	case "unix":
+		return strconv.FormatInt(tm.Unix(), 10), ""
+	case "iso_week":
+		y, w := tm.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d-%d", y, w, (int(tm.Weekday())+6)%7+1), ""
+	default:
+		if layout, ok := outputFormats[preset]; ok {
+			return tm.Format(layout), ""
+		}
+	}
+	probe := formatProbe.Format(format)
+	if _, err := time.Parse(format, probe); err != nil || probe == format {
+		return tm.Format(time.RFC3339), fmt.Sprintf("invalid format %q; using RFC3339", format)
+	}
+	return tm.Format(format), ""
+}
+
+// formattedResult is makeTimeResult with Datetime rendered in format.
+func formattedResult(tz string, tm time.Time, format string) TimeResult {
+	res := makeTimeResult(tz, tm)
+	res.Datetime, res.Warning = formatTime(tm, format)
+	return res
+}
+
+// location resolves tz (empty means the server's local zone) and returns the
+// zone name that was used alongside the loaded location.
+func (t *TimeServer) location(tz string) (string, *time.Location, error) {
 	if tz == "" {
 		tz = t.localTZ
 	}
-	loc, err := time.LoadLocation(tz)
+	tz = resolveZone(tz)
+	loc, err := t.loadLocation(tz)
+	if err != nil {
+		return "", nil, timeErrorf(ErrUnknownTZ, "unknown time zone %s: %v", tz, err)
+	}
+	return tz, loc, nil
+}
+
+// dateTimeLayouts are the zone-less layouts accepted by parseDateTime; they
+// are interpreted in the caller's location.
+var dateTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseDateTime reads s as RFC3339, as one of dateTimeLayouts in loc, or as a
+// natural-language expression relative to now. Empty or "now" yields now.
+func (t *TimeServer) parseDateTime(s string, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	now := t.nowFunc().In(loc)
+	if s == "" || strings.EqualFold(s, "now") {
+		return now, nil
+	}
+	if tm, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return tm.In(loc), nil
+	}
+	for _, layout := range dateTimeLayouts {
+		if tm, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return tm, nil
+		}
+	}
+	if res, err := t.parser.Parse(s, now); err == nil && res != nil {
+		return res.Time.In(loc), nil
+	}
+	return time.Time{}, timeErrorf(ErrBadTimeFormat, "invalid datetime: %s", s)
+}
+
+// daysBetween counts calendar days from a's local date to b's local date,
+// ignoring time of day and DST.
+func daysBetween(a, b time.Time) int {
+	da := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	db := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(db.Sub(da) / (24 * time.Hour))
+}
+
+// makeTimeResult renders tm as the standard TimeResult for zone tz.
+func makeTimeResult(tz string, tm time.Time) TimeResult {
+	abbr, off := tm.Zone()
+	return TimeResult{
+		Timezone:     tz,
+		Datetime:     tm.Format(time.RFC3339),
+		IsDST:        tm.IsDST(),
+		UTCOffset:    formatOffset(off),
+		Abbreviation: abbr,
+		UnixSeconds:  tm.Unix(),
+		UnixMillis:   tm.UnixMilli(),
+	}
+}
+
+// jsonResult marshals v as the indented text payload used by every tool.
+func jsonResult(v any) (*mcp.CallToolResult, error) {
+	b, _ := json.MarshalIndent(v, "", "  ")
+	return mcp.NewToolResultText(string(b)), nil
+}
+
+// errorResult reports err as a tool error. A TimeError anywhere in the
+// chain is sent as {"code", "message"} JSON, keeping the full wrapped
+// message; other errors are sent as plain text.
+func errorResult(err error) (*mcp.CallToolResult, error) {
+	var te *TimeError
+	if !errors.As(err, &te) {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	b, _ := json.Marshal(TimeError{Code: te.Code, Message: err.Error()})
+	return mcp.NewToolResultError(string(b)), nil
+}
+
+/* ----- core methods ----- */
+
+// GetCurrentTime uses the injectable nowFunc
+func (t *TimeServer) GetCurrentTime(tz, format string) (TimeResult, error) {
+	tz, loc, err := t.location(tz)
 	if err != nil {
 		return TimeResult{}, err
 	}
 	// Use the injectable nowFunc
 	now := t.nowFunc().In(loc)
-	return TimeResult{Timezone: tz, Datetime: now.Format(time.RFC3339), IsDST: now.IsDST()}, nil
+	return formattedResult(tz, now, format), nil
 }
 
-// ConvertTime uses the injectable nowFunc for its date context
-func (t *TimeServer) ConvertTime(srcTZ, hhmm, dstTZ string) (TimeConversionResult, error) {
-	if srcTZ == "" {
-		srcTZ = t.localTZ
+// maxMultiZones caps how many zones one GetCurrentTimeMulti call renders.
+const maxMultiZones = 100
+
+// GetCurrentTimeMulti renders a single nowFunc snapshot in every zone, in
+// input order. A zone that fails to load yields an entry holding only its
+// Timezone and Error, so one bad name does not fail the batch.
+func (t *TimeServer) GetCurrentTimeMulti(zones []string) ([]TimeResult, error) {
+	if len(zones) == 0 {
+		return nil, fmt.Errorf("at least one zone is required")
 	}
-	if dstTZ == "" {
-		dstTZ = t.localTZ
+	if len(zones) > maxMultiZones {
+		return nil, fmt.Errorf("at most %d zones are allowed, got %d", maxMultiZones, len(zones))
+	}
+	now := t.nowFunc()
+	out := make([]TimeResult, 0, len(zones))
+	for _, z := range zones {
+		tz, loc, err := t.location(strings.TrimSpace(z))
+		if err != nil {
+			out = append(out, TimeResult{Timezone: z, Error: err.Error()})
+			continue
+		}
+		out = append(out, makeTimeResult(tz, now.In(loc)))
 	}
+	return out, nil
+}
 
-	srcLoc, err := time.LoadLocation(srcTZ)
+// ConvertTime converts "HH:MM[:SS]" or "YYYY-MM-DD HH:MM[:SS]" in srcTZ to
+// dstTZ; the clock may also be 12-hour with an AM/PM suffix ("2:30 PM"). A bare time is
+// taken on today's date from the injectable nowFunc. Both datetimes are
+// rendered in format (see formatTime).
+func (t *TimeServer) ConvertTime(srcTZ, hhmm, dstTZ, format string) (TimeConversionResult, error) {
+	srcTZ, srcLoc, err := t.location(srcTZ)
 	if err != nil {
 		return TimeConversionResult{}, err
 	}
-	dstLoc, err := time.LoadLocation(dstTZ)
+	dstTZ, dstLoc, err := t.location(dstTZ)
 	if err != nil {
 		return TimeConversionResult{}, err
 	}
 
+	// Use the injectable nowFunc for the date context unless a date is given
+	now := t.nowFunc()
+	year, month, dayOfMonth := now.Date()
+	hhmm, meridiem := splitMeridiem(hhmm)
+	if date, clock, ok := strings.Cut(hhmm, " "); ok {
+		d, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return TimeConversionResult{}, timeErrorf(ErrBadTimeFormat, "time must be HH:MM[:SS], h:mm AM/PM or YYYY-MM-DD HH:MM[:SS]")
+		}
+		year, month, dayOfMonth = d.Date()
+		hhmm = strings.TrimSpace(clock)
+	}
+
 	parts := strings.Split(hhmm, ":")
-	if len(parts) != 2 {
-		return TimeConversionResult{}, fmt.Errorf("time must be HH:MM")
+	if len(parts) != 2 && len(parts) != 3 {
+		return TimeConversionResult{}, timeErrorf(ErrBadTimeFormat, "time must be HH:MM[:SS], h:mm AM/PM or YYYY-MM-DD HH:MM[:SS]")
 	}
 	h, errH := atoiStrict(parts[0])
-	if errH != nil || h < 0 || h > 23 {
-		return TimeConversionResult{}, fmt.Errorf("invalid hour: %s", parts[0])
+	switch {
+	case meridiem == "":
+		if errH != nil || h < 0 || h > 23 {
+			return TimeConversionResult{}, timeErrorf(ErrBadTimeFormat, "invalid hour: %s", parts[0])
+		}
+	case errH != nil || h < 1 || h > 12:
+		return TimeConversionResult{}, timeErrorf(ErrBadTimeFormat, "invalid 12-hour clock hour: %s", parts[0])
+	default:
+		// 12 AM is midnight and 12 PM is noon.
+		h %= 12
+		if meridiem == "pm" {
+			h += 12
+		}
 	}
 	m, errM := atoiStrict(parts[1])
 	if errM != nil || m < 0 || m > 59 {
-		return TimeConversionResult{}, fmt.Errorf("invalid minute: %s", parts[1])
+		return TimeConversionResult{}, timeErrorf(ErrBadTimeFormat, "invalid minute: %s", parts[1])
+	}
+	sec := 0
+	if len(parts) == 3 {
+		var errS error
+		sec, errS = atoiStrict(parts[2])
+		if errS != nil || sec < 0 || sec > 59 {
+			return TimeConversionResult{}, timeErrorf(ErrBadTimeFormat, "invalid second: %s", parts[2])
+		}
 	}
 
-	// Use the injectable nowFunc for the date context
-	now := t.nowFunc()
-	srcTime := time.Date(now.Year(), now.Month(), now.Day(), h, m, 0, 0, srcLoc)
+	srcTime := time.Date(year, month, dayOfMonth, h, m, sec, 0, srcLoc)
 	dstTime := srcTime.In(dstLoc)
 
 	_, srcOff := srcTime.Zone()
 	_, dstOff := dstTime.Zone()
-	diff := float64(dstOff-srcOff) / 3600
-	// Format diffStr carefully to avoid excessive precision or trailing zeros
-	var diffStr string
-	if diff == float64(int(diff)) { // Check if it's a whole number
-		diffStr = fmt.Sprintf("%+.0fh", diff)
-	} else {
-		diffStr = fmt.Sprintf("%+.2fh", diff)
-		diffStr = strings.TrimRight(diffStr, "0") // Trim trailing zeros after decimal
-		diffStr = strings.TrimRight(diffStr, ".") // Trim trailing decimal if it became "X."
-	}
+	diffStr := formatHourDiff(dstOff - srcOff)
+	dayOffset := daysBetween(srcTime, dstTime)
 
 	return TimeConversionResult{
-		Source: TimeResult{
-			Timezone: srcTZ,
-			Datetime: srcTime.Format(time.RFC3339),
-			IsDST:    srcTime.IsDST(),
-		},
-		Target: TimeResult{
-			Timezone: dstTZ,
-			Datetime: dstTime.Format(time.RFC3339),
-			IsDST:    dstTime.IsDST(),
-		},
+		Source:         formattedResult(srcTZ, srcTime, format),
+		Target:         formattedResult(dstTZ, dstTime, format),
 		TimeDifference: diffStr,
+		DayOffset:      dayOffset,
+		DayChange:      dayChange(dayOffset),
 	}, nil
 }
 
-// ParseNatural uses the injectable nowFunc as the reference for 'when.Parser'
-func (t *TimeServer) ParseNatural(expr, tz string) (TimeResult, error) {
-	if tz == "" {
-		tz = t.localTZ
+// dayChange names a calendar-day shift between two local readings.
+func dayChange(offset int) string {
+	switch offset {
+	case 0:
+		return "same day"
+	case -1:
+		return "previous day"
+	case 1:
+		return "next day"
 	}
-	loc, err := time.LoadLocation(tz)
+	return fmt.Sprintf("%+d days", offset)
+}
+
+// ParseNatural uses the injectable nowFunc as the reference for 'when.Parser'.
+// lang selects the rule set (en, ru, br, zh, nl); empty means English.
+func (t *TimeServer) ParseNatural(expr, tz, format, lang string) (NaturalParseResult, error) {
+	tz, out, res, err := t.parseNatural(expr, tz, lang)
 	if err != nil {
-		return TimeResult{}, fmt.Errorf("unknown time zone %s: %w", tz, err)
+		return NaturalParseResult{}, err
+	}
+	start := utf8.RuneCountInString(expr[:res.Index])
+	return NaturalParseResult{
+		TimeResult:  formattedResult(tz, out, format),
+		MatchedText: res.Text,
+		MatchStart:  start,
+		MatchEnd:    start + utf8.RuneCountInString(res.Text),
+	}, nil
+}
+
+// parseNatural resolves expr for ParseNatural and returns the zone used,
+// the parsed instant in that zone at full precision and the parser match.
+func (t *TimeServer) parseNatural(expr, tz, lang string) (string, time.Time, *when.Result, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return "", time.Time{}, nil, err
+	}
+	parser, err := t.parserFor(lang)
+	if err != nil {
+		return "", time.Time{}, nil, err
 	}
 	// Use the injectable nowFunc as the reference time for parsing
 	nowForParsing := t.nowFunc().In(loc)
-	res, err := t.parser.Parse(expr, nowForParsing)
+	res, err := parser.Parse(expr, nowForParsing)
 	if err != nil || res == nil {
 		// If err is not nil, include it. Otherwise, just state the expression couldn't be parsed.
-		detailedError := fmt.Errorf("could not parse expression: %s", expr)
+		detailedError := timeErrorf(ErrParseFailed, "could not parse expression: %s", expr)
 		if err != nil {
-			detailedError = fmt.Errorf("could not parse expression '%s': %w", expr, err)
+			detailedError = timeErrorf(ErrParseFailed, "could not parse expression '%s': %v", expr, err)
 		}
-		return TimeResult{}, detailedError
+		return "", time.Time{}, nil, detailedError
 	}
 	// The result from 'when.Parse' is relative to 'nowForParsing'.
 	// We want the final time to be in the specified 'loc' (which is tz).
-	out := res.Time.In(loc)
-	return TimeResult{Timezone: tz, Datetime: out.Format(time.RFC3339), IsDST: out.IsDST()}, nil
+	return tz, res.Time.In(loc), res, nil
+}
+
+// sseBaseURL returns the public base URL advertised by the SSE transport.
+// An explicit base is used verbatim once it parses as an absolute http(s)
+// URL; otherwise it is composed from host and port, with an empty or
+// wildcard bind host advertised as localhost.
+func sseBaseURL(base, host string, port int) (string, error) {
+	if base == "" {
+		switch host {
+		case "", "0.0.0.0", "::":
+			host = "localhost"
+		}
+		return "http://" + net.JoinHostPort(host, strconv.Itoa(port)), nil
+	}
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL %q: %w", base, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid base URL %q: want an absolute http or https URL", base)
+	}
+	return base, nil
 }
 
 /* ----- main ----- */
 // ... (main function remains unchanged)
 func main() {
-	var transport, localTZ string
+	var transport, localTZ, host, baseURL string
 	var port int
 	var showVer bool
 	flag.StringVar(&transport, "transport", "stdio", "")
@@ -206,6 +622,8 @@ func main() {
 	flag.StringVar(&localTZ, "l", "", "")
 	flag.IntVar(&port, "port", 8080, "")
 	flag.IntVar(&port, "p", 8080, "")
+	flag.StringVar(&host, "host", "", "bind address for the sse and streamable-http transports (default all interfaces)")
+	flag.StringVar(&baseURL, "base-url", "", "public base URL advertised by the SSE transport (default http://<host>:<port>)")
 	flag.BoolVar(&showVer, "version", false, "print version and exit")
 	flag.BoolVar(&showVer, "v", false, "print version and exit (shorthand)")
 	flag.Parse()
@@ -215,6 +633,7 @@ func main() {
 	}
 
 	ts := NewTimeServer(localTZ)
+	log.Printf("%s %s: using %s tzdata", appName, version, tzdataSource())
 
 	s := server.NewMCPServer(
 		appName, version,
@@ -226,14 +645,22 @@ func main() {
 		"get_current_time",
 		mcp.WithDescription("Get the current time in a specific timezone."),
 		mcp.WithString("timezone", mcp.Description("IANA timezone (optional).")),
+		mcp.WithString("format", mcp.Description("Output format: rfc3339 (default), rfc1123, kitchen, unix, date, iso_week, or a Go layout.")),
+	)
+
+	getMulti := mcp.NewTool(
+		"get_current_time_multi",
+		mcp.WithDescription("Current time in many timezones from one consistent snapshot. Unknown zones are reported per entry."),
+		mcp.WithArray("timezones", mcp.Required(), mcp.Items(map[string]any{"type": "string"}), mcp.Description("IANA zones or UTC offsets, up to 100.")),
 	)
 
 	convert := mcp.NewTool(
 		"convert_time",
-		mcp.WithDescription("Convert a HH:MM time between timezones."),
+		mcp.WithDescription("Convert a HH:MM time between timezones, noting whether the target falls on the previous, same or next calendar day."),
 		mcp.WithString("source_timezone", mcp.Required()),
-		mcp.WithString("time", mcp.Required()),
+		mcp.WithString("time", mcp.Required(), mcp.Description("HH:MM[:SS] or h:mm AM/PM today, or YYYY-MM-DD HH:MM[:SS] on a given date.")),
 		mcp.WithString("target_timezone", mcp.Required()),
+		mcp.WithString("format", mcp.Description("Output format: rfc3339 (default), rfc1123, kitchen, unix, date, iso_week, or a Go layout.")),
 	)
 
 	parseNL := mcp.NewTool(
@@ -241,60 +668,88 @@ func main() {
 		mcp.WithDescription("Parse natural-language expressions (e.g., 'next Friday at noon')."),
 		mcp.WithString("expression", mcp.Required()),
 		mcp.WithString("timezone"),
+		mcp.WithString("format", mcp.Description("Output format: rfc3339 (default), rfc1123, kitchen, unix, date, iso_week, or a Go layout.")),
+		mcp.WithString("language", mcp.Description("Expression language: en (default), ru, br (Brazilian Portuguese), zh or nl.")),
 	)
 
 	s.AddTool(getCurrent, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		tz := r.GetString("timezone", "")
-		res, err := ts.GetCurrentTime(tz)
+		res, err := ts.GetCurrentTime(tz, r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	s.AddTool(getMulti, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		zones, err := r.RequireStringSlice("timezones")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.GetCurrentTimeMulti(zones)
 		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
+			return errorResult(err)
 		}
-		b, _ := json.MarshalIndent(res, "", "  ")
-		return mcp.NewToolResultText(string(b)), nil
+		return jsonResult(res)
 	})
 
 	s.AddTool(convert, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		src, err := r.RequireString("source_timezone")
 		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
+			return errorResult(err)
 		}
 		hhmm, err := r.RequireString("time")
 		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
+			return errorResult(err)
 		}
 		dst, err := r.RequireString("target_timezone")
 		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
+			return errorResult(err)
 		}
-		res, err := ts.ConvertTime(src, hhmm, dst)
+		res, err := ts.ConvertTime(src, hhmm, dst, r.GetString("format", ""))
 		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
+			return errorResult(err)
 		}
-		b, _ := json.MarshalIndent(res, "", "  ")
-		return mcp.NewToolResultText(string(b)), nil
+		return jsonResult(res)
 	})
 
 	s.AddTool(parseNL, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		expr, err := r.RequireString("expression")
 		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
+			return errorResult(err)
 		}
 		tz := r.GetString("timezone", "")
-		res, err := ts.ParseNatural(expr, tz)
+		res, err := ts.ParseNatural(expr, tz, r.GetString("format", ""), r.GetString("language", ""))
 		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
+			return errorResult(err)
 		}
-		b, _ := json.MarshalIndent(res, "", "  ")
-		return mcp.NewToolResultText(string(b)), nil
+		return jsonResult(res)
 	})
 
+	registerDurationTools(s, ts)
+	registerBusinessTools(s, ts)
+	registerZoneTools(s, ts)
+	registerSolarTools(s, ts)
+	registerScheduleTools(s, ts)
+	registerCalendarTools(s, ts)
+	registerConvertTools(s, ts)
+	registerNaturalTools(s, ts)
+	registerClockTools(s, ts)
+
 	switch transport {
 	case "stdio":
 		log.Fatal(server.ServeStdio(s))
 	case "sse":
-		httpSrv := server.NewSSEServer(s, server.WithBaseURL(fmt.Sprintf("http://localhost:%d", port)))
-		log.Fatal(httpSrv.Start(fmt.Sprintf(":%d", port)))
+		base, err := sseBaseURL(baseURL, host, port)
+		if err != nil {
+			log.Fatal(err)
+		}
+		httpSrv := server.NewSSEServer(s, server.WithBaseURL(base))
+		log.Fatal(httpSrv.Start(net.JoinHostPort(host, strconv.Itoa(port))))
+	case "streamable-http", "http":
+		httpSrv := server.NewStreamableHTTPServer(s)
+		log.Fatal(httpSrv.Start(net.JoinHostPort(host, strconv.Itoa(port))))
 	default:
-		log.Fatalf("unknown transport %q", transport)
+		log.Fatalf("unknown transport %q (valid: stdio, sse, streamable-http, http)", transport)
 	}
 }
@@ -0,0 +1,243 @@
+// main_test.go
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestSSEBaseURL(t *testing.T) {
+	cases := []struct {
+		name, base, host string
+		port             int
+		want             string
+	}{
+		{"default", "", "", 8080, "http://localhost:8080"},
+		{"wildcardHost", "", "0.0.0.0", 9000, "http://localhost:9000"},
+		{"namedHost", "", "time.internal", 8080, "http://time.internal:8080"},
+		{"ipv6Host", "", "::1", 8080, "http://[::1]:8080"},
+		{"explicitVerbatim", "https://time.example.com/mcp", "0.0.0.0", 8080, "https://time.example.com/mcp"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := sseBaseURL(tc.base, tc.host, tc.port)
+			if err != nil {
+				t.Fatalf("sseBaseURL error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("sseBaseURL(%q, %q, %d) = %s, want %s", tc.base, tc.host, tc.port, got, tc.want)
+			}
+		})
+	}
+
+	for _, bad := range []string{"time.example.com", "ftp://time.example.com", "http://%zz", "/relative"} {
+		if _, err := sseBaseURL(bad, "", 8080); err == nil {
+			t.Errorf("sseBaseURL(%q) expected error", bad)
+		}
+	}
+}
+
+func TestLoadLocationCache(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	first, err := ts.loadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatalf("loadLocation error: %v", err)
+	}
+	second, err := ts.loadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatalf("loadLocation error: %v", err)
+	}
+	if first != second {
+		t.Error("expected the cached *time.Location on the second lookup")
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := ts.loadLocation("Mars/Olympus_Mons"); err == nil {
+			t.Fatalf("lookup %d: expected error for unknown zone", i+1)
+		}
+	}
+	if _, ok := ts.locs["Mars/Olympus_Mons"]; ok {
+		t.Error("unknown zone must not be cached")
+	}
+}
+
+func BenchmarkLoadLocation(b *testing.B) {
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := time.LoadLocation("America/New_York"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		ts := NewTimeServer("UTC")
+		for i := 0; i < b.N; i++ {
+			if _, err := ts.loadLocation("America/New_York"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestTimeErrorCodes(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 12, 0, 0, 0, time.UTC) })
+
+	cases := []struct {
+		name string
+		call func() error
+		code string
+	}{
+		{"currentUnknownTZ", func() error { _, err := ts.GetCurrentTime("Mars/Base", ""); return err }, ErrUnknownTZ},
+		{"convertUnknownTZ", func() error { _, err := ts.ConvertTime("UTC", "10:00", "Mars/Base", ""); return err }, ErrUnknownTZ},
+		{"convertBadTime", func() error { _, err := ts.ConvertTime("UTC", "10h00", "UTC", ""); return err }, ErrBadTimeFormat},
+		{"convertBadHour", func() error { _, err := ts.ConvertTime("UTC", "25:00", "UTC", ""); return err }, ErrBadTimeFormat},
+		{"naturalUnknownTZ", func() error { _, err := ts.ParseNatural("tomorrow", "Mars/Base", "", ""); return err }, ErrUnknownTZ},
+		{"naturalParseFailed", func() error { _, err := ts.ParseNatural("gibberish", "UTC", "", ""); return err }, ErrParseFailed},
+		{"naturalLanguage", func() error { _, err := ts.ParseNatural("demain", "UTC", "", "fr"); return err }, ErrUnsupportedLanguage},
+		// Wrapped by a tool method, the code is still reachable.
+		{"wrappedDatetime", func() error { _, err := ts.DayInfo("not a date", "UTC"); return err }, ErrBadTimeFormat},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.call()
+			var te *TimeError
+			if !errors.As(err, &te) {
+				t.Fatalf("error %v is not a TimeError", err)
+			}
+			if te.Code != tc.code {
+				t.Errorf("code = %s, want %s", te.Code, tc.code)
+			}
+		})
+	}
+}
+
+func TestErrorResult(t *testing.T) {
+	textOf := func(res *mcp.CallToolResult) string {
+		if !res.IsError || len(res.Content) != 1 {
+			t.Fatalf("expected a single-content error result, got %+v", res)
+		}
+		return res.Content[0].(mcp.TextContent).Text
+	}
+
+	res, _ := errorResult(fmt.Errorf("start: %w", timeErrorf(ErrUnknownTZ, "unknown time zone Mars/Base")))
+	var got TimeError
+	if err := json.Unmarshal([]byte(textOf(res)), &got); err != nil {
+		t.Fatalf("structured error is not JSON: %v", err)
+	}
+	want := TimeError{Code: ErrUnknownTZ, Message: "start: unknown time zone Mars/Base"}
+	if got != want {
+		t.Errorf("errorResult = %+v, want %+v", got, want)
+	}
+
+	res, _ = errorResult(errors.New("plain failure"))
+	if text := textOf(res); text != "plain failure" {
+		t.Errorf("untyped error = %q, want plain text", text)
+	}
+}
+
+func TestFixedOffsetZones(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 12, 0, 0, 0, time.UTC) })
+
+	res, err := ts.GetCurrentTime("UTC+5:30", "")
+	if err != nil {
+		t.Fatalf("GetCurrentTime(UTC+5:30) error: %v", err)
+	}
+	if res.UTCOffset != "+05:30" || res.Datetime != "2025-05-14T17:30:00+05:30" || res.Timezone != "UTC+5:30" {
+		t.Errorf("GetCurrentTime(UTC+5:30) = %+v", res)
+	}
+
+	cases := []struct {
+		spec string
+		want int // offset in seconds
+	}{
+		{"UTC+5", 5 * 3600},
+		{"UTC-3:30", -(3*3600 + 30*60)},
+		{"UTC+05:45", 5*3600 + 45*60},
+		{"utc+1", 3600},
+		{"GMT-8", -8 * 3600},
+		{"+09:00", 9 * 3600},
+		{"-00:30", -30 * 60},
+		{"UTC+14", 14 * 3600},
+	}
+	for _, tc := range cases {
+		t.Run(tc.spec, func(t *testing.T) {
+			loc, err := ts.loadLocation(tc.spec)
+			if err != nil {
+				t.Fatalf("loadLocation(%q) error: %v", tc.spec, err)
+			}
+			if _, off := time.Date(2025, 1, 1, 0, 0, 0, 0, loc).Zone(); off != tc.want {
+				t.Errorf("loadLocation(%q) offset = %d, want %d", tc.spec, off, tc.want)
+			}
+		})
+	}
+
+	for _, bad := range []string{"UTC+15", "UTC+5:75", "UTC+", "5:30", "UTC+5:3"} {
+		if _, err := ts.loadLocation(bad); err == nil {
+			t.Errorf("loadLocation(%q) expected error", bad)
+		}
+	}
+
+	// ConvertTime and ParseNatural take the same specs.
+	conv, err := ts.ConvertTime("UTC-3:30", "09:00", "UTC", "")
+	if err != nil {
+		t.Fatalf("ConvertTime error: %v", err)
+	}
+	if conv.Target.Datetime != "2025-05-14T12:30:00Z" {
+		t.Errorf("ConvertTime(UTC-3:30 09:00) target = %s, want 2025-05-14T12:30:00Z", conv.Target.Datetime)
+	}
+	if _, err := ts.ParseNatural("tomorrow", "+09:00", "", ""); err != nil {
+		t.Errorf("ParseNatural with +09:00 error: %v", err)
+	}
+}
+
+func TestGetCurrentTimeMulti(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	calls := 0
+	ts.forTesting_SetNowFunc(func() time.Time {
+		calls++
+		return time.Date(2025, 5, 14, 12, 0, 0, 123, time.UTC).Add(time.Duration(calls) * time.Second)
+	})
+
+	res, err := ts.GetCurrentTimeMulti([]string{"Asia/Tokyo", "Mars/Base", "America/New_York", "UTC+5:30", ""})
+	if err != nil {
+		t.Fatalf("GetCurrentTimeMulti error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("nowFunc called %d times, want a single snapshot", calls)
+	}
+	want := []struct{ tz, datetime string }{
+		{"Asia/Tokyo", "2025-05-14T21:00:01+09:00"},
+		{"Mars/Base", ""},
+		{"America/New_York", "2025-05-14T08:00:01-04:00"},
+		{"UTC+5:30", "2025-05-14T17:30:01+05:30"},
+		{"UTC", "2025-05-14T12:00:01Z"},
+	}
+	if len(res) != len(want) {
+		t.Fatalf("got %d results, want %d", len(res), len(want))
+	}
+	for i, w := range want {
+		if res[i].Timezone != w.tz || res[i].Datetime != w.datetime {
+			t.Errorf("entry %d = %s %s, want %s %s", i, res[i].Timezone, res[i].Datetime, w.tz, w.datetime)
+		}
+		if (res[i].Error != "") != (w.datetime == "") {
+			t.Errorf("entry %d error = %q", i, res[i].Error)
+		}
+	}
+	if res[0].UnixMillis != res[2].UnixMillis {
+		t.Error("entries should share one instant")
+	}
+
+	if _, err := ts.GetCurrentTimeMulti(nil); err == nil {
+		t.Error("expected error for empty zone list")
+	}
+	if _, err := ts.GetCurrentTimeMulti(make([]string, maxMultiZones+1)); err == nil {
+		t.Error("expected error above maxMultiZones")
+	}
+}
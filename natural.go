@@ -0,0 +1,576 @@
+// natural.go
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+/* ----- data types ----- */
+
+type FuzzyRangeResult struct {
+	Expression string     `json:"expression"`
+	Term       string     `json:"term"`
+	Start      TimeResult `json:"start"`
+	End        TimeResult `json:"end"`
+	Duration   string     `json:"duration"`
+	Convention string     `json:"convention"`
+}
+
+type RelativeResult struct {
+	Datetime        TimeResult `json:"datetime"`
+	Now             TimeResult `json:"now"`
+	Phrase          string     `json:"phrase"`
+	Count           int        `json:"count"`
+	Unit            string     `json:"unit"`
+	Future          bool       `json:"future"`
+	Locale          string     `json:"locale"`
+	RequestedLocale string     `json:"requested_locale,omitempty"`
+	Fallback        bool       `json:"fallback"`
+}
+
+type NaturalRangeResult struct {
+	Expression  string     `json:"expression"`
+	Start       TimeResult `json:"start"`
+	End         TimeResult `json:"end"` // last second inside the range
+	Granularity string     `json:"granularity"`
+	Matched     string     `json:"matched,omitempty"` // text the date parser recognised
+}
+
+type NaturalDiffResult struct {
+	First             TimeResult `json:"first"`
+	Second            TimeResult `json:"second"`
+	Difference        string     `json:"difference"` // second minus first
+	DifferenceSeconds float64    `json:"difference_seconds"`
+	DifferenceHours   float64    `json:"difference_hours"`
+	CalendarDays      int        `json:"calendar_days"`
+	SecondIsLater     bool       `json:"second_is_later"`
+}
+
+/* ----- helpers ----- */
+
+// dayparts are the default local-time ranges for fuzzy parts of the day.
+// Point terms (noon, midnight) have Start == End and only gain width when
+// qualified with "around".
+var dayparts = map[string]clockWindow{
+	"early morning":   {5 * time.Hour, 8 * time.Hour},
+	"morning":         {6 * time.Hour, 12 * time.Hour},
+	"late morning":    {10 * time.Hour, 12 * time.Hour},
+	"noon":            {12 * time.Hour, 12 * time.Hour},
+	"midday":          {11 * time.Hour, 13 * time.Hour},
+	"lunchtime":       {12 * time.Hour, 14 * time.Hour},
+	"early afternoon": {12 * time.Hour, 15 * time.Hour},
+	"afternoon":       {12 * time.Hour, 17 * time.Hour},
+	"late afternoon":  {15 * time.Hour, 18 * time.Hour},
+	"early evening":   {17 * time.Hour, 19 * time.Hour},
+	"evening":         {17 * time.Hour, 21 * time.Hour},
+	"late evening":    {20 * time.Hour, 23 * time.Hour},
+	"tonight":         {18 * time.Hour, 24 * time.Hour},
+	"night":           {21 * time.Hour, 24 * time.Hour},
+	"midnight":        {24 * time.Hour, 24 * time.Hour},
+}
+
+// defaultAroundMinutes is how far "around <time>" extends either side.
+const defaultAroundMinutes = 30
+
+var (
+	fuzzyPrefixRe = regexp.MustCompile(`^(sometime|some time|at some point)\s+`)
+	aroundRe      = regexp.MustCompile(`^(around|about|approximately|circa|roughly|~)\s*`)
+	fuzzyPeriodRe = regexp.MustCompile(`^(this|next|last)\s+(week|weekend|month)$`)
+
+	betweenRe   = regexp.MustCompile(`^(.*?)\s*\b(?:between|from)\s+(.+?)\s+(?:and|to|until|till)\s+(.+)$`)
+	timeOfDayRe = regexp.MustCompile(`\d\s*(?:am|pm|a\.m\.|p\.m\.)|\d:\d\d|\b(?:noon|midnight|now|o'clock|hours?|minutes?|mins?|seconds?|secs?)\b`)
+	// rangeEndRe splits the end of "between X and Y" into a clock time and a
+	// trailing date phrase ("4pm tomorrow").
+	rangeEndRe = regexp.MustCompile(`^(\d{1,2}(?::\d\d)?\s*(?:am|pm|a\.m\.|p\.m\.)?|noon|midnight)\s+(.+)$`)
+)
+
+// fuzzyPeriod resolves "this/next/last week|weekend|month" to a range of
+// whole days. Weeks start on Monday; a weekend is Saturday through Sunday.
+func fuzzyPeriod(rel, unit string, now time.Time) (time.Time, time.Time) {
+	shift := map[string]int{"last": -1, "this": 0, "next": 1}[rel]
+	today := startOfDay(now)
+	switch unit {
+	case "month":
+		first := time.Date(now.Year(), now.Month()+time.Month(shift), 1, 0, 0, 0, 0, now.Location())
+		return first, first.AddDate(0, 1, 0)
+	case "weekend":
+		sat := today.AddDate(0, 0, (int(time.Saturday)-int(today.Weekday())+7)%7)
+		if today.Weekday() == time.Sunday {
+			sat = today.AddDate(0, 0, -1)
+		}
+		sat = sat.AddDate(0, 0, 7*shift)
+		return sat, sat.AddDate(0, 0, 2)
+	}
+	monday := today.AddDate(0, 0, -mod(int(today.Weekday())-int(time.Monday), 7)+7*shift)
+	return monday, monday.AddDate(0, 0, 7)
+}
+
+// formatClock renders a time-of-day offset as HH:MM.
+func formatClock(c time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(c/time.Hour), int(c%time.Hour/time.Minute))
+}
+
+// matchDaypart finds the longest daypart term contained in s and returns it
+// with the rest of the expression.
+func matchDaypart(s string, parts map[string]clockWindow) (string, string) {
+	terms := make([]string, 0, len(parts))
+	for k := range parts {
+		terms = append(terms, k)
+	}
+	sort.Slice(terms, func(i, j int) bool { return len(terms[i]) > len(terms[j]) })
+	for _, term := range terms {
+		re := regexp.MustCompile(`(^|\s)(in the |at |this )?` + regexp.QuoteMeta(term) + `(\s|$)`)
+		if loc := re.FindStringIndex(s); loc != nil {
+			rest := strings.TrimSpace(s[:loc[0]] + " " + s[loc[1]:])
+			return term, rest
+		}
+	}
+	return "", s
+}
+
+// relLocale holds the templates for one language: past/future wrap the
+// "<n> <unit>" phrase and units maps each unit to its plural forms keyed by
+// the category returned from plural.
+type relLocale struct {
+	past, future, now string
+	plural            func(n int) string
+	units             map[string]map[string]string
+}
+
+func pluralOneOther(n int) string {
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+// pluralFrench treats zero as singular ("0 jour"), unlike English.
+func pluralFrench(n int) string {
+	if n == 0 || n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+// pluralSlavic implements the one/few/many split shared by Russian and
+// Polish; Polish uses "one" only for exactly 1 (21 lat, not 21 rok).
+func pluralSlavic(onlyExactOne bool) func(int) string {
+	return func(n int) string {
+		m10, m100 := n%10, n%100
+		switch {
+		case n == 1 || (!onlyExactOne && m10 == 1 && m100 != 11):
+			return "one"
+		case m10 >= 2 && m10 <= 4 && (m100 < 12 || m100 > 14):
+			return "few"
+		}
+		return "many"
+	}
+}
+
+// oneOther builds a unit table from singular/plural pairs.
+func oneOther(pairs ...string) map[string]map[string]string {
+	units := []string{"second", "minute", "hour", "day", "week", "month", "year"}
+	out := make(map[string]map[string]string, len(units))
+	for i, u := range units {
+		out[u] = map[string]string{"one": pairs[2*i], "other": pairs[2*i+1]}
+	}
+	return out
+}
+
+// oneFewMany builds a unit table from one/few/many triples.
+func oneFewMany(forms ...string) map[string]map[string]string {
+	units := []string{"second", "minute", "hour", "day", "week", "month", "year"}
+	out := make(map[string]map[string]string, len(units))
+	for i, u := range units {
+		out[u] = map[string]string{"one": forms[3*i], "few": forms[3*i+1], "many": forms[3*i+2]}
+	}
+	return out
+}
+
+var relLocales = map[string]relLocale{
+	"en": {"%s ago", "in %s", "just now", pluralOneOther,
+		oneOther("second", "seconds", "minute", "minutes", "hour", "hours", "day", "days", "week", "weeks", "month", "months", "year", "years")},
+	"fr": {"il y a %s", "dans %s", "à l'instant", pluralFrench,
+		oneOther("seconde", "secondes", "minute", "minutes", "heure", "heures", "jour", "jours", "semaine", "semaines", "mois", "mois", "an", "ans")},
+	"es": {"hace %s", "dentro de %s", "ahora mismo", pluralOneOther,
+		oneOther("segundo", "segundos", "minuto", "minutos", "hora", "horas", "día", "días", "semana", "semanas", "mes", "meses", "año", "años")},
+	"de": {"vor %s", "in %s", "gerade eben", pluralOneOther,
+		oneOther("Sekunde", "Sekunden", "Minute", "Minuten", "Stunde", "Stunden", "Tag", "Tagen", "Woche", "Wochen", "Monat", "Monaten", "Jahr", "Jahren")},
+	"it": {"%s fa", "tra %s", "proprio ora", pluralOneOther,
+		oneOther("secondo", "secondi", "minuto", "minuti", "ora", "ore", "giorno", "giorni", "settimana", "settimane", "mese", "mesi", "anno", "anni")},
+	"pt": {"há %s", "em %s", "agora mesmo", pluralOneOther,
+		oneOther("segundo", "segundos", "minuto", "minutos", "hora", "horas", "dia", "dias", "semana", "semanas", "mês", "meses", "ano", "anos")},
+	"ru": {"%s назад", "через %s", "только что", pluralSlavic(false),
+		oneFewMany("секунду", "секунды", "секунд", "минуту", "минуты", "минут", "час", "часа", "часов", "день", "дня", "дней",
+			"неделю", "недели", "недель", "месяц", "месяца", "месяцев", "год", "года", "лет")},
+	"pl": {"%s temu", "za %s", "przed chwilą", pluralSlavic(true),
+		oneFewMany("sekundę", "sekundy", "sekund", "minutę", "minuty", "minut", "godzinę", "godziny", "godzin", "dzień", "dni", "dni",
+			"tydzień", "tygodnie", "tygodni", "miesiąc", "miesiące", "miesięcy", "rok", "lata", "lat")},
+}
+
+// relativeUnits are tried largest first; months and years are averaged.
+var relativeUnits = []struct {
+	name string
+	size time.Duration
+}{
+	{"year", 365 * day},
+	{"month", 30 * day},
+	{"week", 7 * day},
+	{"day", day},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// baseLocale reduces "fr-FR" or "pt_BR" to its language code.
+func baseLocale(l string) string {
+	l = strings.ToLower(strings.TrimSpace(l))
+	if i := strings.IndexAny(l, "-_"); i >= 0 {
+		l = l[:i]
+	}
+	return l
+}
+
+/* ----- core methods ----- */
+
+// FuzzyRange turns an imprecise expression into a plausible local range.
+// Parts of the day ("early morning", "late evening") use dayparts unless
+// overridden; "around <time>" widens by aroundMinutes either side; "this /
+// next / last week|weekend|month" span whole days. Any remaining text is
+// the date, read by the natural-language parser; none means today.
+func (t *TimeServer) FuzzyRange(expr, tz string, overrides map[string]string, aroundMinutes float64, format string) (FuzzyRangeResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return FuzzyRangeResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return FuzzyRangeResult{}, err
+	}
+	if aroundMinutes <= 0 {
+		aroundMinutes = defaultAroundMinutes
+	}
+	parts := make(map[string]clockWindow, len(dayparts)+len(overrides))
+	for k, v := range dayparts {
+		parts[k] = v
+	}
+	for k, v := range overrides {
+		w, err := parseClockWindow(v, true)
+		if err != nil {
+			return FuzzyRangeResult{}, fmt.Errorf("range for %q: %w", k, err)
+		}
+		parts[strings.ToLower(strings.TrimSpace(k))] = w
+	}
+
+	now := t.nowFunc().In(loc)
+	s := strings.ToLower(strings.Join(strings.Fields(expr), " "))
+	s = fuzzyPrefixRe.ReplaceAllString(s, "")
+	around := aroundRe.MatchString(s)
+	s = aroundRe.ReplaceAllString(s, "")
+	margin := time.Duration(aroundMinutes * float64(time.Minute))
+
+	res := FuzzyRangeResult{Expression: expr}
+	var from, until time.Time
+	if m := fuzzyPeriodRe.FindStringSubmatch(s); m != nil {
+		res.Term = m[1] + " " + m[2]
+		from, until = fuzzyPeriod(m[1], m[2], now)
+		res.Convention = "weeks run Monday to Monday; weekends are Saturday and Sunday; months are whole calendar months"
+	} else if term, rest := matchDaypart(s, parts); term != "" {
+		d, err := t.parseDateTime(rest, loc)
+		if err != nil {
+			return FuzzyRangeResult{}, fmt.Errorf("could not read the date in %q: %w", expr, err)
+		}
+		w := parts[term]
+		from, until = w.on(d)
+		res.Term = term
+		res.Convention = fmt.Sprintf("%s = %s-%s local time", term, formatClock(w.Start), formatClock(w.End))
+		if around {
+			from, until = from.Add(-margin), until.Add(margin)
+			res.Term = "around " + term
+			res.Convention += fmt.Sprintf(", widened by %.0f minutes either side", aroundMinutes)
+		}
+	} else if around {
+		at, err := t.parseDateTime(s, loc)
+		if err != nil {
+			return FuzzyRangeResult{}, fmt.Errorf("could not read the time in %q: %w", expr, err)
+		}
+		from, until = at.Add(-margin), at.Add(margin)
+		res.Term = "around"
+		res.Convention = fmt.Sprintf("around = %.0f minutes either side", aroundMinutes)
+	} else {
+		return FuzzyRangeResult{}, fmt.Errorf("no fuzzy time term found in %q", expr)
+	}
+
+	res.Start = makeTimeResult(tz, from)
+	res.End = makeTimeResult(tz, until)
+	res.Duration = render(until.Sub(from))
+	return res, nil
+}
+
+// RelativeLocalized phrases the distance from now to datetime as an "ago" or
+// "from now" expression in locale, using the largest unit that fits at least
+// once and that language's plural rules. Unsupported locales fall back to
+// English and are flagged.
+func (t *TimeServer) RelativeLocalized(datetime, tz, locale string) (RelativeResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return RelativeResult{}, err
+	}
+	target, err := t.parseDateTime(datetime, loc)
+	if err != nil {
+		return RelativeResult{}, err
+	}
+	now := t.nowFunc().In(loc)
+
+	res := RelativeResult{Datetime: makeTimeResult(tz, target), Now: makeTimeResult(tz, now), Locale: baseLocale(locale)}
+	if res.Locale == "" {
+		res.Locale = "en"
+	}
+	lang, ok := relLocales[res.Locale]
+	if !ok {
+		res.RequestedLocale, res.Locale, res.Fallback = locale, "en", true
+		lang = relLocales["en"]
+	}
+
+	diff := target.Sub(now)
+	res.Future = diff > 0
+	if diff < 0 {
+		diff = -diff
+	}
+	res.Unit = "second"
+	for _, u := range relativeUnits {
+		if diff >= u.size {
+			res.Unit, res.Count = u.name, int(diff/u.size)
+			break
+		}
+	}
+	if res.Count == 0 {
+		res.Phrase = lang.now
+		return res, nil
+	}
+	form := lang.units[res.Unit][lang.plural(res.Count)]
+	tmpl := lang.past
+	if res.Future {
+		tmpl = lang.future
+	}
+	res.Phrase = fmt.Sprintf(tmpl, fmt.Sprintf("%d %s", res.Count, form))
+	return res, nil
+}
+
+// NaturalDiff resolves two natural-language expressions with ParseNatural
+// against the same now and returns second minus first. A parse failure names
+// the expression (first or second) that could not be resolved. Difference
+// is rendered in format (see durationFormatter).
+func (t *TimeServer) NaturalDiff(first, second, tz, format string) (NaturalDiffResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return NaturalDiffResult{}, err
+	}
+	resolve := func(name, expr string) (TimeResult, time.Time, error) {
+		tr, err := t.ParseNatural(expr, tz, "", "")
+		if err != nil {
+			return TimeResult{}, time.Time{}, fmt.Errorf("%s: %w", name, err)
+		}
+		tm, err := time.Parse(time.RFC3339, tr.Datetime)
+		if err != nil {
+			return TimeResult{}, time.Time{}, fmt.Errorf("%s: %w", name, err)
+		}
+		return tr.TimeResult, tm, nil
+	}
+	a, at, err := resolve("first", first)
+	if err != nil {
+		return NaturalDiffResult{}, err
+	}
+	b, bt, err := resolve("second", second)
+	if err != nil {
+		return NaturalDiffResult{}, err
+	}
+
+	diff := bt.Sub(at)
+	return NaturalDiffResult{
+		First:             a,
+		Second:            b,
+		Difference:        render(diff),
+		DifferenceSeconds: diff.Seconds(),
+		DifferenceHours:   diff.Hours(),
+		CalendarDays:      daysBetween(at, bt),
+		SecondIsLater:     diff > 0,
+	}, nil
+}
+
+// ParseNaturalRange resolves an expression that names a span rather than an
+// instant. End is inclusive: the last second inside the range.
+//   - "between X and Y" / "from X to Y": both ends parsed, sharing any date
+//     given before "between" or after Y ("between 2pm and 4pm tomorrow");
+//     an end earlier than the start rolls to the next day ("from 10pm to
+//     2am").
+//   - "this/next/last week|weekend|month": whole days as in fuzzy_range, so
+//     a weekend runs Saturday 00:00 to Sunday 23:59:59.
+//   - a part of the day ("tomorrow morning"): its dayparts window.
+//   - anything else goes to the date parser. If the recognised text carries
+//     no time of day ("tomorrow", "next friday"), the range is that whole
+//     day; otherwise it is the single instant.
+func (t *TimeServer) ParseNaturalRange(expr, tz string) (NaturalRangeResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return NaturalRangeResult{}, err
+	}
+	now := t.nowFunc().In(loc)
+	s := strings.ToLower(strings.Join(strings.Fields(expr), " "))
+	res := NaturalRangeResult{Expression: expr}
+	var from, until time.Time // until is exclusive until the final conversion
+
+	if m := betweenRe.FindStringSubmatch(s); m != nil {
+		date, endClock := m[1], m[3]
+		if e := rangeEndRe.FindStringSubmatch(endClock); e != nil {
+			date, endClock = strings.TrimSpace(date+" "+e[2]), e[1]
+		}
+		if from, err = t.parseDateTime(strings.TrimSpace(date+" "+m[2]), loc); err != nil {
+			return NaturalRangeResult{}, fmt.Errorf("range start: %w", err)
+		}
+		if until, err = t.parseDateTime(strings.TrimSpace(date+" "+endClock), loc); err != nil {
+			return NaturalRangeResult{}, fmt.Errorf("range end: %w", err)
+		}
+		if until.Before(from) {
+			until = until.AddDate(0, 0, 1)
+		}
+		res.Granularity = "explicit"
+	} else if m := fuzzyPeriodRe.FindStringSubmatch(s); m != nil {
+		from, until = fuzzyPeriod(m[1], m[2], now)
+		res.Granularity = m[2]
+	} else if term, rest := matchDaypart(s, dayparts); term != "" {
+		d, err := t.parseDateTime(rest, loc)
+		if err != nil {
+			return NaturalRangeResult{}, fmt.Errorf("could not read the date in %q: %w", expr, err)
+		}
+		from, until = dayparts[term].on(d)
+		res.Granularity = "daypart"
+	} else {
+		r, err := t.parser.Parse(s, now)
+		if err != nil || r == nil {
+			return NaturalRangeResult{}, timeErrorf(ErrParseFailed, "could not parse expression: %s", expr)
+		}
+		res.Matched = r.Text
+		at := r.Time.In(loc)
+		if timeOfDayRe.MatchString(r.Text) {
+			res.Granularity = "instant"
+			res.Start, res.End = makeTimeResult(tz, at), makeTimeResult(tz, at)
+			return res, nil
+		}
+		from = startOfDay(at)
+		until = from.AddDate(0, 0, 1)
+		res.Granularity = "day"
+	}
+	if !until.After(from) {
+		until = from.Add(time.Second)
+	}
+	res.Start, res.End = makeTimeResult(tz, from), makeTimeResult(tz, until.Add(-time.Second))
+	return res, nil
+}
+
+/* ----- tools ----- */
+
+func registerNaturalTools(s *server.MCPServer, ts *TimeServer) {
+	fuzzy := mcp.NewTool(
+		"fuzzy_range",
+		mcp.WithDescription("Turn a vague phrase such as 'around noon', 'early morning tomorrow' or 'sometime next week' into a plausible start/end range. Default ranges: early morning 05-08, morning 06-12, late morning 10-12, afternoon 12-17, late afternoon 15-18, evening 17-21, late evening 20-23, night 21-24."),
+		mcp.WithString("expression", mcp.Required()),
+		mcp.WithString("timezone"),
+		mcp.WithObject("ranges", mcp.Description("Override or add terms, e.g. {\"morning\": \"07:00-11:00\"}")),
+		mcp.WithNumber("around_minutes", mcp.Description("How far 'around' extends either side (default 30)")),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(fuzzy, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			Expression    string            `json:"expression"`
+			Timezone      string            `json:"timezone"`
+			Ranges        map[string]string `json:"ranges"`
+			AroundMinutes float64           `json:"around_minutes"`
+		}
+		if err := r.BindArguments(&args); err != nil {
+			return errorResult(err)
+		}
+		if args.Expression == "" {
+			return errorResult(fmt.Errorf("expression is required"))
+		}
+		res, err := ts.FuzzyRange(args.Expression, args.Timezone, args.Ranges, args.AroundMinutes, r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	relative := mcp.NewTool(
+		"relative_localized",
+		mcp.WithDescription("Describe a datetime relative to now ('3 days ago', 'il y a 3 jours', 'через 2 часа') in the requested language. Supports en, fr, es, de, it, pt, ru and pl; other locales fall back to English."),
+		mcp.WithString("datetime", mcp.Required()),
+		mcp.WithString("timezone"),
+		mcp.WithString("locale", mcp.Description("Language code such as 'fr' or 'pt-BR' (default en)")),
+	)
+
+	s.AddTool(relative, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		datetime, err := r.RequireString("datetime")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.RelativeLocalized(datetime, r.GetString("timezone", ""), r.GetString("locale", "en"))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	diff := mcp.NewTool(
+		"natural_diff",
+		mcp.WithDescription("Duration between two natural-language times such as 'next Monday noon' and 'tomorrow 3pm', both resolved against the same now."),
+		mcp.WithString("first", mcp.Required()),
+		mcp.WithString("second", mcp.Required()),
+		mcp.WithString("timezone"),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(diff, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		first, err := r.RequireString("first")
+		if err != nil {
+			return errorResult(err)
+		}
+		second, err := r.RequireString("second")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.NaturalDiff(first, second, r.GetString("timezone", ""), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	naturalRange := mcp.NewTool(
+		"parse_natural_range",
+		mcp.WithDescription("Resolve an expression naming a span ('this weekend', 'tomorrow', 'between 2pm and 4pm', 'next month') to an inclusive start/end. Bare dates cover the whole day; weekends run Saturday 00:00 to Sunday 23:59:59, and 'between 2pm and 4pm' ends at 15:59:59. A date before 'between' or after the end applies to both ends."),
+		mcp.WithString("expression", mcp.Required()),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(naturalRange, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		expr, err := r.RequireString("expression")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.ParseNaturalRange(expr, r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+}
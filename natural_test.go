@@ -0,0 +1,161 @@
+// natural_test.go
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFuzzyRange(t *testing.T) {
+	locNY, _ := time.LoadLocation("America/New_York")
+	ts := NewTimeServer("UTC")
+	// Wednesday 2025-05-14 09:00 New York.
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 9, 0, 0, 0, locNY) })
+
+	cases := []struct {
+		expr       string
+		wantStart  string
+		wantEnd    string
+		wantSource string
+	}{
+		{"around noon", "2025-05-14T11:30:00-04:00", "2025-05-14T12:30:00-04:00", "around noon"},
+		{"early morning tomorrow", "2025-05-15T05:00:00-04:00", "2025-05-15T08:00:00-04:00", "early morning"},
+		{"late evening", "2025-05-14T20:00:00-04:00", "2025-05-14T23:00:00-04:00", "late evening"},
+		{"sometime next week", "2025-05-19T00:00:00-04:00", "2025-05-26T00:00:00-04:00", "next week"},
+		{"this weekend", "2025-05-17T00:00:00-04:00", "2025-05-19T00:00:00-04:00", "this weekend"},
+		{"around 2025-05-20 15:00", "2025-05-20T14:30:00-04:00", "2025-05-20T15:30:00-04:00", "around"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.expr, func(t *testing.T) {
+			res, err := ts.FuzzyRange(tc.expr, "America/New_York", nil, 0, "")
+			if err != nil {
+				t.Fatalf("FuzzyRange(%q) error: %v", tc.expr, err)
+			}
+			if res.Start.Datetime != tc.wantStart || res.End.Datetime != tc.wantEnd || res.Term != tc.wantSource {
+				t.Errorf("FuzzyRange(%q) = %s..%s (%s), want %s..%s (%s)", tc.expr,
+					res.Start.Datetime, res.End.Datetime, res.Term, tc.wantStart, tc.wantEnd, tc.wantSource)
+			}
+		})
+	}
+
+	res, err := ts.FuzzyRange("morning", "America/New_York", map[string]string{"morning": "07:00-11:00"}, 0, "")
+	if err != nil {
+		t.Fatalf("FuzzyRange with override error: %v", err)
+	}
+	if res.Start.Datetime != "2025-05-14T07:00:00-04:00" || res.End.Datetime != "2025-05-14T11:00:00-04:00" {
+		t.Errorf("override not applied: %s..%s", res.Start.Datetime, res.End.Datetime)
+	}
+
+	if _, err := ts.FuzzyRange("whenever", "UTC", nil, 0, ""); err == nil {
+		t.Errorf("expected error for an expression without a fuzzy term, got nil")
+	}
+}
+
+func TestRelativeLocalized(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 12, 0, 0, 0, time.UTC) })
+
+	cases := []struct {
+		datetime, locale string
+		want             string
+	}{
+		{"2025-05-11 12:00", "fr", "il y a 3 jours"},
+		{"2025-05-13 11:00", "fr-FR", "il y a 1 jour"},
+		{"2025-05-14 14:30", "en", "in 2 hours"},
+		{"2025-05-14 11:59", "es", "hace 1 minuto"},
+		{"2025-05-09 12:00", "de", "vor 5 Tagen"},
+		{"2025-05-16 12:00", "ru", "через 2 дня"},
+		{"2025-05-09 12:00", "ru", "5 дней назад"},
+		{"2025-05-13 12:00", "ru", "1 день назад"},
+		{"2004-05-14 12:00", "ru", "21 год назад"},
+		{"2004-05-14 12:00", "pl", "21 lat temu"},
+		{"2022-05-14 12:00", "pl", "3 lata temu"},
+		{"2025-05-14 12:00", "it", "proprio ora"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.locale+"/"+tc.datetime, func(t *testing.T) {
+			res, err := ts.RelativeLocalized(tc.datetime, "UTC", tc.locale)
+			if err != nil {
+				t.Fatalf("RelativeLocalized error: %v", err)
+			}
+			if res.Phrase != tc.want {
+				t.Errorf("RelativeLocalized(%q, %q) = %q, want %q", tc.datetime, tc.locale, res.Phrase, tc.want)
+			}
+		})
+	}
+
+	res, err := ts.RelativeLocalized("2025-05-11 12:00", "UTC", "xx")
+	if err != nil {
+		t.Fatalf("RelativeLocalized error: %v", err)
+	}
+	if !res.Fallback || res.Phrase != "3 days ago" {
+		t.Errorf("expected English fallback, got %+v", res)
+	}
+}
+
+func TestNaturalDiff(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	// Wednesday, May 14 2025, 10:00 in New York.
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 14, 0, 0, 0, time.UTC) })
+
+	res, err := ts.NaturalDiff("tomorrow 3pm", "next monday at noon", "America/New_York", "")
+	if err != nil {
+		t.Fatalf("NaturalDiff error: %v", err)
+	}
+	if res.First.Datetime != "2025-05-15T15:00:00-04:00" || res.Second.Datetime != "2025-05-19T12:00:00-04:00" ||
+		res.DifferenceHours != 93 || res.CalendarDays != 4 || !res.SecondIsLater {
+		t.Errorf("got %+v", res)
+	}
+
+	back, err := ts.NaturalDiff("in 3 hours", "yesterday at 9am", "America/New_York", "")
+	if err != nil {
+		t.Fatalf("NaturalDiff error: %v", err)
+	}
+	if back.SecondIsLater || back.DifferenceHours != -28 {
+		t.Errorf("reversed diff = %+v", back)
+	}
+
+	_, err = ts.NaturalDiff("tomorrow 3pm", "the twelfth of never", "UTC", "")
+	if err == nil || !strings.HasPrefix(err.Error(), "second:") {
+		t.Errorf("expected an error naming the second expression, got %v", err)
+	}
+}
+
+func TestParseNaturalRange(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 9, 0, 0, 0, time.UTC) }) // Wednesday
+
+	cases := []struct {
+		name, expr, tz, start, end, granularity string
+	}{
+		{"thisWeekend", "this weekend", "UTC", "2025-05-17T00:00:00Z", "2025-05-18T23:59:59Z", "weekend"},
+		{"tomorrow", "tomorrow", "UTC", "2025-05-15T00:00:00Z", "2025-05-15T23:59:59Z", "day"},
+		{"weekdayName", "next friday", "UTC", "2025-05-16T00:00:00Z", "2025-05-16T23:59:59Z", "day"},
+		{"nextMonth", "next month", "UTC", "2025-06-01T00:00:00Z", "2025-06-30T23:59:59Z", "month"},
+		{"between", "between 2pm and 4pm", "UTC", "2025-05-14T14:00:00Z", "2025-05-14T15:59:59Z", "explicit"},
+		{"betweenWithDate", "tomorrow between 2pm and 4pm", "UTC", "2025-05-15T14:00:00Z", "2025-05-15T15:59:59Z", "explicit"},
+		{"betweenTrailingDate", "between 2pm and 4pm tomorrow", "UTC", "2025-05-15T14:00:00Z", "2025-05-15T15:59:59Z", "explicit"},
+		{"fromToTrailingWeekday", "from 9:30 am to 11am next friday", "UTC", "2025-05-16T09:30:00Z", "2025-05-16T10:59:59Z", "explicit"},
+		{"fromToOvernight", "from 10pm to 2am", "UTC", "2025-05-14T22:00:00Z", "2025-05-15T01:59:59Z", "explicit"},
+		{"daypart", "tomorrow morning", "UTC", "2025-05-15T06:00:00Z", "2025-05-15T11:59:59Z", "daypart"},
+		{"instant", "tomorrow at 3pm", "UTC", "2025-05-15T15:00:00Z", "2025-05-15T15:00:00Z", "instant"},
+		{"weekendInZone", "this weekend", "America/New_York", "2025-05-17T00:00:00-04:00", "2025-05-18T23:59:59-04:00", "weekend"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.ParseNaturalRange(tc.expr, tc.tz)
+			if err != nil {
+				t.Fatalf("ParseNaturalRange(%q) error: %v", tc.expr, err)
+			}
+			if res.Start.Datetime != tc.start || res.End.Datetime != tc.end || res.Granularity != tc.granularity {
+				t.Errorf("ParseNaturalRange(%q) = %s .. %s (%s), want %s .. %s (%s)", tc.expr,
+					res.Start.Datetime, res.End.Datetime, res.Granularity, tc.start, tc.end, tc.granularity)
+			}
+		})
+	}
+
+	if _, err := ts.ParseNaturalRange("gibberish", "UTC"); err == nil {
+		t.Error("expected error for unparseable expression")
+	}
+}
@@ -73,7 +73,7 @@ func TestTimeServerParseNatural_Deterministic(t *testing.T) {
 	})
 
 	// Helper to check TimeResult
-	checkResult := func(t *testing.T, res TimeResult, expr, expectedOutputTZ string, checkTime func(parsedTime time.Time, loc *time.Location)) {
+	checkResult := func(t *testing.T, res NaturalParseResult, expr, expectedOutputTZ string, checkTime func(parsedTime time.Time, loc *time.Location)) {
 		t.Helper()
 		if res.Timezone != expectedOutputTZ {
 			t.Errorf("expr %q: expected output timezone %q, got %q", expr, expectedOutputTZ, res.Timezone)
@@ -82,6 +82,9 @@ func TestTimeServerParseNatural_Deterministic(t *testing.T) {
 		if err != nil {
 			t.Fatalf("expr %q: could not parse returned datetime %q: %v", expr, res.Datetime, err)
 		}
+		if res.UnixSeconds != parsedTimeUTC.Unix() || res.UnixMillis != parsedTimeUTC.UnixMilli() {
+			t.Errorf("expr %q: unix %d / %d ms does not match datetime %q", expr, res.UnixSeconds, res.UnixMillis, res.Datetime)
+		}
 
 		// Load the location for the expectedOutputTZ to perform checks in that zone
 		outputLoc, err := time.LoadLocation(expectedOutputTZ)
@@ -97,7 +100,7 @@ func TestTimeServerParseNatural_Deterministic(t *testing.T) {
 	t.Run("specificDateTimeWithExplicitTZ", func(t *testing.T) {
 		expr := "July 4, 2026 10:00 AM"
 		parseAsTZ := "America/Los_Angeles" // Parse expression as if it's LA time
-		res, err := ts.ParseNatural(expr, parseAsTZ)
+		res, err := ts.ParseNatural(expr, parseAsTZ, "", "")
 		if err != nil {
 			t.Fatalf("ParseNatural(%q, %q) error: %v", expr, parseAsTZ, err)
 		}
@@ -117,7 +120,7 @@ func TestTimeServerParseNatural_Deterministic(t *testing.T) {
 	t.Run("relativeTomorrowUsingFixedNowInUTC", func(t *testing.T) {
 		expr := "tomorrow at 9:30am"
 		parseAsTZ := "UTC" // Parse relative to fixedNow (converted to UTC)
-		res, err := ts.ParseNatural(expr, parseAsTZ)
+		res, err := ts.ParseNatural(expr, parseAsTZ, "", "")
 		if err != nil {
 			t.Fatalf("ParseNatural(%q, %q) error: %v", expr, parseAsTZ, err)
 		}
@@ -137,7 +140,7 @@ func TestTimeServerParseNatural_Deterministic(t *testing.T) {
 	t.Run("relativeNextMondayUsingFixedNowInChicago", func(t *testing.T) {
 		expr := "next monday 2pm"
 		parseAsTZ := "America/Chicago" // Parse relative to fixedNow (converted to Chicago time)
-		res, err := ts.ParseNatural(expr, parseAsTZ)
+		res, err := ts.ParseNatural(expr, parseAsTZ, "", "")
 		if err != nil {
 			t.Fatalf("ParseNatural(%q, %q) error: %v", expr, parseAsTZ, err)
 		}
@@ -165,7 +168,7 @@ func TestTimeServerParseNatural_Deterministic(t *testing.T) {
 		tsChicagoDefault.forTesting_SetNowFunc(func() time.Time { return fixedNow })
 
 		expr := "January 10, 2027 3:00 PM"
-		res, err := tsChicagoDefault.ParseNatural(expr, "") // Empty tz string, should use server's default
+		res, err := tsChicagoDefault.ParseNatural(expr, "", "", "") // Empty tz string, should use server's default
 		if err != nil {
 			t.Fatalf("ParseNatural(%q, \"\") error: %v", expr, err)
 		}
@@ -181,7 +184,7 @@ func TestTimeServerParseNatural_Deterministic(t *testing.T) {
 	t.Run("invalidTimezoneError", func(t *testing.T) {
 		expr := "now"
 		tz := "Invalid/Timezone"
-		_, err := ts.ParseNatural(expr, tz) // ts uses fixedNow
+		_, err := ts.ParseNatural(expr, tz, "", "") // ts uses fixedNow
 		if err == nil {
 			t.Fatalf("Expected error for invalid timezone %q, got nil", tz)
 		}
@@ -193,7 +196,7 @@ func TestTimeServerParseNatural_Deterministic(t *testing.T) {
 	t.Run("unparseableExpressionError", func(t *testing.T) {
 		expr := "this is not a date at all"
 		tz := "UTC"
-		_, err := ts.ParseNatural(expr, tz) // ts uses fixedNow
+		_, err := ts.ParseNatural(expr, tz, "", "") // ts uses fixedNow
 		if err == nil {
 			t.Fatalf("Expected error for unparseable expression %q, got nil", expr)
 		}
@@ -216,7 +219,7 @@ func TestTimeServerParseNatural_Deterministic(t *testing.T) {
 		tzNY := "America/New_York"
 
 		exprBefore := "March 9, 2025, 1:59 AM" // This is 1:59 AM EST
-		resBefore, errB := tsDSTTest.ParseNatural(exprBefore, tzNY)
+		resBefore, errB := tsDSTTest.ParseNatural(exprBefore, tzNY, "", "")
 		if errB != nil {
 			t.Fatalf("Error parsing %q: %v", exprBefore, errB)
 		}
@@ -231,7 +234,7 @@ func TestTimeServerParseNatural_Deterministic(t *testing.T) {
 		})
 
 		exprAfter := "March 9, 2025, 3:01 AM" // This is 3:01 AM EDT
-		resAfter, errA := tsDSTTest.ParseNatural(exprAfter, tzNY)
+		resAfter, errA := tsDSTTest.ParseNatural(exprAfter, tzNY, "", "")
 		if errA != nil {
 			t.Fatalf("Error parsing %q: %v", exprAfter, errA)
 		}
@@ -249,7 +252,7 @@ func TestTimeServerParseNatural_Deterministic(t *testing.T) {
 		// For "March 9, 2025, 2:30 AM" in NY, it doesn't exist.
 		// `when` might parse this as 2:30 standard time, which then becomes 3:30 daylight time.
 		exprDuring := "March 9, 2025, 2:30 AM"
-		resDuring, errD := tsDSTTest.ParseNatural(exprDuring, tzNY)
+		resDuring, errD := tsDSTTest.ParseNatural(exprDuring, tzNY, "", "")
 		if errD != nil {
 			t.Logf("Parsing %q (during DST spring forward) resulted in error (potentially expected for some parsers): %v", exprDuring, errD)
 			// Depending on 'when's strictness, an error might be valid.
@@ -269,3 +272,77 @@ func TestTimeServerParseNatural_Deterministic(t *testing.T) {
 		}
 	})
 }
+
+func TestParseNaturalLanguages(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 9, 0, 0, 0, time.UTC) })
+
+	cases := []struct {
+		name, expr, lang, wantDate string
+	}{
+		{"englishDefault", "tomorrow", "", "2025-05-15"},
+		{"english", "tomorrow", "en", "2025-05-15"},
+		{"russian", "завтра", "ru", "2025-05-15"},
+		{"russianUpperCode", "завтра", "RU", "2025-05-15"},
+		{"portuguese", "amanhã", "br", "2025-05-15"},
+		{"dutch", "morgen", "nl", "2025-05-15"},
+		{"chinese", "明天", "zh", "2025-05-15"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.ParseNatural(tc.expr, "UTC", "", tc.lang)
+			if err != nil {
+				t.Fatalf("ParseNatural(%q, %q) error: %v", tc.expr, tc.lang, err)
+			}
+			if !strings.HasPrefix(res.Datetime, tc.wantDate) {
+				t.Errorf("ParseNatural(%q, %q) = %s, want date %s", tc.expr, tc.lang, res.Datetime, tc.wantDate)
+			}
+		})
+	}
+
+	if _, err := ts.ParseNatural("tomorrow", "UTC", "", "ru"); err == nil {
+		t.Error("expected English expression to fail under the Russian rules")
+	}
+	_, err := ts.ParseNatural("demain", "UTC", "", "fr")
+	if err == nil || !strings.Contains(err.Error(), "br, en, nl, ru, zh") {
+		t.Errorf("unsupported language error = %v, want the supported codes listed", err)
+	}
+}
+
+func TestParseNaturalMatchedSpan(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 5, 14, 9, 0, 0, 0, time.UTC) })
+
+	cases := []struct {
+		name, expr, lang, matched string
+		start, end                int
+	}{
+		{"embedded", "let's meet next Friday at noon maybe", "", "next Friday at noon", 11, 30},
+		{"whole", "tomorrow", "", "tomorrow", 0, 8},
+		// Offsets count characters, not bytes.
+		{"cyrillic", "встреча завтра", "ru", "завтра", 8, 14},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.ParseNatural(tc.expr, "UTC", "", tc.lang)
+			if err != nil {
+				t.Fatalf("ParseNatural(%q) error: %v", tc.expr, err)
+			}
+			if res.MatchedText != tc.matched || res.MatchStart != tc.start || res.MatchEnd != tc.end {
+				t.Errorf("ParseNatural(%q) matched %q [%d:%d], want %q [%d:%d]",
+					tc.expr, res.MatchedText, res.MatchStart, res.MatchEnd, tc.matched, tc.start, tc.end)
+			}
+			if got := string([]rune(tc.expr)[res.MatchStart:res.MatchEnd]); got != res.MatchedText {
+				t.Errorf("span [%d:%d] of %q = %q, want %q", res.MatchStart, res.MatchEnd, tc.expr, got, res.MatchedText)
+			}
+		})
+	}
+
+	res, err := ts.ParseNatural("let's meet next Friday at noon maybe", "UTC", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Datetime != "2025-05-16T12:00:00Z" {
+		t.Errorf("datetime = %s, want 2025-05-16T12:00:00Z", res.Datetime)
+	}
+}
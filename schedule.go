@@ -0,0 +1,1423 @@
+// schedule.go
+
+package main
+
+import (
+	"context"
+	crand "crypto/rand"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+/* ----- data types ----- */
+
+type OncallResult struct {
+	OnCall         string     `json:"on_call"`
+	Index          int        `json:"index"`
+	Rotation       int        `json:"rotation"`
+	ShiftStart     TimeResult `json:"shift_start"`
+	ShiftEnd       TimeResult `json:"shift_end"`
+	NextOnCall     string     `json:"next_on_call"`
+	PreviousOnCall string     `json:"previous_on_call"`
+}
+
+type EventSpec struct {
+	Start    string `json:"start"`
+	Duration string `json:"duration"`
+}
+
+type EventSpan struct {
+	Start TimeResult `json:"start"`
+	End   TimeResult `json:"end"`
+}
+
+type EventsOverlapResult struct {
+	First          EventSpan `json:"first"`
+	Second         EventSpan `json:"second"`
+	Overlaps       bool      `json:"overlaps"`
+	Overlap        string    `json:"overlap,omitempty"`
+	OverlapSeconds float64   `json:"overlap_seconds"`
+	Gap            string    `json:"gap,omitempty"`
+	GapSeconds     float64   `json:"gap_seconds"`
+	BoundaryPolicy string    `json:"boundary_policy"`
+}
+
+// eventsBoundaryPolicy documents how EventsOverlap treats touching events.
+const eventsBoundaryPolicy = "events are half-open [start, end): back-to-back events do not overlap; a zero-duration event overlaps another only if its instant falls inside it"
+
+type RandomTimeResult struct {
+	Start         TimeResult `json:"start"`
+	End           TimeResult `json:"end"`
+	Result        TimeResult `json:"result"`
+	OffsetSeconds float64    `json:"offset_seconds"`
+	Seed          *int64     `json:"seed,omitempty"`
+}
+
+type BestSendResult struct {
+	Timezone    string     `json:"timezone"`
+	Window      string     `json:"window"`
+	ActiveNow   bool       `json:"active_now"`
+	SendAtUTC   string     `json:"send_at_utc"`
+	SendAtLocal TimeResult `json:"send_at_local"`
+	WindowEnd   TimeResult `json:"window_end"`
+}
+
+type InMaintenanceResult struct {
+	Timezone    string     `json:"timezone"`
+	Schedule    string     `json:"schedule"`
+	Now         TimeResult `json:"now"`
+	Active      bool       `json:"active"`
+	WindowStart TimeResult `json:"window_start"` // the current window, or the next one
+	WindowEnd   TimeResult `json:"window_end"`
+	Remaining   string     `json:"remaining,omitempty"`
+	StartsIn    string     `json:"starts_in,omitempty"`
+}
+
+type AssignSlotResult struct {
+	Key          string     `json:"key"`
+	Slot         int        `json:"slot"`
+	Slots        int        `json:"slots"`
+	SlotDuration string     `json:"slot_duration"`
+	Window       string     `json:"window"`
+	Start        TimeResult `json:"start"`
+	End          TimeResult `json:"end"`
+	Hash         string     `json:"hash"`
+}
+
+type MeetingVsSleepResult struct {
+	Timezone       string      `json:"timezone"`
+	SleepWindow    string      `json:"sleep_window"`
+	LocalStart     TimeResult  `json:"local_start"`
+	LocalEnd       TimeResult  `json:"local_end"`
+	Overlaps       bool        `json:"overlaps"`
+	Overlap        string      `json:"overlap"`
+	OverlapMinutes float64     `json:"overlap_minutes"`
+	SleepPeriods   []EventSpan `json:"sleep_periods,omitempty"`
+}
+
+type MaintenanceRegion struct {
+	Timezone string `json:"timezone"`
+	Window   string `json:"window"` // local HH:MM-HH:MM, may wrap midnight
+}
+
+type MaintenanceOverlapResult struct {
+	Date         string      `json:"date"`
+	Overlaps     bool        `json:"overlaps"`
+	Windows      []EventSpan `json:"windows"`
+	Total        string      `json:"total"`
+	TotalMinutes float64     `json:"total_minutes"`
+}
+
+type HireOverlap struct {
+	Timezone       string  `json:"timezone"`
+	OverlapMinutes float64 `json:"overlap_minutes"`
+}
+
+type HireCandidate struct {
+	Rank         int           `json:"rank"`
+	Timezone     string        `json:"timezone"`
+	TotalMinutes float64       `json:"total_overlap_minutes"`
+	MinMinutes   float64       `json:"min_overlap_minutes"` // with the least-overlapping member
+	Members      []HireOverlap `json:"members"`
+}
+
+type BestHireZoneResult struct {
+	Date       string          `json:"date"`
+	Window     string          `json:"window"`
+	Best       string          `json:"best"`
+	Candidates []HireCandidate `json:"candidates"`
+}
+
+type Blackout struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type AvailableSlotResult struct {
+	Timezone        string     `json:"timezone"`
+	Duration        string     `json:"duration"`
+	Start           TimeResult `json:"start"`
+	End             TimeResult `json:"end"`
+	StartsIn        string     `json:"starts_in"`
+	BlackoutsMerged int        `json:"blackouts_merged"` // busy intervals after merging overlaps
+}
+
+type PomodoroInterval struct {
+	Kind  string     `json:"kind"` // "work", "short_break" or "long_break"
+	Cycle int        `json:"cycle"`
+	Start TimeResult `json:"start"`
+	End   TimeResult `json:"end"`
+}
+
+type PomodoroPlanResult struct {
+	Intervals []PomodoroInterval `json:"intervals"`
+	Start     TimeResult         `json:"start"`
+	End       TimeResult         `json:"end"`
+	WorkTotal string             `json:"work_total"`
+	Elapsed   string             `json:"elapsed"`
+}
+
+type CronFire struct {
+	UTC           string     `json:"utc"`
+	Local         TimeResult `json:"local"`
+	Offset        string     `json:"offset"`
+	OffsetChanged bool       `json:"offset_changed"` // offset differs from the previous fire
+}
+
+type CronLocalTimesResult struct {
+	Expression string     `json:"expression"`
+	Timezone   string     `json:"timezone"`
+	From       string     `json:"from"`
+	Fires      []CronFire `json:"fires"`
+	DSTShifts  int        `json:"dst_shifts"`
+}
+
+/* ----- helpers ----- */
+
+// approx returns a rough absolute length for d, counting months as 30 days
+// and years as 365 days. It is only used to seed searches.
+func (d calendarDuration) approx() time.Duration {
+	return time.Duration(d.Years*365+d.Months*30+d.Days)*day + d.Clock
+}
+
+// times returns d applied k times, computed from the anchor each time so
+// that month clamping does not accumulate.
+func (d calendarDuration) times(k int) calendarDuration {
+	return calendarDuration{Years: d.Years * k, Months: d.Months * k, Days: d.Days * k, Clock: d.Clock * time.Duration(k)}
+}
+
+// periodIndex returns the k for which anchor+k*period <= at < anchor+(k+1)*period.
+func periodIndex(anchor, at time.Time, period calendarDuration) int {
+	k := int(at.Sub(anchor) / period.approx())
+	for period.times(k).addTo(anchor).After(at) {
+		k--
+	}
+	for !period.times(k + 1).addTo(anchor).After(at) {
+		k++
+	}
+	return k
+}
+
+// mod returns a modulo n in the range [0, n).
+func mod(a, n int) int {
+	return ((a % n) + n) % n
+}
+
+// cronSpec is a parsed five-field cron expression; each field is a bit set
+// of the values it allows.
+type cronSpec struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+// cronMacros maps the common @ shorthands to their five-field form.
+var cronMacros = map[string]string{
+	"@yearly": "0 0 1 1 *", "@annually": "0 0 1 1 *", "@monthly": "0 0 1 * *",
+	"@weekly": "0 0 * * 0", "@daily": "0 0 * * *", "@midnight": "0 0 * * *", "@hourly": "0 * * * *",
+}
+
+// maxPomodoroCycles bounds how many cycles PomodoroPlan will lay out.
+const maxPomodoroCycles = 100
+
+// maxCronFires bounds how many fires CronLocalTimes will list.
+const maxCronFires = 500
+
+// parseCronField reads one cron field of comma-separated items, each "*",
+// "n" or "a-b", optionally followed by "/step", into a bit set over [lo, hi].
+func parseCronField(f string, lo, hi int) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(f, ",") {
+		rng, step := item, 1
+		if i := strings.IndexByte(item, '/'); i >= 0 {
+			n, err := atoiStrict(item[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid cron step: %s", item)
+			}
+			rng, step = item[:i], n
+		}
+		a, b := lo, hi
+		if rng != "*" {
+			from, to, isRange := strings.Cut(rng, "-")
+			var err error
+			if a, err = atoiStrict(from); err != nil {
+				return 0, fmt.Errorf("invalid cron value: %s", item)
+			}
+			b = a
+			if isRange {
+				if b, err = atoiStrict(to); err != nil {
+					return 0, fmt.Errorf("invalid cron value: %s", item)
+				}
+			} else if step > 1 {
+				b = hi
+			}
+		}
+		if a < lo || b > hi || a > b {
+			return 0, fmt.Errorf("cron value out of range %d-%d: %s", lo, hi, item)
+		}
+		for v := a; v <= b; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// parseCron reads a standard five-field cron expression (minute hour
+// day-of-month month day-of-week) or one of cronMacros. Day of week 7 is
+// accepted as Sunday.
+func parseCron(expr string) (cronSpec, error) {
+	expr = strings.TrimSpace(expr)
+	if m, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = m
+	}
+	f := strings.Fields(expr)
+	if len(f) != 5 {
+		return cronSpec{}, fmt.Errorf("cron expression must have 5 fields: %s", expr)
+	}
+	var c cronSpec
+	var err error
+	if c.minute, err = parseCronField(f[0], 0, 59); err != nil {
+		return cronSpec{}, err
+	}
+	if c.hour, err = parseCronField(f[1], 0, 23); err != nil {
+		return cronSpec{}, err
+	}
+	if c.dom, err = parseCronField(f[2], 1, 31); err != nil {
+		return cronSpec{}, err
+	}
+	if c.month, err = parseCronField(f[3], 1, 12); err != nil {
+		return cronSpec{}, err
+	}
+	if c.dow, err = parseCronField(f[4], 0, 7); err != nil {
+		return cronSpec{}, err
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domStar, c.dowStar = f[2] == "*", f[4] == "*"
+	return c, nil
+}
+
+// dayMatches applies the cron rule that when both day-of-month and
+// day-of-week are restricted, a day matching either one fires.
+func (c cronSpec) dayMatches(tm time.Time) bool {
+	dom := c.dom&(1<<uint(tm.Day())) != 0
+	dow := c.dow&(1<<uint(tm.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// next returns the first fire strictly after after, evaluated on the wall
+// clock of after's location. It gives up after five years, which only
+// happens for impossible dates such as February 30.
+func (c cronSpec) next(after time.Time) (time.Time, bool) {
+	loc := after.Location()
+	tm := after.Truncate(time.Minute).Add(time.Minute)
+	limit := tm.AddDate(5, 0, 0)
+	for tm.Before(limit) {
+		switch {
+		case c.month&(1<<uint(tm.Month())) == 0:
+			tm = time.Date(tm.Year(), tm.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.dayMatches(tm):
+			tm = time.Date(tm.Year(), tm.Month(), tm.Day()+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<uint(tm.Hour())) == 0:
+			tm = time.Date(tm.Year(), tm.Month(), tm.Day(), tm.Hour()+1, 0, 0, 0, loc)
+		case c.minute&(1<<uint(tm.Minute())) == 0:
+			tm = tm.Add(time.Minute)
+		default:
+			return tm, true
+		}
+	}
+	return time.Time{}, false
+}
+
+/* ----- core methods ----- */
+
+// OncallWho reports who is on call at queryTime for a rotation that hands
+// off every period starting at start. Days and longer units advance with
+// AddDate, so handoffs stay at the same local wall-clock time across DST.
+func (t *TimeServer) OncallWho(start, period string, people []string, tz, queryTime string) (OncallResult, error) {
+	if len(people) == 0 {
+		return OncallResult{}, fmt.Errorf("people must not be empty")
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return OncallResult{}, err
+	}
+	p, _, err := parseDurationSpec(period)
+	if err != nil {
+		return OncallResult{}, err
+	}
+	if p.approx() <= 0 {
+		return OncallResult{}, fmt.Errorf("period must be positive")
+	}
+	anchor, err := t.parseDateTime(start, loc)
+	if err != nil {
+		return OncallResult{}, err
+	}
+	at, err := t.parseDateTime(queryTime, loc)
+	if err != nil {
+		return OncallResult{}, err
+	}
+
+	k := periodIndex(anchor, at, p)
+	idx := mod(k, len(people))
+	return OncallResult{
+		OnCall:         people[idx],
+		Index:          idx,
+		Rotation:       k,
+		ShiftStart:     makeTimeResult(tz, p.times(k).addTo(anchor)),
+		ShiftEnd:       makeTimeResult(tz, p.times(k+1).addTo(anchor)),
+		NextOnCall:     people[mod(k+1, len(people))],
+		PreviousOnCall: people[mod(k-1, len(people))],
+	}, nil
+}
+
+// eventSpan resolves an event's start and end in loc.
+func (t *TimeServer) eventSpan(e EventSpec, loc *time.Location) (time.Time, time.Time, error) {
+	start, err := t.parseDateTime(e.Start, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	d, _, err := parseDurationSpec(e.Duration)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end := d.addTo(start)
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("duration must not be negative: %s", e.Duration)
+	}
+	return start, end, nil
+}
+
+// EventsOverlap reports whether two start+duration events collide, and
+// either how long they overlap or the gap between them.
+func (t *TimeServer) EventsOverlap(a, b EventSpec, tz, format string) (EventsOverlapResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return EventsOverlapResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return EventsOverlapResult{}, err
+	}
+	aStart, aEnd, err := t.eventSpan(a, loc)
+	if err != nil {
+		return EventsOverlapResult{}, fmt.Errorf("first event: %w", err)
+	}
+	bStart, bEnd, err := t.eventSpan(b, loc)
+	if err != nil {
+		return EventsOverlapResult{}, fmt.Errorf("second event: %w", err)
+	}
+
+	res := EventsOverlapResult{
+		First:          EventSpan{Start: makeTimeResult(tz, aStart), End: makeTimeResult(tz, aEnd)},
+		Second:         EventSpan{Start: makeTimeResult(tz, bStart), End: makeTimeResult(tz, bEnd)},
+		BoundaryPolicy: eventsBoundaryPolicy,
+	}
+	lo, hi := aStart, aEnd
+	if bStart.After(lo) {
+		lo = bStart
+	}
+	if bEnd.Before(hi) {
+		hi = bEnd
+	}
+	switch {
+	case lo.Before(hi):
+		res.Overlaps = true
+	case aStart.Equal(aEnd) || bStart.Equal(bEnd):
+		// A point event overlaps when it lies inside [start, end) of the
+		// other event, or when both are points at the same instant.
+		res.Overlaps = (!aStart.Before(bStart) && aStart.Before(bEnd)) ||
+			(!bStart.Before(aStart) && bStart.Before(aEnd)) ||
+			aStart.Equal(bStart)
+	}
+	if res.Overlaps {
+		ov := hi.Sub(lo)
+		if ov < 0 {
+			ov = 0
+		}
+		res.Overlap = render(ov)
+		res.OverlapSeconds = ov.Seconds()
+		return res, nil
+	}
+	gap := lo.Sub(hi)
+	res.Gap = render(gap)
+	res.GapSeconds = gap.Seconds()
+	return res, nil
+}
+
+// RandomTime picks a uniformly random instant in [start, end). The span is
+// handled as a big integer of nanoseconds so ranges longer than
+// time.Duration's ~292 years do not overflow. A seed makes the pick
+// reproducible; without one the pick comes from crypto/rand.
+func (t *TimeServer) RandomTime(start, end, tz string, seed *int64) (RandomTimeResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return RandomTimeResult{}, err
+	}
+	from, err := t.parseDateTime(start, loc)
+	if err != nil {
+		return RandomTimeResult{}, err
+	}
+	until, err := t.parseDateTime(end, loc)
+	if err != nil {
+		return RandomTimeResult{}, err
+	}
+	if !from.Before(until) {
+		return RandomTimeResult{}, fmt.Errorf("start must be before end")
+	}
+
+	billion := big.NewInt(1e9)
+	span := new(big.Int).Mul(big.NewInt(until.Unix()-from.Unix()), billion)
+	span.Add(span, big.NewInt(int64(until.Nanosecond()-from.Nanosecond())))
+
+	var offset *big.Int
+	if seed != nil {
+		offset = new(big.Int).Rand(rand.New(rand.NewSource(*seed)), span)
+	} else if offset, err = crand.Int(crand.Reader, span); err != nil {
+		return RandomTimeResult{}, err
+	}
+	sec, nsec := new(big.Int).QuoRem(offset, billion, new(big.Int))
+	picked := time.Unix(from.Unix()+sec.Int64(), int64(from.Nanosecond())+nsec.Int64()).In(loc)
+
+	offSeconds, _ := new(big.Float).Quo(new(big.Float).SetInt(offset), big.NewFloat(1e9)).Float64()
+	return RandomTimeResult{
+		Start:         makeTimeResult(tz, from),
+		End:           makeTimeResult(tz, until),
+		Result:        makeTimeResult(tz, picked),
+		OffsetSeconds: offSeconds,
+		Seed:          seed,
+	}, nil
+}
+
+// BestSendTime returns the first instant at or after from that falls inside
+// the local daily window in tz: from itself if the window is open, else the
+// next window start. Windows are rebuilt per local date, so 08:00 stays
+// 08:00 local across DST changes, and may wrap past midnight.
+func (t *TimeServer) BestSendTime(tz, window, from string) (BestSendResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return BestSendResult{}, err
+	}
+	w, err := parseClockWindow(window, true)
+	if err != nil {
+		return BestSendResult{}, err
+	}
+	at, err := t.parseDateTime(from, loc)
+	if err != nil {
+		return BestSendResult{}, err
+	}
+
+	res := BestSendResult{Timezone: tz, Window: window}
+	// Start a day early so a wrapping window opened yesterday is seen.
+	for d := startOfDay(at).AddDate(0, 0, -1); ; d = d.AddDate(0, 0, 1) {
+		opens, closes := w.on(d)
+		if !closes.After(at) {
+			continue
+		}
+		send := opens
+		if !opens.After(at) {
+			send, res.ActiveNow = at, true
+		}
+		res.SendAtUTC = send.UTC().Format(time.RFC3339)
+		res.SendAtLocal = makeTimeResult(tz, send)
+		res.WindowEnd = makeTimeResult(tz, closes)
+		return res, nil
+	}
+}
+
+// InMaintenance reports whether now falls in a weekly maintenance window
+// opening at start local time on weekday and lasting duration, returning
+// the window in progress or else the next one. Each window opens at its
+// wall-clock start in tz, so 02:00 stays 02:00 across DST; the duration is
+// elapsed time and may carry the window past midnight. Windows may not be
+// longer than the week they recur in.
+func (t *TimeServer) InMaintenance(weekday, start, duration, tz, format string) (InMaintenanceResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return InMaintenanceResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return InMaintenanceResult{}, err
+	}
+	wd, err := parseWeekday(weekday)
+	if err != nil {
+		return InMaintenanceResult{}, err
+	}
+	c, err := parseClock(start)
+	if err != nil {
+		return InMaintenanceResult{}, err
+	}
+	length, err := fixedSpec("duration", duration)
+	if err != nil {
+		return InMaintenanceResult{}, err
+	}
+	if length > 7*day {
+		return InMaintenanceResult{}, fmt.Errorf("duration must be at most 7 days: %s", duration)
+	}
+	now := t.nowFunc().In(loc)
+
+	res := InMaintenanceResult{
+		Timezone: tz,
+		Schedule: fmt.Sprintf("%s %s for %s", wd, formatClock(c), length),
+		Now:      makeTimeResult(tz, now),
+	}
+	// Start a week early so a long window opened last week is seen.
+	for d := startOfDay(now).AddDate(0, 0, -7); ; d = d.AddDate(0, 0, 1) {
+		if d.Weekday() != wd {
+			continue
+		}
+		opens := atClock(d, c)
+		closes := opens.Add(length)
+		if !closes.After(now) {
+			continue
+		}
+		res.WindowStart = makeTimeResult(tz, opens)
+		res.WindowEnd = makeTimeResult(tz, closes)
+		if opens.After(now) {
+			res.StartsIn = render(opens.Sub(now))
+		} else {
+			res.Active = true
+			res.Remaining = render(closes.Sub(now))
+		}
+		return res, nil
+	}
+}
+
+// AssignSlot deterministically maps key to one of slots equal slots laid
+// out from the start of the daily window on date. The key is hashed with
+// 64-bit FNV-1a, which is fixed by its spec, so assignments are stable
+// across runs and builds. Slot times are wall-clock offsets from the window
+// start. With slots <= 0 the window is filled with as many slots as fit.
+func (t *TimeServer) AssignSlot(key string, slots int, duration, window, date, tz, format string) (AssignSlotResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return AssignSlotResult{}, err
+	}
+	if key == "" {
+		return AssignSlotResult{}, fmt.Errorf("key must not be empty")
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return AssignSlotResult{}, err
+	}
+	w, err := parseClockWindow(window, true)
+	if err != nil {
+		return AssignSlotResult{}, err
+	}
+	cd, _, err := parseDurationSpec(duration)
+	if err != nil {
+		return AssignSlotResult{}, err
+	}
+	size, err := cd.fixed()
+	if err != nil {
+		return AssignSlotResult{}, err
+	}
+	if size <= 0 {
+		return AssignSlotResult{}, fmt.Errorf("slot duration must be positive")
+	}
+	span := w.End - w.Start
+	if span < 0 {
+		span += day
+	}
+	if slots <= 0 {
+		slots = int(span / size)
+	}
+	if slots == 0 || time.Duration(slots)*size > span {
+		return AssignSlotResult{}, fmt.Errorf("%d slots of %s do not fit in window %s", slots, size, window)
+	}
+	d, err := t.parseDateTime(date, loc)
+	if err != nil {
+		return AssignSlotResult{}, err
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	sum := h.Sum64()
+	idx := int(sum % uint64(slots))
+	from := atClock(d, w.Start+time.Duration(idx)*size)
+	until := atClock(d, w.Start+time.Duration(idx+1)*size)
+	return AssignSlotResult{
+		Key:          key,
+		Slot:         idx,
+		Slots:        slots,
+		SlotDuration: render(size),
+		Window:       window,
+		Start:        makeTimeResult(tz, from),
+		End:          makeTimeResult(tz, until),
+		Hash:         fmt.Sprintf("%016x", sum),
+	}, nil
+}
+
+// MeetingVsSleep checks a meeting against a participant's nightly sleep
+// window on their local wall clock. The window usually wraps midnight, so
+// each night is built from the evening's date, starting with the night
+// before the meeting's local date. The instant is a UTC time: one given
+// without an offset is read as UTC, not in the participant's zone.
+func (t *TimeServer) MeetingVsSleep(tz, sleep, instant, duration, format string) (MeetingVsSleepResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return MeetingVsSleepResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return MeetingVsSleepResult{}, err
+	}
+	w, err := parseClockWindow(sleep, true)
+	if err != nil {
+		return MeetingVsSleepResult{}, err
+	}
+	from, until, err := t.eventSpan(EventSpec{Start: instant, Duration: duration}, time.UTC)
+	if err != nil {
+		return MeetingVsSleepResult{}, err
+	}
+	from, until = from.In(loc), until.In(loc)
+
+	res := MeetingVsSleepResult{Timezone: tz, SleepWindow: sleep, LocalStart: makeTimeResult(tz, from), LocalEnd: makeTimeResult(tz, until)}
+	var total time.Duration
+	for d := startOfDay(from).AddDate(0, 0, -1); !d.After(until); d = d.AddDate(0, 0, 1) {
+		opens, closes := w.on(d)
+		lo, hi := opens, closes
+		if from.After(lo) {
+			lo = from
+		}
+		if until.Before(hi) {
+			hi = until
+		}
+		if hi.After(lo) {
+			total += hi.Sub(lo)
+			res.SleepPeriods = append(res.SleepPeriods, EventSpan{Start: makeTimeResult(tz, opens), End: makeTimeResult(tz, closes)})
+		}
+	}
+	res.Overlaps = total > 0
+	res.Overlap = render(total)
+	res.OverlapMinutes = total.Minutes()
+	return res, nil
+}
+
+// timeSpan is a half-open interval [from, to) of absolute time.
+type timeSpan struct{ from, to time.Time }
+
+// intersectSpans returns the overlap of two sorted, disjoint span lists.
+func intersectSpans(a, b []timeSpan) []timeSpan {
+	var out []timeSpan
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		lo, hi := a[i].from, a[i].to
+		if b[j].from.After(lo) {
+			lo = b[j].from
+		}
+		if b[j].to.Before(hi) {
+			hi = b[j].to
+		}
+		if hi.After(lo) {
+			out = append(out, timeSpan{lo, hi})
+		}
+		if a[i].to.Before(b[j].to) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return out
+}
+
+// windowSpans lists the occurrences in loc of the daily window w that can
+// touch the day starting at frame, merging any that abut. Callers intersect
+// the result with the frame to clip it.
+func windowSpans(w clockWindow, loc *time.Location, frame time.Time) []timeSpan {
+	var out []timeSpan
+	for ld := startOfDay(frame.In(loc)).AddDate(0, 0, -1); ld.Before(frame.Add(day)); ld = ld.AddDate(0, 0, 1) {
+		opens, closes := w.on(ld)
+		if n := len(out); n > 0 && !opens.After(out[n-1].to) {
+			out[n-1].to = closes
+			continue
+		}
+		out = append(out, timeSpan{opens, closes})
+	}
+	return out
+}
+
+// MaintenanceOverlap finds the UTC intervals on date during which every
+// region's local maintenance window is open at once. The UTC day is the
+// frame: each region contributes the windows opening on the local dates
+// around it, clipped to that day, so a wrapping window such as 23:00-02:00
+// counts on both sides of midnight. No common window is not an error.
+func (t *TimeServer) MaintenanceOverlap(regions []MaintenanceRegion, date, format string) (MaintenanceOverlapResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return MaintenanceOverlapResult{}, err
+	}
+	if len(regions) == 0 {
+		return MaintenanceOverlapResult{}, fmt.Errorf("regions must not be empty")
+	}
+	d, err := t.parseDateTime(date, time.UTC)
+	if err != nil {
+		return MaintenanceOverlapResult{}, err
+	}
+	frame := startOfDay(d.UTC())
+	common := []timeSpan{{frame, frame.Add(day)}}
+	for i, rg := range regions {
+		_, loc, err := t.location(rg.Timezone)
+		if err != nil {
+			return MaintenanceOverlapResult{}, fmt.Errorf("region %d: %w", i+1, err)
+		}
+		w, err := parseClockWindow(rg.Window, true)
+		if err != nil {
+			return MaintenanceOverlapResult{}, fmt.Errorf("region %d: %w", i+1, err)
+		}
+		common = intersectSpans(common, windowSpans(w, loc, frame))
+	}
+
+	res := MaintenanceOverlapResult{Date: frame.Format("2006-01-02"), Windows: []EventSpan{}}
+	var total time.Duration
+	for _, sp := range common {
+		total += sp.to.Sub(sp.from)
+		res.Windows = append(res.Windows, EventSpan{Start: makeTimeResult("UTC", sp.from.UTC()), End: makeTimeResult("UTC", sp.to.UTC())})
+	}
+	res.Overlaps = total > 0
+	res.Total = render(total)
+	res.TotalMinutes = total.Minutes()
+	return res, nil
+}
+
+// defaultHireZones are the candidates BestHireZone ranks when none are
+// given: one major city per commonly used offset.
+var defaultHireZones = []string{
+	"Pacific/Honolulu", "America/Anchorage", "America/Los_Angeles", "America/Denver",
+	"America/Chicago", "America/New_York", "America/Sao_Paulo", "Atlantic/Azores",
+	"Europe/London", "Europe/Berlin", "Europe/Athens", "Europe/Moscow", "Asia/Dubai",
+	"Asia/Karachi", "Asia/Kolkata", "Asia/Dhaka", "Asia/Bangkok", "Asia/Singapore",
+	"Asia/Tokyo", "Australia/Sydney", "Pacific/Auckland",
+}
+
+// BestHireZone ranks candidate timezones for a new team member by how many
+// minutes of their working window overlap each existing member's on date,
+// summed over the team. Everyone works the same local window; the windows
+// are intersected per pair within the UTC day, as in MaintenanceOverlap.
+// Ties go to the candidate whose worst single overlap is larger.
+func (t *TimeServer) BestHireZone(team []string, window string, candidates []string, date string) (BestHireZoneResult, error) {
+	if len(team) == 0 {
+		return BestHireZoneResult{}, fmt.Errorf("team must not be empty")
+	}
+	if len(candidates) == 0 {
+		candidates = defaultHireZones
+	}
+	w, err := parseClockWindow(window, true)
+	if err != nil {
+		return BestHireZoneResult{}, err
+	}
+	d, err := t.parseDateTime(date, time.UTC)
+	if err != nil {
+		return BestHireZoneResult{}, err
+	}
+	frame := startOfDay(d.UTC())
+	day0 := []timeSpan{{frame, frame.Add(day)}}
+
+	spansOf := func(z string) (string, []timeSpan, error) {
+		name, loc, err := t.location(strings.TrimSpace(z))
+		if err != nil {
+			return "", nil, err
+		}
+		return name, intersectSpans(day0, windowSpans(w, loc, frame)), nil
+	}
+	names := make([]string, len(team))
+	spans := make([][]timeSpan, len(team))
+	for i, z := range team {
+		if names[i], spans[i], err = spansOf(z); err != nil {
+			return BestHireZoneResult{}, err
+		}
+	}
+
+	res := BestHireZoneResult{Date: frame.Format("2006-01-02"), Window: window}
+	for _, z := range candidates {
+		name, cand, err := spansOf(z)
+		if err != nil {
+			return BestHireZoneResult{}, err
+		}
+		hc := HireCandidate{Timezone: name, MinMinutes: math.Inf(1)}
+		for i, member := range spans {
+			var ov time.Duration
+			for _, sp := range intersectSpans(cand, member) {
+				ov += sp.to.Sub(sp.from)
+			}
+			hc.Members = append(hc.Members, HireOverlap{Timezone: names[i], OverlapMinutes: ov.Minutes()})
+			hc.TotalMinutes += ov.Minutes()
+			hc.MinMinutes = math.Min(hc.MinMinutes, ov.Minutes())
+		}
+		res.Candidates = append(res.Candidates, hc)
+	}
+	sort.SliceStable(res.Candidates, func(i, j int) bool {
+		a, b := res.Candidates[i], res.Candidates[j]
+		if a.TotalMinutes != b.TotalMinutes {
+			return a.TotalMinutes > b.TotalMinutes
+		}
+		return a.MinMinutes > b.MinMinutes
+	})
+	for i := range res.Candidates {
+		res.Candidates[i].Rank = i + 1
+	}
+	res.Best = res.Candidates[0].Timezone
+	return res, nil
+}
+
+// NextAvailableSlot finds the earliest slot of duration, starting at or
+// after the injected now (rounded up to the minute), that lies inside one
+// business day's window in tz and clear of every blackout. Blackouts are
+// half-open [start, end) and are merged first, so overlapping bookings act
+// as one. A slot never straddles two days: if the rest of a day's window is
+// too short, the search moves to the next business day.
+func (t *TimeServer) NextAvailableSlot(duration, tz, window string, blackouts []Blackout, holidays []string, format string) (AvailableSlotResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return AvailableSlotResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return AvailableSlotResult{}, err
+	}
+	size, err := fixedSpec("duration", duration)
+	if err != nil {
+		return AvailableSlotResult{}, err
+	}
+	w, err := parseClockWindow(window, false)
+	if err != nil {
+		return AvailableSlotResult{}, err
+	}
+	if size > w.End-w.Start {
+		return AvailableSlotResult{}, fmt.Errorf("duration %s does not fit in window %s", size, window)
+	}
+	hs, err := parseHolidays(holidays)
+	if err != nil {
+		return AvailableSlotResult{}, err
+	}
+	busy := make([]timeSpan, 0, len(blackouts))
+	for i, b := range blackouts {
+		from, err := t.parseDateTime(b.Start, loc)
+		if err != nil {
+			return AvailableSlotResult{}, fmt.Errorf("blackout %d: %w", i+1, err)
+		}
+		until, err := t.parseDateTime(b.End, loc)
+		if err != nil {
+			return AvailableSlotResult{}, fmt.Errorf("blackout %d: %w", i+1, err)
+		}
+		if until.Before(from) {
+			return AvailableSlotResult{}, fmt.Errorf("blackout %d: end must not be before start", i+1)
+		}
+		busy = append(busy, timeSpan{from, until})
+	}
+	sort.Slice(busy, func(i, j int) bool { return busy[i].from.Before(busy[j].from) })
+	merged := busy[:0]
+	for _, b := range busy {
+		if n := len(merged); n > 0 && !b.from.After(merged[n-1].to) {
+			if b.to.After(merged[n-1].to) {
+				merged[n-1].to = b.to
+			}
+			continue
+		}
+		merged = append(merged, b)
+	}
+
+	now := t.nowFunc().In(loc)
+	if m := now.Truncate(time.Minute); m.Before(now) {
+		now = m.Add(time.Minute)
+	}
+	d := startOfDay(now)
+	for i := 0; i < maxBusinessScanDays; i, d = i+1, d.AddDate(0, 0, 1) {
+		if !isBusinessDay(d, hs) {
+			continue
+		}
+		opens, closes := w.on(d)
+		cursor := opens
+		if now.After(cursor) {
+			cursor = now
+		}
+		for _, b := range merged {
+			if !b.to.After(cursor) {
+				continue
+			}
+			if !b.from.Before(closes) || b.from.Sub(cursor) >= size {
+				break
+			}
+			cursor = b.to
+		}
+		if closes.Sub(cursor) >= size {
+			return AvailableSlotResult{
+				Timezone:        tz,
+				Duration:        render(size),
+				Start:           makeTimeResult(tz, cursor),
+				End:             makeTimeResult(tz, cursor.Add(size)),
+				StartsIn:        render(cursor.Sub(now)),
+				BlackoutsMerged: len(merged),
+			}, nil
+		}
+	}
+	return AvailableSlotResult{}, fmt.Errorf("no free slot within %d days", maxBusinessScanDays)
+}
+
+// fixedSpec parses a duration spec that must have a fixed, positive length.
+func fixedSpec(name, s string) (time.Duration, error) {
+	cd, _, err := parseDurationSpec(s)
+	if err != nil {
+		return 0, err
+	}
+	d, err := cd.fixed()
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%s must be positive: %s", name, s)
+	}
+	return d, nil
+}
+
+// PomodoroPlan lays out cycles work intervals from start, separated by short
+// breaks, with a long break in place of the short one after every
+// longEvery-th cycle. No break follows the final cycle. Intervals are
+// elapsed time, so a plan crossing a DST change keeps its real lengths.
+func (t *TimeServer) PomodoroPlan(start string, cycles int, work, shortBreak, longBreak string, longEvery int, tz, format string) (PomodoroPlanResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return PomodoroPlanResult{}, err
+	}
+	if cycles < 1 || cycles > maxPomodoroCycles {
+		return PomodoroPlanResult{}, fmt.Errorf("cycles must be between 1 and %d", maxPomodoroCycles)
+	}
+	if longEvery < 1 {
+		return PomodoroPlanResult{}, fmt.Errorf("long_break_every must be at least 1")
+	}
+	w, err := fixedSpec("work", work)
+	if err != nil {
+		return PomodoroPlanResult{}, err
+	}
+	sb, err := fixedSpec("short_break", shortBreak)
+	if err != nil {
+		return PomodoroPlanResult{}, err
+	}
+	lb, err := fixedSpec("long_break", longBreak)
+	if err != nil {
+		return PomodoroPlanResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return PomodoroPlanResult{}, err
+	}
+	begin, err := t.parseDateTime(start, loc)
+	if err != nil {
+		return PomodoroPlanResult{}, err
+	}
+
+	res := PomodoroPlanResult{Start: makeTimeResult(tz, begin)}
+	cur := begin
+	add := func(kind string, cycle int, d time.Duration) {
+		res.Intervals = append(res.Intervals, PomodoroInterval{Kind: kind, Cycle: cycle, Start: makeTimeResult(tz, cur), End: makeTimeResult(tz, cur.Add(d))})
+		cur = cur.Add(d)
+	}
+	for c := 1; c <= cycles; c++ {
+		add("work", c, w)
+		switch {
+		case c == cycles:
+		case c%longEvery == 0:
+			add("long_break", c, lb)
+		default:
+			add("short_break", c, sb)
+		}
+	}
+	res.End = makeTimeResult(tz, cur)
+	res.WorkTotal = render(w * time.Duration(cycles))
+	res.Elapsed = render(cur.Sub(begin))
+	return res, nil
+}
+
+// CronLocalTimes evaluates a cron expression in UTC and renders the next
+// count fires after now in tz. Fires whose local UTC offset differs from the
+// previous fire are flagged: those are the days DST moves the apparent local
+// time of a fixed UTC schedule.
+func (t *TimeServer) CronLocalTimes(expr, tz string, count int) (CronLocalTimesResult, error) {
+	if count <= 0 {
+		count = 5
+	}
+	if count > maxCronFires {
+		return CronLocalTimesResult{}, fmt.Errorf("count must be at most %d", maxCronFires)
+	}
+	c, err := parseCron(expr)
+	if err != nil {
+		return CronLocalTimesResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return CronLocalTimesResult{}, err
+	}
+
+	now := t.nowFunc().UTC()
+	res := CronLocalTimesResult{Expression: strings.TrimSpace(expr), Timezone: tz, From: now.Format(time.RFC3339)}
+	prevOffset := 0
+	for at := now; len(res.Fires) < count; {
+		next, ok := c.next(at)
+		if !ok {
+			if len(res.Fires) == 0 {
+				return CronLocalTimesResult{}, fmt.Errorf("cron expression never fires: %s", expr)
+			}
+			break
+		}
+		at = next
+		local := at.In(loc)
+		_, off := local.Zone()
+		f := CronFire{
+			UTC:           at.Format(time.RFC3339),
+			Local:         makeTimeResult(tz, local),
+			Offset:        local.Format("-07:00"),
+			OffsetChanged: len(res.Fires) > 0 && off != prevOffset,
+		}
+		if f.OffsetChanged {
+			res.DSTShifts++
+		}
+		prevOffset = off
+		res.Fires = append(res.Fires, f)
+	}
+	return res, nil
+}
+
+/* ----- tools ----- */
+
+func registerScheduleTools(s *server.MCPServer, ts *TimeServer) {
+	oncall := mcp.NewTool(
+		"oncall_who",
+		mcp.WithDescription("Who is on call at a given time for a fixed-period rotation, with the surrounding handoff times."),
+		mcp.WithString("start", mcp.Required(), mcp.Description("First handoff of the rotation.")),
+		mcp.WithString("period", mcp.Required(), mcp.Description("Rotation length, e.g. 1w, 2d, P1W.")),
+		mcp.WithArray("people", mcp.Required(), mcp.Items(map[string]any{"type": "string"})),
+		mcp.WithString("timezone"),
+		mcp.WithString("query_time", mcp.Description("Instant to look up. Defaults to now.")),
+	)
+
+	s.AddTool(oncall, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start, err := r.RequireString("start")
+		if err != nil {
+			return errorResult(err)
+		}
+		period, err := r.RequireString("period")
+		if err != nil {
+			return errorResult(err)
+		}
+		people, err := r.RequireStringSlice("people")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.OncallWho(start, period, people, r.GetString("timezone", ""), r.GetString("query_time", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	eventProps := map[string]any{
+		"start":    map[string]any{"type": "string"},
+		"duration": map[string]any{"type": "string", "description": "e.g. 90m, PT1H, an hour"},
+	}
+	overlap := mcp.NewTool(
+		"events_overlap",
+		mcp.WithDescription("Check whether two start+duration events collide, returning the overlap or the gap. Back-to-back events do not overlap."),
+		mcp.WithObject("first", mcp.Required(), mcp.Properties(eventProps)),
+		mcp.WithObject("second", mcp.Required(), mcp.Properties(eventProps)),
+		mcp.WithString("timezone"),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(overlap, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			First    *EventSpec `json:"first"`
+			Second   *EventSpec `json:"second"`
+			Timezone string     `json:"timezone"`
+		}
+		if err := r.BindArguments(&args); err != nil {
+			return errorResult(err)
+		}
+		if args.First == nil || args.Second == nil {
+			return errorResult(fmt.Errorf("both first and second events are required"))
+		}
+		res, err := ts.EventsOverlap(*args.First, *args.Second, args.Timezone, r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	random := mcp.NewTool(
+		"random_time",
+		mcp.WithDescription("Pick a uniformly random instant in [start, end), e.g. to jitter scheduled jobs. Pass a seed for a reproducible pick."),
+		mcp.WithString("start", mcp.Required()),
+		mcp.WithString("end", mcp.Required()),
+		mcp.WithString("timezone"),
+		mcp.WithNumber("seed", mcp.Description("Optional integer seed.")),
+	)
+
+	s.AddTool(random, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			Start    string `json:"start"`
+			End      string `json:"end"`
+			Timezone string `json:"timezone"`
+			Seed     *int64 `json:"seed"`
+		}
+		if err := r.BindArguments(&args); err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.RandomTime(args.Start, args.End, args.Timezone, args.Seed)
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	bestSend := mcp.NewTool(
+		"best_send_time",
+		mcp.WithDescription("The next instant that lands inside a local send window in the recipient's timezone, e.g. 08:00-10:00. Returns now if the window is currently open."),
+		mcp.WithString("timezone", mcp.Required()),
+		mcp.WithString("window", mcp.Description("Local window HH:MM-HH:MM (default 08:00-10:00).")),
+		mcp.WithString("from", mcp.Description("Reference instant. Defaults to now.")),
+	)
+
+	s.AddTool(bestSend, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		tz, err := r.RequireString("timezone")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.BestSendTime(tz, r.GetString("window", "08:00-10:00"), r.GetString("from", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	slot := mcp.NewTool(
+		"assign_slot",
+		mcp.WithDescription("Deterministically assign a key (e.g. a tenant ID) to a time slot inside a daily window, spreading scheduled jobs without collisions. The same key always gets the same slot."),
+		mcp.WithString("key", mcp.Required()),
+		mcp.WithString("duration", mcp.Required(), mcp.Description("Slot length, e.g. 5m or PT15M.")),
+		mcp.WithNumber("slots", mcp.Description("Number of slots. Defaults to as many as fit in the window.")),
+		mcp.WithString("window", mcp.Description("Local window HH:MM-HH:MM (default 00:00-24:00).")),
+		mcp.WithString("date", mcp.Description("Local date. Defaults to today.")),
+		mcp.WithString("timezone"),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(slot, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		key, err := r.RequireString("key")
+		if err != nil {
+			return errorResult(err)
+		}
+		duration, err := r.RequireString("duration")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.AssignSlot(key, r.GetInt("slots", 0), duration, r.GetString("window", "00:00-24:00"),
+			r.GetString("date", ""), r.GetString("timezone", ""), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	sleep := mcp.NewTool(
+		"meeting_vs_sleep",
+		mcp.WithDescription("Whether a proposed meeting overlaps a participant's local sleep window (default 23:00-07:00) and by how much, with the local meeting time."),
+		mcp.WithString("timezone", mcp.Required(), mcp.Description("Participant's timezone.")),
+		mcp.WithString("instant", mcp.Required(), mcp.Description("Meeting start in UTC, e.g. 2025-05-14T05:00:00Z; a time without an offset is read as UTC.")),
+		mcp.WithString("duration", mcp.Required(), mcp.Description("e.g. 1h, PT30M")),
+		mcp.WithString("sleep_window", mcp.Description("Local sleep window HH:MM-HH:MM (default 23:00-07:00).")),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(sleep, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		tz, err := r.RequireString("timezone")
+		if err != nil {
+			return errorResult(err)
+		}
+		instant, err := r.RequireString("instant")
+		if err != nil {
+			return errorResult(err)
+		}
+		duration, err := r.RequireString("duration")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.MeetingVsSleep(tz, r.GetString("sleep_window", "23:00-07:00"), instant, duration, r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	cronLocal := mcp.NewTool(
+		"cron_local_times",
+		mcp.WithDescription("Next fires of a cron expression interpreted in UTC, rendered in a local timezone, flagging fires where DST shifts the local time."),
+		mcp.WithString("expression", mcp.Required(), mcp.Description("Five-field cron (minute hour day-of-month month day-of-week) or @daily, @hourly, ...")),
+		mcp.WithString("timezone"),
+		mcp.WithNumber("count", mcp.Description("Number of fires to list (default 5).")),
+	)
+
+	s.AddTool(cronLocal, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		expr, err := r.RequireString("expression")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.CronLocalTimes(expr, r.GetString("timezone", ""), r.GetInt("count", 5))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	pomodoro := mcp.NewTool(
+		"pomodoro_plan",
+		mcp.WithDescription("Pomodoro schedule: alternating work and break intervals from a start time, with a long break every N cycles."),
+		mcp.WithString("start", mcp.Description("Defaults to now.")),
+		mcp.WithNumber("cycles", mcp.Description("Work intervals to plan (default 4).")),
+		mcp.WithString("work", mcp.Description("Work interval length (default 25m).")),
+		mcp.WithString("short_break", mcp.Description("Default 5m.")),
+		mcp.WithString("long_break", mcp.Description("Default 15m.")),
+		mcp.WithNumber("long_break_every", mcp.Description("Cycles between long breaks (default 4).")),
+		mcp.WithString("timezone"),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(pomodoro, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := ts.PomodoroPlan(r.GetString("start", ""), r.GetInt("cycles", 4), r.GetString("work", "25m"),
+			r.GetString("short_break", "5m"), r.GetString("long_break", "15m"), r.GetInt("long_break_every", 4), r.GetString("timezone", ""), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	maintenance := mcp.NewTool(
+		"maintenance_overlap",
+		mcp.WithDescription("UTC intervals on a date during which every region's local maintenance window (e.g. 01:00-04:00, may wrap midnight) is open at once. An empty list means no common window."),
+		mcp.WithArray("regions", mcp.Required(), mcp.Items(map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"timezone": map[string]any{"type": "string"},
+				"window":   map[string]any{"type": "string", "description": "Local window HH:MM-HH:MM."},
+			},
+			"required": []string{"timezone", "window"},
+		})),
+		mcp.WithString("date", mcp.Description("UTC date YYYY-MM-DD. Defaults to today.")),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(maintenance, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			Regions []MaintenanceRegion `json:"regions"`
+			Date    string              `json:"date"`
+		}
+		if err := r.BindArguments(&args); err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.MaintenanceOverlap(args.Regions, args.Date, r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	maint := mcp.NewTool(
+		"in_maintenance",
+		mcp.WithDescription("Whether now falls in a weekly maintenance window (weekday, local start, duration), with the window in progress or the next one. Windows may run past midnight and keep their wall-clock start across DST."),
+		mcp.WithString("weekday", mcp.Required(), mcp.Description("Day the window opens, e.g. Sunday.")),
+		mcp.WithString("start", mcp.Required(), mcp.Description("Local opening time HH:MM.")),
+		mcp.WithString("duration", mcp.Required(), mcp.Description("Window length, e.g. 4h or PT90M.")),
+		mcp.WithString("timezone"),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(maint, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		weekday, err := r.RequireString("weekday")
+		if err != nil {
+			return errorResult(err)
+		}
+		start, err := r.RequireString("start")
+		if err != nil {
+			return errorResult(err)
+		}
+		duration, err := r.RequireString("duration")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.InMaintenance(weekday, start, duration, r.GetString("timezone", ""), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	hire := mcp.NewTool(
+		"best_hire_zone",
+		mcp.WithDescription("Rank candidate timezones for a new team member by total working-hours overlap with the existing team on a date. Uses a default list of major zones when no candidates are given."),
+		mcp.WithArray("team", mcp.Required(), mcp.Items(map[string]any{"type": "string"}), mcp.Description("Existing members' timezones.")),
+		mcp.WithString("window", mcp.Description("Local working window HH:MM-HH:MM (default 09:00-17:00).")),
+		mcp.WithArray("candidates", mcp.Items(map[string]any{"type": "string"}), mcp.Description("Timezones to rank.")),
+		mcp.WithString("date", mcp.Description("UTC date YYYY-MM-DD. Defaults to today.")),
+	)
+
+	s.AddTool(hire, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		team, err := r.RequireStringSlice("team")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.BestHireZone(team, r.GetString("window", defaultBusinessWindow), r.GetStringSlice("candidates", nil), r.GetString("date", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	freeSlot := mcp.NewTool(
+		"next_available_slot",
+		mcp.WithDescription("Earliest future slot of a given length inside business hours that avoids a list of blackout intervals (existing bookings). Overlapping blackouts are merged; slots never straddle two days."),
+		mcp.WithString("duration", mcp.Required(), mcp.Description("Slot length, e.g. 30m or 1h.")),
+		mcp.WithString("timezone"),
+		mcp.WithString("window", mcp.Description("Daily business window HH:MM-HH:MM (default 09:00-17:00).")),
+		mcp.WithArray("blackouts", mcp.Items(map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"start": map[string]any{"type": "string"},
+				"end":   map[string]any{"type": "string"},
+			},
+			"required": []string{"start", "end"},
+		})),
+		mcp.WithArray("holidays", mcp.Items(map[string]any{"type": "string"}), mcp.Description("Dates to skip, as YYYY-MM-DD.")),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(freeSlot, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			Duration  string     `json:"duration"`
+			Timezone  string     `json:"timezone"`
+			Window    string     `json:"window"`
+			Blackouts []Blackout `json:"blackouts"`
+			Holidays  []string   `json:"holidays"`
+		}
+		if err := r.BindArguments(&args); err != nil {
+			return errorResult(err)
+		}
+		if args.Window == "" {
+			args.Window = defaultBusinessWindow
+		}
+		res, err := ts.NextAvailableSlot(args.Duration, args.Timezone, args.Window, args.Blackouts, args.Holidays, r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+}
@@ -0,0 +1,454 @@
+// schedule_test.go
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOncallWho(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	people := []string{"alice", "bob", "carol"}
+
+	cases := []struct {
+		name       string
+		query      string
+		wantPerson string
+		wantStart  string
+	}{
+		{"firstShift", "2025-03-05 12:00", "alice", "2025-03-03T09:00:00-05:00"},
+		// The handoff on 2025-03-10 follows the spring-forward change but
+		// still happens at 09:00 local time.
+		{"acrossDST", "2025-03-10 09:30", "bob", "2025-03-10T09:00:00-04:00"},
+		{"justBeforeHandoff", "2025-03-17 08:59", "bob", "2025-03-10T09:00:00-04:00"},
+		{"wrapsAround", "2025-03-24 10:00", "alice", "2025-03-24T09:00:00-04:00"},
+		{"beforeStart", "2025-02-28 10:00", "carol", "2025-02-24T09:00:00-05:00"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.OncallWho("2025-03-03 09:00", "1w", people, "America/New_York", tc.query)
+			if err != nil {
+				t.Fatalf("OncallWho error: %v", err)
+			}
+			if res.OnCall != tc.wantPerson || res.ShiftStart.Datetime != tc.wantStart {
+				t.Errorf("got %s from %s, want %s from %s", res.OnCall, res.ShiftStart.Datetime, tc.wantPerson, tc.wantStart)
+			}
+		})
+	}
+
+	if _, err := ts.OncallWho("2025-03-03 09:00", "1w", nil, "UTC", ""); err == nil {
+		t.Errorf("expected error for empty rotation, got nil")
+	}
+}
+
+func TestEventsOverlap(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	cases := []struct {
+		name         string
+		a, b         EventSpec
+		wantOverlap  bool
+		wantDuration string
+	}{
+		{"overlapping", EventSpec{"2025-05-14 10:00", "1h"}, EventSpec{"2025-05-14 10:30", "1h"}, true, "30m0s"},
+		{"contained", EventSpec{"2025-05-14 10:00", "3h"}, EventSpec{"2025-05-14 11:00", "PT30M"}, true, "30m0s"},
+		{"adjacent", EventSpec{"2025-05-14 10:00", "1h"}, EventSpec{"2025-05-14 11:00", "1h"}, false, "0s"},
+		{"gap", EventSpec{"2025-05-14 10:00", "1h"}, EventSpec{"2025-05-14 12:15", "1h"}, false, "1h15m0s"},
+		{"pointInside", EventSpec{"2025-05-14 10:00", "1h"}, EventSpec{"2025-05-14 10:20", "0s"}, true, "0s"},
+		{"pointAtEnd", EventSpec{"2025-05-14 10:00", "1h"}, EventSpec{"2025-05-14 11:00", "0s"}, false, "0s"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.EventsOverlap(tc.a, tc.b, "UTC", "")
+			if err != nil {
+				t.Fatalf("EventsOverlap error: %v", err)
+			}
+			got := res.Gap
+			if res.Overlaps {
+				got = res.Overlap
+			}
+			if res.Overlaps != tc.wantOverlap || got != tc.wantDuration {
+				t.Errorf("got overlaps=%v %s, want overlaps=%v %s", res.Overlaps, got, tc.wantOverlap, tc.wantDuration)
+			}
+		})
+	}
+
+	if _, err := ts.EventsOverlap(EventSpec{"2025-05-14 10:00", "-1h"}, EventSpec{"2025-05-14 10:00", "1h"}, "UTC", ""); err == nil {
+		t.Errorf("expected error for negative duration, got nil")
+	}
+}
+
+func TestRandomTime(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	seed := int64(42)
+
+	first, err := ts.RandomTime("2025-05-14 10:00", "2025-05-14 11:00", "Europe/Paris", &seed)
+	if err != nil {
+		t.Fatalf("RandomTime error: %v", err)
+	}
+	second, err := ts.RandomTime("2025-05-14 10:00", "2025-05-14 11:00", "Europe/Paris", &seed)
+	if err != nil {
+		t.Fatalf("RandomTime error: %v", err)
+	}
+	if first.Result != second.Result {
+		t.Errorf("same seed gave different picks: %s vs %s", first.Result.Datetime, second.Result.Datetime)
+	}
+	if first.OffsetSeconds < 0 || first.OffsetSeconds >= 3600 {
+		t.Errorf("pick %s outside the range (offset %v s)", first.Result.Datetime, first.OffsetSeconds)
+	}
+
+	// A range far longer than time.Duration can represent must not overflow.
+	wide, err := ts.RandomTime("1000-01-01", "3000-01-01", "UTC", nil)
+	if err != nil {
+		t.Fatalf("RandomTime error for wide range: %v", err)
+	}
+	if wide.Result.Datetime < "1000-01-01" || wide.Result.Datetime >= "3000-01-01" {
+		t.Errorf("wide-range pick %s outside the range", wide.Result.Datetime)
+	}
+
+	if _, err := ts.RandomTime("2025-05-14 11:00", "2025-05-14 10:00", "UTC", nil); err == nil {
+		t.Errorf("expected error when start is after end, got nil")
+	}
+}
+
+func TestBestSendTime(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	cases := []struct {
+		name, tz, window, from string
+		wantUTC                string
+		wantActive             bool
+	}{
+		{"beforeWindow", "Europe/Berlin", "08:00-10:00", "2025-05-14T04:00:00Z", "2025-05-14T06:00:00Z", false},
+		{"insideWindow", "Europe/Berlin", "08:00-10:00", "2025-05-14T06:30:00Z", "2025-05-14T06:30:00Z", true},
+		{"afterWindow", "Europe/Berlin", "08:00-10:00", "2025-05-14T09:00:00Z", "2025-05-15T06:00:00Z", false},
+		// Berlin springs forward on 2025-03-30; 08:00 local moves from 07:00Z to 06:00Z.
+		{"acrossDST", "Europe/Berlin", "08:00-10:00", "2025-03-29T12:00:00Z", "2025-03-30T06:00:00Z", false},
+		{"wrapsMidnight", "America/New_York", "22:00-02:00", "2025-05-14T05:00:00Z", "2025-05-14T05:00:00Z", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.BestSendTime(tc.tz, tc.window, tc.from)
+			if err != nil {
+				t.Fatalf("BestSendTime error: %v", err)
+			}
+			if res.SendAtUTC != tc.wantUTC || res.ActiveNow != tc.wantActive {
+				t.Errorf("got %s active=%v, want %s active=%v", res.SendAtUTC, res.ActiveNow, tc.wantUTC, tc.wantActive)
+			}
+		})
+	}
+}
+
+func TestAssignSlot(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	res, err := ts.AssignSlot("tenant-42", 12, "5m", "02:00-03:00", "2025-05-14", "Europe/Paris", "")
+	if err != nil {
+		t.Fatalf("AssignSlot error: %v", err)
+	}
+	// FNV-1a is fixed by its spec, so this assignment must never change.
+	if res.Hash != "2944b77b49f24de2" || res.Slot != 10 {
+		t.Errorf("tenant-42 hashed to %s slot %d, want 2944b77b49f24de2 slot 10", res.Hash, res.Slot)
+	}
+	if res.Start.Datetime < "2025-05-14T02:00:00+02:00" || res.End.Datetime > "2025-05-14T03:00:00+02:00" {
+		t.Errorf("slot %s..%s outside the window", res.Start.Datetime, res.End.Datetime)
+	}
+
+	filled, err := ts.AssignSlot("tenant-42", 0, "15m", "22:00-02:00", "2025-05-14", "UTC", "")
+	if err != nil {
+		t.Fatalf("AssignSlot error: %v", err)
+	}
+	if filled.Slots != 16 {
+		t.Errorf("wrapping 4h window with 15m slots gave %d slots, want 16", filled.Slots)
+	}
+
+	if _, err := ts.AssignSlot("tenant-42", 13, "5m", "02:00-03:00", "", "UTC", ""); err == nil {
+		t.Errorf("expected error when slots overflow the window, got nil")
+	}
+}
+
+func TestMeetingVsSleep(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	cases := []struct {
+		name, tz, instant, duration string
+		wantMinutes                 float64
+		wantLocal                   string
+	}{
+		// 05:00Z is 22:00 in Los Angeles: the last 30 minutes run past 23:00.
+		{"eveningOverlap", "America/Los_Angeles", "2025-05-14T05:30:00Z", "1h", 30, "2025-05-13T22:30:00-07:00"},
+		// 04:00Z is 06:00 in Berlin, one hour before the 07:00 wake-up.
+		{"morningOverlap", "Europe/Berlin", "2025-05-14T04:00:00Z", "90m", 60, "2025-05-14T06:00:00+02:00"},
+		{"acrossMidnight", "Asia/Tokyo", "2025-05-14T14:30:00Z", "1h", 60, "2025-05-14T23:30:00+09:00"},
+		{"awake", "Europe/London", "2025-05-14T14:00:00Z", "1h", 0, "2025-05-14T15:00:00+01:00"},
+		// A zone-less instant is UTC, not New York wall time: 05:00Z is 01:00.
+		{"zonelessIsUTC", "America/New_York", "2025-05-14 05:00", "1h", 60, "2025-05-14T01:00:00-04:00"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.MeetingVsSleep(tc.tz, "23:00-07:00", tc.instant, tc.duration, "")
+			if err != nil {
+				t.Fatalf("MeetingVsSleep error: %v", err)
+			}
+			if res.OverlapMinutes != tc.wantMinutes || res.LocalStart.Datetime != tc.wantLocal || res.Overlaps != (tc.wantMinutes > 0) {
+				t.Errorf("got %v min overlap at %s, want %v at %s", res.OverlapMinutes, res.LocalStart.Datetime, tc.wantMinutes, tc.wantLocal)
+			}
+		})
+	}
+}
+
+func TestCronLocalTimes(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 3, 7, 12, 0, 0, 0, time.UTC) })
+
+	// 14:00 UTC on weekdays is 09:00 in New York until DST starts on March 9.
+	res, err := ts.CronLocalTimes("0 14 * * 1-5", "America/New_York", 3)
+	if err != nil {
+		t.Fatalf("CronLocalTimes error: %v", err)
+	}
+	want := []string{"2025-03-07T09:00:00-05:00", "2025-03-10T10:00:00-04:00", "2025-03-11T10:00:00-04:00"}
+	if len(res.Fires) != len(want) || res.DSTShifts != 1 || !res.Fires[1].OffsetChanged {
+		t.Fatalf("fires = %+v", res)
+	}
+	for i, w := range want {
+		if res.Fires[i].Local.Datetime != w {
+			t.Errorf("fire %d = %s, want %s", i, res.Fires[i].Local.Datetime, w)
+		}
+	}
+
+	// With both day fields restricted, either one matching fires.
+	either, err := ts.CronLocalTimes("30 6 13 * 5", "UTC", 2)
+	if err != nil {
+		t.Fatalf("CronLocalTimes error: %v", err)
+	}
+	if either.Fires[0].UTC != "2025-03-13T06:30:00Z" || either.Fires[1].UTC != "2025-03-14T06:30:00Z" {
+		t.Errorf("day-of-month or day-of-week fires = %+v", either.Fires)
+	}
+
+	for _, expr := range []string{"0 0 30 2 *", "61 * * * *", "* * *", "*/0 * * * *"} {
+		if _, err := ts.CronLocalTimes(expr, "UTC", 1); err == nil {
+			t.Errorf("expected error for %q, got nil", expr)
+		}
+	}
+}
+
+func TestPomodoroPlan(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	res, err := ts.PomodoroPlan("2025-05-14 09:00", 5, "25m", "5m", "15m", 4, "Europe/Berlin", "")
+	if err != nil {
+		t.Fatalf("PomodoroPlan error: %v", err)
+	}
+	kinds := []string{"work", "short_break", "work", "short_break", "work", "short_break", "work", "long_break", "work"}
+	if len(res.Intervals) != len(kinds) {
+		t.Fatalf("got %d intervals, want %d: %+v", len(res.Intervals), len(kinds), res.Intervals)
+	}
+	for i, k := range kinds {
+		if res.Intervals[i].Kind != k {
+			t.Errorf("interval %d = %s, want %s", i, res.Intervals[i].Kind, k)
+		}
+	}
+	// 5x25m work + 3x5m + 15m = 2h35m.
+	if res.End.Datetime != "2025-05-14T11:35:00+02:00" || res.WorkTotal != "2h5m0s" || res.Intervals[7].Start.Datetime != "2025-05-14T10:55:00+02:00" {
+		t.Errorf("plan = end %s, work %s, long break at %s", res.End.Datetime, res.WorkTotal, res.Intervals[7].Start.Datetime)
+	}
+
+	if _, err := ts.PomodoroPlan("", 4, "0m", "5m", "15m", 4, "UTC", ""); err == nil {
+		t.Errorf("expected error for a zero work interval, got nil")
+	}
+	if _, err := ts.PomodoroPlan("", 0, "25m", "5m", "15m", 4, "UTC", ""); err == nil {
+		t.Errorf("expected error for zero cycles, got nil")
+	}
+}
+
+func TestMaintenanceOverlap(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	cases := []struct {
+		name    string
+		regions []MaintenanceRegion
+		windows [][2]string
+		total   string
+	}{
+		{
+			name:    "alignedWinterWindows",
+			regions: []MaintenanceRegion{{"Europe/London", "01:00-04:00"}, {"Europe/Berlin", "02:00-05:00"}},
+			windows: [][2]string{{"2025-01-15T01:00:00Z", "2025-01-15T04:00:00Z"}},
+			total:   "3h0m0s",
+		},
+		{
+			name:    "wrappingWindowFromPreviousEvening",
+			regions: []MaintenanceRegion{{"America/New_York", "22:00-02:00"}, {"Europe/London", "03:00-06:00"}},
+			windows: [][2]string{{"2025-01-15T03:00:00Z", "2025-01-15T06:00:00Z"}},
+			total:   "3h0m0s",
+		},
+		{
+			name:    "noCommonWindow",
+			regions: []MaintenanceRegion{{"Asia/Tokyo", "01:00-04:00"}, {"America/New_York", "01:00-04:00"}},
+			total:   "0s",
+		},
+		{
+			name:    "clippedToUTCDay",
+			regions: []MaintenanceRegion{{"UTC", "23:00-01:00"}},
+			windows: [][2]string{{"2025-01-15T00:00:00Z", "2025-01-15T01:00:00Z"}, {"2025-01-15T23:00:00Z", "2025-01-16T00:00:00Z"}},
+			total:   "2h0m0s",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.MaintenanceOverlap(tc.regions, "2025-01-15", "")
+			if err != nil {
+				t.Fatalf("MaintenanceOverlap error: %v", err)
+			}
+			if len(res.Windows) != len(tc.windows) {
+				t.Fatalf("windows = %+v, want %v", res.Windows, tc.windows)
+			}
+			for i, w := range tc.windows {
+				if res.Windows[i].Start.Datetime != w[0] || res.Windows[i].End.Datetime != w[1] {
+					t.Errorf("window %d = %s..%s, want %s..%s", i, res.Windows[i].Start.Datetime, res.Windows[i].End.Datetime, w[0], w[1])
+				}
+			}
+			if res.Total != tc.total || res.Overlaps != (len(tc.windows) > 0) {
+				t.Errorf("total = %s overlaps = %v, want %s", res.Total, res.Overlaps, tc.total)
+			}
+		})
+	}
+
+	if _, err := ts.MaintenanceOverlap([]MaintenanceRegion{{"Mars/Base", "01:00-02:00"}}, "2025-01-15", ""); err == nil {
+		t.Error("expected error for unknown timezone")
+	}
+}
+
+func TestInMaintenance(t *testing.T) {
+	cases := []struct {
+		name, now, weekday, start, duration, tz string
+		active                                  bool
+		from, until, in                         string
+	}{
+		// 2025-05-17 is a Saturday.
+		{"upcoming", "2025-05-14T12:00:00Z", "Saturday", "23:00", "4h", "UTC", false,
+			"2025-05-17T23:00:00Z", "2025-05-18T03:00:00Z", "83h0m0s"},
+		{"activeAfterMidnight", "2025-05-18T01:30:00Z", "Saturday", "23:00", "4h", "UTC", true,
+			"2025-05-17T23:00:00Z", "2025-05-18T03:00:00Z", "1h30m0s"},
+		{"justClosed", "2025-05-18T03:00:00Z", "Saturday", "23:00", "4h", "UTC", false,
+			"2025-05-24T23:00:00Z", "2025-05-25T03:00:00Z", "164h0m0s"},
+		{"opensNow", "2025-05-17T23:00:00Z", "Saturday", "23:00", "4h", "UTC", true,
+			"2025-05-17T23:00:00Z", "2025-05-18T03:00:00Z", "4h0m0s"},
+		// 2025-03-09 is the US spring-forward Sunday: 04:00 local is already EDT.
+		{"wallClockAcrossDST", "2025-03-08T12:00:00Z", "Sunday", "04:00", "2h", "America/New_York", false,
+			"2025-03-09T04:00:00-04:00", "2025-03-09T06:00:00-04:00", "20h0m0s"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := NewTimeServer("UTC")
+			now, _ := time.Parse(time.RFC3339, tc.now)
+			ts.forTesting_SetNowFunc(func() time.Time { return now })
+			res, err := ts.InMaintenance(tc.weekday, tc.start, tc.duration, tc.tz, "")
+			if err != nil {
+				t.Fatalf("InMaintenance error: %v", err)
+			}
+			if res.Active != tc.active || res.WindowStart.Datetime != tc.from || res.WindowEnd.Datetime != tc.until {
+				t.Errorf("got active=%v %s..%s, want active=%v %s..%s", res.Active, res.WindowStart.Datetime, res.WindowEnd.Datetime, tc.active, tc.from, tc.until)
+			}
+			in := res.StartsIn
+			if tc.active {
+				in = res.Remaining
+			}
+			if in != tc.in {
+				t.Errorf("starts_in/remaining = %s, want %s", in, tc.in)
+			}
+		})
+	}
+
+	ts := NewTimeServer("UTC")
+	if _, err := ts.InMaintenance("Funday", "01:00", "1h", "UTC", ""); err == nil {
+		t.Error("expected error for unknown weekday")
+	}
+	if _, err := ts.InMaintenance("Monday", "01:00", "8d", "UTC", ""); err == nil {
+		t.Error("expected error for window longer than a week")
+	}
+}
+
+func TestBestHireZone(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	// Winter: New York works 14-22Z and London 09-17Z.
+	res, err := ts.BestHireZone([]string{"America/New_York", "Europe/London"}, "09:00-17:00",
+		[]string{"Europe/Berlin", "Asia/Tokyo", "America/Chicago", "Atlantic/Azores"}, "2025-01-15")
+	if err != nil {
+		t.Fatalf("BestHireZone error: %v", err)
+	}
+	want := []struct {
+		tz         string
+		total, min float64
+	}{
+		{"Atlantic/Azores", 660, 240},
+		{"Europe/Berlin", 540, 120},
+		{"America/Chicago", 540, 120},
+		{"Asia/Tokyo", 0, 0},
+	}
+	if res.Best != "Atlantic/Azores" || len(res.Candidates) != len(want) {
+		t.Fatalf("best = %s, candidates = %+v", res.Best, res.Candidates)
+	}
+	for i, w := range want {
+		c := res.Candidates[i]
+		if c.Rank != i+1 || c.Timezone != w.tz || c.TotalMinutes != w.total || c.MinMinutes != w.min {
+			t.Errorf("candidate %d = %+v, want %s total %v min %v", i, c, w.tz, w.total, w.min)
+		}
+	}
+
+	def, err := ts.BestHireZone([]string{"Europe/Paris"}, "09:00-17:00", nil, "2025-01-15")
+	if err != nil {
+		t.Fatalf("BestHireZone error: %v", err)
+	}
+	if len(def.Candidates) != len(defaultHireZones) || def.Candidates[0].TotalMinutes != 480 {
+		t.Errorf("default candidates: best %+v of %d", def.Candidates[0], len(def.Candidates))
+	}
+
+	if _, err := ts.BestHireZone(nil, "09:00-17:00", nil, "2025-01-15"); err == nil {
+		t.Error("expected error for empty team")
+	}
+}
+
+func TestNextAvailableSlot(t *testing.T) {
+	// 2025-05-14 is a Wednesday.
+	cases := []struct {
+		name, now, duration string
+		blackouts           []Blackout
+		holidays            []string
+		start, end          string
+	}{
+		{"beforeOpening", "2025-05-14T07:00:00Z", "30m", nil, nil, "2025-05-14T09:00:00Z", "2025-05-14T09:30:00Z"},
+		{"roundsUpNow", "2025-05-14T10:00:20Z", "30m", nil, nil, "2025-05-14T10:01:00Z", "2025-05-14T10:31:00Z"},
+		{"skipsOverlappingBlackouts", "2025-05-14T09:00:00Z", "1h", []Blackout{
+			{"2025-05-14T09:30:00Z", "2025-05-14T11:00:00Z"},
+			{"2025-05-14T10:30:00Z", "2025-05-14T12:00:00Z"},
+			{"2025-05-14T12:30:00Z", "2025-05-14T13:00:00Z"},
+		}, nil, "2025-05-14T13:00:00Z", "2025-05-14T14:00:00Z"},
+		{"fitsBetweenBookings", "2025-05-14T09:00:00Z", "30m", []Blackout{
+			{"2025-05-14T09:00:00Z", "2025-05-14T10:00:00Z"},
+			{"2025-05-14T10:30:00Z", "2025-05-14T12:00:00Z"},
+		}, nil, "2025-05-14T10:00:00Z", "2025-05-14T10:30:00Z"},
+		{"noStraddle", "2025-05-14T16:30:00Z", "1h", nil, nil, "2025-05-15T09:00:00Z", "2025-05-15T10:00:00Z"},
+		{"skipsWeekendAndHoliday", "2025-05-16T16:30:00Z", "1h", nil, []string{"2025-05-19"}, "2025-05-20T09:00:00Z", "2025-05-20T10:00:00Z"},
+		{"blackoutSpansNight", "2025-05-14T15:00:00Z", "1h", []Blackout{
+			{"2025-05-14T15:00:00Z", "2025-05-15T11:00:00Z"},
+		}, nil, "2025-05-15T11:00:00Z", "2025-05-15T12:00:00Z"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := NewTimeServer("UTC")
+			now, _ := time.Parse(time.RFC3339, tc.now)
+			ts.forTesting_SetNowFunc(func() time.Time { return now })
+			res, err := ts.NextAvailableSlot(tc.duration, "UTC", "09:00-17:00", tc.blackouts, tc.holidays, "")
+			if err != nil {
+				t.Fatalf("NextAvailableSlot error: %v", err)
+			}
+			if res.Start.Datetime != tc.start || res.End.Datetime != tc.end {
+				t.Errorf("slot = %s..%s, want %s..%s", res.Start.Datetime, res.End.Datetime, tc.start, tc.end)
+			}
+		})
+	}
+
+	ts := NewTimeServer("UTC")
+	if _, err := ts.NextAvailableSlot("9h", "UTC", "09:00-17:00", nil, nil, ""); err == nil {
+		t.Error("expected error for a slot longer than the window")
+	}
+}
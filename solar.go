@@ -0,0 +1,1098 @@
+// solar.go
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+/* ----- data types ----- */
+
+// crossing describes whether the sun reaches a target altitude on a day.
+type crossing int
+
+const (
+	crossingOK    crossing = iota // the sun passes through the altitude
+	crossingAbove                 // the sun stays above the altitude all day
+	crossingBelow                 // the sun never climbs to the altitude
+)
+
+type LightInterval struct {
+	Start  string `json:"start,omitempty"`
+	End    string `json:"end,omitempty"`
+	Status string `json:"status"` // "ok", "merged" or "none"
+}
+
+type PhotoLightResult struct {
+	Date              string        `json:"date"`
+	Timezone          string        `json:"timezone"`
+	SolarNoon         string        `json:"solar_noon"`
+	MorningBlueHour   LightInterval `json:"morning_blue_hour"`
+	MorningGoldenHour LightInterval `json:"morning_golden_hour"`
+	EveningGoldenHour LightInterval `json:"evening_golden_hour"`
+	EveningBlueHour   LightInterval `json:"evening_blue_hour"`
+}
+
+// Altitude bands used for photography light. Golden hour is the sun between
+// -4° and +6°, blue hour between -6° and -4°.
+const (
+	blueHourLow    = -6.0
+	goldenHourLow  = -4.0
+	goldenHourHigh = 6.0
+)
+
+type DaylightTrendResult struct {
+	Date                     string  `json:"date"`
+	Timezone                 string  `json:"timezone"`
+	DayLength                string  `json:"day_length"`
+	DayLengthSeconds         float64 `json:"day_length_seconds"`
+	PreviousDayLength        string  `json:"previous_day_length"`
+	PreviousDayLengthSeconds float64 `json:"previous_day_length_seconds"`
+	DeltaMinutes             float64 `json:"delta_minutes"`
+	Trend                    string  `json:"trend"` // "gaining", "losing" or "steady"
+	NextSolstice             string  `json:"next_solstice"`
+	NextSolsticeKind         string  `json:"next_solstice_kind"` // "june" or "december"
+	DaysUntilSolstice        int     `json:"days_until_solstice"`
+}
+
+type ShadowPoint struct {
+	Time         string  `json:"time,omitempty"`
+	Length       float64 `json:"length,omitempty"`
+	AltitudeDeg  float64 `json:"altitude_deg,omitempty"`
+	Availability string  `json:"availability"` // "ok" or "none"
+}
+
+type ShadowLengthResult struct {
+	Datetime       TimeResult  `json:"datetime"`
+	Height         float64     `json:"height"`
+	AltitudeDeg    float64     `json:"altitude_deg"`
+	Status         string      `json:"status"` // "ok" or "night"
+	Length         float64     `json:"length,omitempty"`
+	Shortest       ShadowPoint `json:"shortest"`
+	MorningLongest ShadowPoint `json:"morning_longest"`
+	EveningLongest ShadowPoint `json:"evening_longest"`
+}
+
+// practicalShadowAltitude is the sun altitude used for the "longest
+// practical" shadow. Below it shadows grow without bound toward sunrise and
+// sunset, so the longest useful shadow is about 11.4 times the height.
+const practicalShadowAltitude = 5.0
+
+type SeasonResult struct {
+	Date       string     `json:"date"`
+	Hemisphere string     `json:"hemisphere"`
+	Season     string     `json:"season"`
+	Start      TimeResult `json:"start"`
+	StartEvent string     `json:"start_event"`
+	End        TimeResult `json:"end"`
+	EndEvent   string     `json:"end_event"`
+	DaysIn     int        `json:"days_in"`
+	DaysLeft   int        `json:"days_left"`
+	Precision  string     `json:"precision"`
+}
+
+type DaylightFractionResult struct {
+	Date                  string  `json:"date"`
+	Timezone              string  `json:"timezone"`
+	DayLength             string  `json:"day_length"`
+	DaylightFraction      float64 `json:"daylight_fraction"`
+	CivilDayLength        string  `json:"civil_day_length"`
+	CivilDaylightFraction float64 `json:"civil_daylight_fraction"`
+	Polar                 string  `json:"polar,omitempty"` // "day" or "night"
+}
+
+type DaylightDay struct {
+	Date             string  `json:"date"`
+	DayLength        string  `json:"day_length"`
+	DayLengthSeconds float64 `json:"day_length_seconds"`
+}
+
+type DaylightAverageResult struct {
+	Timezone       string      `json:"timezone"`
+	Start          string      `json:"start"`
+	End            string      `json:"end"`
+	Days           int         `json:"days"`
+	Average        string      `json:"average"`
+	AverageSeconds float64     `json:"average_seconds"`
+	AverageHours   float64     `json:"average_hours"`
+	Shortest       DaylightDay `json:"shortest"`
+	Longest        DaylightDay `json:"longest"`
+	PolarDays      int         `json:"polar_days"`   // 24h of daylight
+	PolarNights    int         `json:"polar_nights"` // no daylight
+}
+
+// maxDaylightAverageDays bounds the range DaylightAverage will iterate.
+const maxDaylightAverageDays = 3660
+
+type SunAltitudeResult struct {
+	Date     string      `json:"date"`
+	Altitude float64     `json:"altitude"`
+	Crossing string      `json:"crossing"` // "rising" or "setting"
+	Status   string      `json:"status"`   // "ok", "always_above" or "never_reached"
+	Time     *TimeResult `json:"time,omitempty"`
+}
+
+type MeridianOffsetResult struct {
+	Timezone                 string  `json:"timezone"`
+	Longitude                float64 `json:"longitude"`
+	StandardOffset           string  `json:"standard_offset"`
+	StandardMeridian         float64 `json:"standard_meridian"`
+	SolarOffset              string  `json:"solar_offset"`
+	DifferenceMinutes        float64 `json:"difference_minutes"` // standard minus solar
+	Assessment               string  `json:"assessment"`         // "ahead of the sun", "behind the sun" or "aligned"
+	CurrentOffset            string  `json:"current_offset"`
+	CurrentDifferenceMinutes float64 `json:"current_difference_minutes"`
+}
+
+type SeasonalMarkerResult struct {
+	Year      int        `json:"year"`
+	Marker    string     `json:"marker"`
+	Instant   TimeResult `json:"instant"`
+	UTC       string     `json:"utc"`
+	Precision string     `json:"precision"`
+}
+
+// civilTwilightAltitude is the sun altitude at which civil twilight begins
+// and ends.
+const civilTwilightAltitude = -6.0
+
+/* ----- solar position ----- */
+
+// The solar position formulas follow the NOAA solar calculator, which is
+// accurate to about a minute for sunrise and sunset between 1800 and 2100.
+
+func deg2rad(d float64) float64 { return d * math.Pi / 180 }
+func rad2deg(r float64) float64 { return r * 180 / math.Pi }
+
+// julianDay returns the Julian Day of the instant tm.
+func julianDay(tm time.Time) float64 {
+	return float64(tm.UnixNano())/float64(day) + 2440587.5
+}
+
+// sunDeclination returns the sun's declination (degrees) and the equation of
+// time (minutes) at tm.
+func sunDeclination(tm time.Time) (float64, float64) {
+	jc := (julianDay(tm) - 2451545) / 36525
+	l0 := math.Mod(280.46646+jc*(36000.76983+jc*0.0003032), 360)
+	m := 357.52911 + jc*(35999.05029-0.0001537*jc)
+	e := 0.016708634 - jc*(0.000042037+0.0000001267*jc)
+	c := math.Sin(deg2rad(m))*(1.914602-jc*(0.004817+0.000014*jc)) +
+		math.Sin(deg2rad(2*m))*(0.019993-0.000101*jc) +
+		math.Sin(deg2rad(3*m))*0.000289
+	omega := 125.04 - 1934.136*jc
+	appLong := l0 + c - 0.00569 - 0.00478*math.Sin(deg2rad(omega))
+	meanObliq := 23 + (26+(21.448-jc*(46.815+jc*(0.00059-jc*0.001813)))/60)/60
+	obliq := meanObliq + 0.00256*math.Cos(deg2rad(omega))
+	decl := rad2deg(math.Asin(math.Sin(deg2rad(obliq)) * math.Sin(deg2rad(appLong))))
+
+	y := math.Pow(math.Tan(deg2rad(obliq/2)), 2)
+	l0r, mr := deg2rad(l0), deg2rad(m)
+	eqTime := 4 * rad2deg(y*math.Sin(2*l0r)-2*e*math.Sin(mr)+4*e*y*math.Sin(mr)*math.Cos(2*l0r)-
+		0.5*y*y*math.Sin(4*l0r)-1.25*e*e*math.Sin(2*mr))
+	return decl, eqTime
+}
+
+// solarAltitude returns the geometric altitude of the sun's centre in degrees
+// (no refraction correction) at tm for the given coordinates.
+func solarAltitude(tm time.Time, lat, lon float64) float64 {
+	decl, eqTime := sunDeclination(tm)
+	u := tm.UTC()
+	minutes := float64(u.Hour()*60+u.Minute()) + float64(u.Second())/60 + float64(u.Nanosecond())/6e10
+	trueSolar := math.Mod(minutes+eqTime+4*lon, 1440)
+	hourAngle := trueSolar/4 - 180
+	cosZenith := math.Sin(deg2rad(lat))*math.Sin(deg2rad(decl)) +
+		math.Cos(deg2rad(lat))*math.Cos(deg2rad(decl))*math.Cos(deg2rad(hourAngle))
+	return 90 - rad2deg(math.Acos(math.Max(-1, math.Min(1, cosZenith))))
+}
+
+// utcMidnight returns 00:00 UTC on the calendar date of d (in d's zone).
+func utcMidnight(d time.Time) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func minutesAfter(base time.Time, m float64) time.Time {
+	return base.Add(time.Duration(m * float64(time.Minute)))
+}
+
+// solarNoon returns the instant of solar transit on the local date of d.
+func solarNoon(d time.Time, lon float64) time.Time {
+	base := utcMidnight(d)
+	noon := minutesAfter(base, 720-4*lon)
+	for i := 0; i < 3; i++ {
+		_, eqTime := sunDeclination(noon)
+		noon = minutesAfter(base, 720-4*lon-eqTime)
+	}
+	return noon
+}
+
+// sunCrossing returns when the sun passes altitude alt (degrees) on the local
+// date of d, on the rising (morning) or setting (evening) side of solar noon.
+func sunCrossing(d time.Time, lat, lon, alt float64, rising bool) (time.Time, crossing) {
+	base := utcMidnight(d)
+	tm := solarNoon(d, lon)
+	for i := 0; i < 4; i++ {
+		decl, eqTime := sunDeclination(tm)
+		cosH := (math.Sin(deg2rad(alt)) - math.Sin(deg2rad(lat))*math.Sin(deg2rad(decl))) /
+			(math.Cos(deg2rad(lat)) * math.Cos(deg2rad(decl)))
+		switch {
+		case math.IsNaN(cosH) || cosH > 1:
+			return time.Time{}, crossingBelow
+		case cosH < -1:
+			return time.Time{}, crossingAbove
+		}
+		h := rad2deg(math.Acos(cosH))
+		if rising {
+			h = -h
+		}
+		tm = minutesAfter(base, 720-4*lon-eqTime+4*h)
+	}
+	return tm, crossingOK
+}
+
+// sunriseAltitude is the standard altitude of the sun's centre at sunrise
+// and sunset, allowing for refraction and the solar disc's radius.
+const sunriseAltitude = -0.833
+
+// dayLength returns how long the sun is above alt on the local date of d.
+// Polar day yields 24h and polar night yields zero.
+func dayLength(d time.Time, lat, lon, alt float64) time.Duration {
+	rise, st := sunCrossing(d, lat, lon, alt, true)
+	switch st {
+	case crossingAbove:
+		return day
+	case crossingBelow:
+		return 0
+	}
+	set, _ := sunCrossing(d, lat, lon, alt, false)
+	return set.Sub(rise)
+}
+
+// nextSolstice returns the local date of the first solstice on or after d's
+// date. Each solstice is located by scanning the days around its usual date
+// for the extremum of the sun's declination at solar noon, which (unlike day
+// length) is well defined at the equator and inside the polar circles.
+func nextSolstice(d time.Time, lon float64) (time.Time, string) {
+	loc := d.Location()
+	today := startOfDay(d)
+	for year := d.Year(); ; year++ {
+		for _, m := range []time.Month{time.June, time.December} {
+			best := time.Date(year, m, 15, 0, 0, 0, 0, loc)
+			bestDecl, _ := sunDeclination(solarNoon(best, lon))
+			for dd := 16; dd <= 27; dd++ {
+				cand := time.Date(year, m, dd, 0, 0, 0, 0, loc)
+				decl, _ := sunDeclination(solarNoon(cand, lon))
+				if (m == time.June && decl > bestDecl) || (m == time.December && decl < bestDecl) {
+					best, bestDecl = cand, decl
+				}
+			}
+			if !best.Before(today) {
+				return best, map[time.Month]string{time.June: "june", time.December: "december"}[m]
+			}
+		}
+	}
+}
+
+// seasonEvents names the equinoxes and solstices in calendar order.
+var seasonEvents = []string{"march_equinox", "june_solstice", "september_equinox", "december_solstice"}
+
+// meeusMean holds the polynomial coefficients (Meeus, Astronomical
+// Algorithms, table 27.C) for the mean instant of each event, valid for the
+// years 1000-3000.
+var meeusMean = [4][5]float64{
+	{2451623.80984, 365242.37404, 0.05169, -0.00411, -0.00057},
+	{2451716.56767, 365241.62603, 0.00325, 0.00888, -0.00030},
+	{2451810.21715, 365242.01767, -0.11575, 0.00337, 0.00078},
+	{2451900.05952, 365242.74049, -0.06223, -0.00823, 0.00032},
+}
+
+// meeusPeriodic are the A, B, C terms of Meeus table 27.C.
+var meeusPeriodic = [][3]float64{
+	{485, 324.96, 1934.136}, {203, 337.23, 32964.467}, {199, 342.08, 20.186},
+	{182, 27.85, 445267.112}, {156, 73.14, 45036.886}, {136, 171.52, 22518.443},
+	{77, 222.54, 65928.934}, {74, 296.72, 3034.906}, {70, 243.58, 9037.513},
+	{58, 119.81, 33718.147}, {52, 297.17, 150.678}, {50, 21.02, 2281.226},
+	{45, 247.54, 29929.562}, {44, 325.15, 31555.956}, {29, 60.93, 4443.417},
+	{18, 155.12, 67555.328}, {17, 288.79, 4562.452}, {16, 198.04, 62894.029},
+	{14, 199.76, 31436.921}, {12, 95.39, 14577.848}, {12, 287.11, 31931.756},
+	{12, 320.81, 34777.259}, {9, 227.73, 1222.114}, {8, 15.45, 16859.074},
+}
+
+// seasonPrecision documents the accuracy of seasonEvent.
+const seasonPrecision = "equinox and solstice instants from Meeus ch. 27 with the Espenak-Meeus ΔT polynomials; within about a minute for 1950-2100, degrading toward 1000 and 3000 as ΔT grows uncertain"
+
+// seasonMinYear and seasonMaxYear bound the years meeusMean is fitted for.
+const (
+	seasonMinYear = 1000
+	seasonMaxYear = 3000
+)
+
+// seasonEvent returns the UTC instant of event k (0 = March equinox .. 3 =
+// December solstice) in year.
+func seasonEvent(year, k int) time.Time {
+	y := float64(year-2000) / 1000
+	c := meeusMean[k]
+	jde0 := c[0] + y*(c[1]+y*(c[2]+y*(c[3]+y*c[4])))
+	tc := (jde0 - 2451545) / 36525
+	w := deg2rad(35999.373*tc - 2.47)
+	dl := 1 + 0.0334*math.Cos(w) + 0.0007*math.Cos(2*w)
+	sum := 0.0
+	for _, p := range meeusPeriodic {
+		sum += p[0] * math.Cos(deg2rad(p[1]+p[2]*tc))
+	}
+	jde := jde0 + 0.00001*sum/dl
+	// JDE is Terrestrial Time; subtract ΔT at the event's month (March,
+	// June, September, December).
+	// Whole seconds plus remainder: nanoseconds since 1970 overflow int64
+	// outside roughly 1678-2262.
+	secs := (jde-2440587.5)*86400 - deltaT(float64(year)+(float64(3*k+3)-0.5)/12)
+	sec := math.Floor(secs)
+	return time.Unix(int64(sec), int64((secs-sec)*1e9)).UTC()
+}
+
+// deltaT returns ΔT = TT - UT in seconds for the decimal year y, using the
+// piecewise Espenak-Meeus polynomials (NASA Five Millennium Canon of Solar
+// Eclipses) for the years 500 onward.
+func deltaT(y float64) float64 {
+	switch {
+	case y < 1600:
+		u := (y - 1000) / 100
+		return 1574.2 + u*(-556.01+u*(71.23472+u*(0.319781+u*(-0.8503463+u*(-0.005050998+u*0.0083572073)))))
+	case y < 1700:
+		t := y - 1600
+		return 120 + t*(-0.9808+t*(-0.01532+t/7129))
+	case y < 1800:
+		t := y - 1700
+		return 8.83 + t*(0.1603+t*(-0.0059285+t*(0.00013336-t/1174000)))
+	case y < 1860:
+		t := y - 1800
+		return 13.72 + t*(-0.332447+t*(0.0068612+t*(0.0041116+t*(-0.00037436+t*(0.0000121272+t*(-0.0000001699+t*0.000000000875))))))
+	case y < 1900:
+		t := y - 1860
+		return 7.62 + t*(0.5737+t*(-0.251754+t*(0.01680668+t*(-0.0004473624+t/233174))))
+	case y < 1920:
+		t := y - 1900
+		return -2.79 + t*(1.494119+t*(-0.0598939+t*(0.0061966-t*0.000197)))
+	case y < 1941:
+		t := y - 1920
+		return 21.20 + t*(0.84493+t*(-0.076100+t*0.0020936))
+	case y < 1961:
+		t := y - 1950
+		return 29.07 + t*(0.407+t*(-1.0/233+t/2547))
+	case y < 1986:
+		t := y - 1975
+		return 45.45 + t*(1.067+t*(-1.0/260-t/718))
+	case y < 2005:
+		t := y - 2000
+		return 63.86 + t*(0.3345+t*(-0.060374+t*(0.0017275+t*(0.000651814+t*0.00002373599))))
+	case y < 2050:
+		t := y - 2000
+		return 62.92 + t*(0.32217+t*0.005589)
+	case y < 2150:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u - 0.5628*(2150-y)
+	default:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u
+	}
+}
+
+// validateCoordinates checks latitude and longitude ranges.
+func validateCoordinates(lat, lon float64) error {
+	if math.IsNaN(lat) || lat < -90 || lat > 90 {
+		return fmt.Errorf("latitude must be between -90 and 90")
+	}
+	if math.IsNaN(lon) || lon < -180 || lon > 180 {
+		return fmt.Errorf("longitude must be between -180 and 180")
+	}
+	return nil
+}
+
+// lightBand returns the interval in which the sun moves between the low and
+// high altitudes on one side of solar noon. When the sun never leaves the
+// band on that side the interval runs to solar noon or solar midnight and is
+// reported as "merged".
+func lightBand(d time.Time, lat, lon, low, high float64, morning bool) LightInterval {
+	loc := d.Location()
+	noon := solarNoon(d, lon)
+	lowT, lowS := sunCrossing(d, lat, lon, low, morning)
+	highT, highS := sunCrossing(d, lat, lon, high, morning)
+
+	switch {
+	case lowS == crossingBelow, lowS == crossingAbove && highS == crossingAbove:
+		return LightInterval{Status: "none"}
+	case lowS == crossingOK && highS == crossingOK:
+		if morning {
+			return LightInterval{Start: lowT.In(loc).Format(time.RFC3339), End: highT.In(loc).Format(time.RFC3339), Status: "ok"}
+		}
+		return LightInterval{Start: highT.In(loc).Format(time.RFC3339), End: lowT.In(loc).Format(time.RFC3339), Status: "ok"}
+	case lowS == crossingOK: // the sun never climbs above the band
+		if morning {
+			return LightInterval{Start: lowT.In(loc).Format(time.RFC3339), End: noon.In(loc).Format(time.RFC3339), Status: "merged"}
+		}
+		return LightInterval{Start: noon.In(loc).Format(time.RFC3339), End: lowT.In(loc).Format(time.RFC3339), Status: "merged"}
+	default: // the sun never sinks below the band
+		if morning {
+			return LightInterval{Start: noon.Add(-12 * time.Hour).In(loc).Format(time.RFC3339), End: highT.In(loc).Format(time.RFC3339), Status: "merged"}
+		}
+		return LightInterval{Start: highT.In(loc).Format(time.RFC3339), End: noon.Add(12 * time.Hour).In(loc).Format(time.RFC3339), Status: "merged"}
+	}
+}
+
+// shadowLength returns the length of the shadow cast by height with the sun
+// at alt degrees.
+func shadowLength(height, alt float64) float64 {
+	return height / math.Tan(deg2rad(alt))
+}
+
+/* ----- core methods ----- */
+
+// PhotoLight returns the morning and evening blue-hour and golden-hour
+// intervals for a location on a local date.
+func (t *TimeServer) PhotoLight(lat, lon float64, date, tz string) (PhotoLightResult, error) {
+	if err := validateCoordinates(lat, lon); err != nil {
+		return PhotoLightResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return PhotoLightResult{}, err
+	}
+	d, err := t.parseDateTime(date, loc)
+	if err != nil {
+		return PhotoLightResult{}, err
+	}
+
+	return PhotoLightResult{
+		Date:              d.Format("2006-01-02"),
+		Timezone:          tz,
+		SolarNoon:         solarNoon(d, lon).In(loc).Format(time.RFC3339),
+		MorningBlueHour:   lightBand(d, lat, lon, blueHourLow, goldenHourLow, true),
+		MorningGoldenHour: lightBand(d, lat, lon, goldenHourLow, goldenHourHigh, true),
+		EveningGoldenHour: lightBand(d, lat, lon, goldenHourLow, goldenHourHigh, false),
+		EveningBlueHour:   lightBand(d, lat, lon, blueHourLow, goldenHourLow, false),
+	}, nil
+}
+
+// DaylightTrend compares a day's length with the previous day's and reports
+// the next solstice.
+func (t *TimeServer) DaylightTrend(lat, lon float64, date, tz, format string) (DaylightTrendResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return DaylightTrendResult{}, err
+	}
+	if err := validateCoordinates(lat, lon); err != nil {
+		return DaylightTrendResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return DaylightTrendResult{}, err
+	}
+	d, err := t.parseDateTime(date, loc)
+	if err != nil {
+		return DaylightTrendResult{}, err
+	}
+
+	today := dayLength(d, lat, lon, sunriseAltitude)
+	yesterday := dayLength(d.AddDate(0, 0, -1), lat, lon, sunriseAltitude)
+	delta := today - yesterday
+	trend := "steady"
+	switch {
+	case delta >= time.Second:
+		trend = "gaining"
+	case delta <= -time.Second:
+		trend = "losing"
+	}
+	solstice, kind := nextSolstice(d, lon)
+
+	return DaylightTrendResult{
+		Date:                     d.Format("2006-01-02"),
+		Timezone:                 tz,
+		DayLength:                render(today.Round(time.Second)),
+		DayLengthSeconds:         today.Seconds(),
+		PreviousDayLength:        render(yesterday.Round(time.Second)),
+		PreviousDayLengthSeconds: yesterday.Seconds(),
+		DeltaMinutes:             delta.Minutes(),
+		Trend:                    trend,
+		NextSolstice:             solstice.Format("2006-01-02"),
+		NextSolsticeKind:         kind,
+		DaysUntilSolstice:        daysBetween(d, solstice),
+	}, nil
+}
+
+// ShadowLength returns the shadow cast by an object of the given height at
+// datetime, plus the shortest shadow of the day (at solar noon) and the
+// longest practical shadows, taken where the sun passes
+// practicalShadowAltitude in the morning and evening. Lengths share the
+// unit of height. A sun at or below the horizon reports "night".
+func (t *TimeServer) ShadowLength(lat, lon, height float64, datetime, tz string) (ShadowLengthResult, error) {
+	if err := validateCoordinates(lat, lon); err != nil {
+		return ShadowLengthResult{}, err
+	}
+	if height <= 0 {
+		return ShadowLengthResult{}, fmt.Errorf("height must be positive")
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return ShadowLengthResult{}, err
+	}
+	at, err := t.parseDateTime(datetime, loc)
+	if err != nil {
+		return ShadowLengthResult{}, err
+	}
+
+	alt := solarAltitude(at, lat, lon)
+	res := ShadowLengthResult{Datetime: makeTimeResult(tz, at), Height: height, AltitudeDeg: alt, Status: "night"}
+	if alt > 0 {
+		res.Status, res.Length = "ok", shadowLength(height, alt)
+	}
+
+	res.Shortest = ShadowPoint{Availability: "none"}
+	noon := solarNoon(at, lon)
+	if noonAlt := solarAltitude(noon, lat, lon); noonAlt > 0 {
+		res.Shortest = ShadowPoint{
+			Time:         noon.In(loc).Format(time.RFC3339),
+			Length:       shadowLength(height, noonAlt),
+			AltitudeDeg:  noonAlt,
+			Availability: "ok",
+		}
+	}
+	longest := func(rising bool) ShadowPoint {
+		tm, st := sunCrossing(at, lat, lon, practicalShadowAltitude, rising)
+		if st != crossingOK {
+			return ShadowPoint{Availability: "none"}
+		}
+		return ShadowPoint{
+			Time:         tm.In(loc).Format(time.RFC3339),
+			Length:       shadowLength(height, practicalShadowAltitude),
+			AltitudeDeg:  practicalShadowAltitude,
+			Availability: "ok",
+		}
+	}
+	res.MorningLongest = longest(true)
+	res.EveningLongest = longest(false)
+	return res, nil
+}
+
+// Season returns the astronomical season containing date, bounded by the
+// equinox or solstice instants on either side. Seasons are inverted in the
+// southern hemisphere: the March equinox starts autumn there. Dates outside
+// the years 1000-3000 covered by meeusMean are rejected.
+func (t *TimeServer) Season(date, tz, hemisphere string) (SeasonResult, error) {
+	hemisphere = strings.ToLower(strings.TrimSpace(hemisphere))
+	if hemisphere == "" {
+		hemisphere = "north"
+	}
+	names := map[string][]string{
+		"north": {"spring", "summer", "autumn", "winter"},
+		"south": {"autumn", "winter", "spring", "summer"},
+	}[hemisphere]
+	if names == nil {
+		return SeasonResult{}, fmt.Errorf("hemisphere must be north or south: %s", hemisphere)
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return SeasonResult{}, err
+	}
+	at, err := t.parseDateTime(date, loc)
+	if err != nil {
+		return SeasonResult{}, err
+	}
+	if y := at.Year(); y < seasonMinYear || y > seasonMaxYear {
+		return SeasonResult{}, fmt.Errorf("date must fall in the years %d-%d: %s", seasonMinYear, seasonMaxYear, date)
+	}
+
+	// The previous December solstice precedes every instant of the year.
+	k, year := 3, at.Year()-1
+	for {
+		nk, ny := (k+1)%4, year
+		if nk == 0 {
+			ny++
+		}
+		if seasonEvent(ny, nk).After(at) {
+			break
+		}
+		k, year = nk, ny
+	}
+	start := seasonEvent(year, k).In(loc)
+	nk, ny := (k+1)%4, year
+	if nk == 0 {
+		ny++
+	}
+	end := seasonEvent(ny, nk).In(loc)
+
+	return SeasonResult{
+		Date:       at.Format("2006-01-02"),
+		Hemisphere: hemisphere,
+		Season:     names[k],
+		Start:      makeTimeResult(tz, start),
+		StartEvent: seasonEvents[k],
+		End:        makeTimeResult(tz, end),
+		EndEvent:   seasonEvents[nk],
+		DaysIn:     daysBetween(start, at),
+		DaysLeft:   daysBetween(at, end),
+		Precision:  seasonPrecision,
+	}, nil
+}
+
+// DaylightFraction returns the share of the 24-hour day the sun is up on
+// date, and the share including civil twilight. Polar day gives 1 and
+// polar night 0.
+func (t *TimeServer) DaylightFraction(lat, lon float64, date, tz, format string) (DaylightFractionResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return DaylightFractionResult{}, err
+	}
+	if err := validateCoordinates(lat, lon); err != nil {
+		return DaylightFractionResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return DaylightFractionResult{}, err
+	}
+	d, err := t.parseDateTime(date, loc)
+	if err != nil {
+		return DaylightFractionResult{}, err
+	}
+
+	sun := dayLength(d, lat, lon, sunriseAltitude)
+	civil := dayLength(d, lat, lon, civilTwilightAltitude)
+	res := DaylightFractionResult{
+		Date:                  d.Format("2006-01-02"),
+		Timezone:              tz,
+		DayLength:             render(sun.Round(time.Second)),
+		DaylightFraction:      sun.Seconds() / day.Seconds(),
+		CivilDayLength:        render(civil.Round(time.Second)),
+		CivilDaylightFraction: civil.Seconds() / day.Seconds(),
+	}
+	switch sun {
+	case day:
+		res.Polar = "day"
+	case 0:
+		res.Polar = "night"
+	}
+	return res, nil
+}
+
+// DaylightAverage averages the day length over every local date from start
+// to end inclusive. Polar days count as 24h and polar nights as zero, so the
+// average stays a plain arithmetic mean; the shortest and longest days are
+// the first dates reaching each extreme.
+func (t *TimeServer) DaylightAverage(lat, lon float64, start, end, tz, format string) (DaylightAverageResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return DaylightAverageResult{}, err
+	}
+	if err := validateCoordinates(lat, lon); err != nil {
+		return DaylightAverageResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return DaylightAverageResult{}, err
+	}
+	from, err := t.parseDateTime(start, loc)
+	if err != nil {
+		return DaylightAverageResult{}, err
+	}
+	until, err := t.parseDateTime(end, loc)
+	if err != nil {
+		return DaylightAverageResult{}, err
+	}
+	from, until = startOfDay(from), startOfDay(until)
+	n := daysBetween(from, until) + 1
+	if n < 1 {
+		return DaylightAverageResult{}, fmt.Errorf("end must not be before start")
+	}
+	if n > maxDaylightAverageDays {
+		return DaylightAverageResult{}, fmt.Errorf("range must be at most %d days", maxDaylightAverageDays)
+	}
+
+	res := DaylightAverageResult{Timezone: tz, Start: from.Format("2006-01-02"), End: until.Format("2006-01-02"), Days: n}
+	var total, shortest, longest time.Duration
+	for i := 0; i < n; i++ {
+		d := from.AddDate(0, 0, i)
+		length := dayLength(d, lat, lon, sunriseAltitude)
+		dd := DaylightDay{Date: d.Format("2006-01-02"), DayLength: render(length.Round(time.Second)), DayLengthSeconds: length.Seconds()}
+		if i == 0 || length < shortest {
+			shortest, res.Shortest = length, dd
+		}
+		if i == 0 || length > longest {
+			longest, res.Longest = length, dd
+		}
+		switch length {
+		case day:
+			res.PolarDays++
+		case 0:
+			res.PolarNights++
+		}
+		total += length
+	}
+	avg := total / time.Duration(n)
+	res.Average = render(avg.Round(time.Second))
+	res.AverageSeconds = avg.Seconds()
+	res.AverageHours = avg.Hours()
+	return res, nil
+}
+
+// TimeAtSunAltitude returns when the sun's centre passes altitude degrees on
+// the morning (rising) or evening (setting) side of solar noon on date.
+// Sunrise and sunset are -0.833°; civil, nautical and astronomical twilight
+// begin or end at -6°, -12° and -18°. On days the sun stays above the
+// altitude or never reaches it the status says so and no time is given.
+func (t *TimeServer) TimeAtSunAltitude(lat, lon float64, date string, altitude float64, crossing, tz string) (SunAltitudeResult, error) {
+	if err := validateCoordinates(lat, lon); err != nil {
+		return SunAltitudeResult{}, err
+	}
+	if math.IsNaN(altitude) || altitude < -90 || altitude > 90 {
+		return SunAltitudeResult{}, fmt.Errorf("altitude must be between -90 and 90 degrees")
+	}
+	crossing = strings.ToLower(strings.TrimSpace(crossing))
+	if crossing == "" {
+		crossing = "rising"
+	}
+	if crossing != "rising" && crossing != "setting" {
+		return SunAltitudeResult{}, fmt.Errorf("crossing must be rising or setting: %s", crossing)
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return SunAltitudeResult{}, err
+	}
+	d, err := t.parseDateTime(date, loc)
+	if err != nil {
+		return SunAltitudeResult{}, err
+	}
+
+	res := SunAltitudeResult{Date: d.Format("2006-01-02"), Altitude: altitude, Crossing: crossing}
+	tm, st := sunCrossing(d, lat, lon, altitude, crossing == "rising")
+	switch st {
+	case crossingAbove:
+		res.Status = "always_above"
+	case crossingBelow:
+		res.Status = "never_reached"
+	default:
+		at := makeTimeResult(tz, tm.In(loc))
+		res.Status, res.Time = "ok", &at
+	}
+	return res, nil
+}
+
+// MeridianOffset compares a zone's legal standard offset with the mean solar
+// offset of a longitude, 4 minutes per degree east of Greenwich. A positive
+// difference means clocks run ahead of the sun, so mean solar noon falls
+// after 12:00 by that much (before the equation of time). The standard
+// offset is taken from whichever of January 1 and July 1 this year is not
+// DST; the current offset, DST included, is compared as well.
+func (t *TimeServer) MeridianOffset(tz string, lon float64) (MeridianOffsetResult, error) {
+	if err := validateCoordinates(0, lon); err != nil {
+		return MeridianOffsetResult{}, err
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return MeridianOffsetResult{}, err
+	}
+	now := t.nowFunc().In(loc)
+	jan := time.Date(now.Year(), time.January, 1, 12, 0, 0, 0, loc)
+	jul := time.Date(now.Year(), time.July, 1, 12, 0, 0, 0, loc)
+	_, std := jan.Zone()
+	if jan.IsDST() && !jul.IsDST() {
+		_, std = jul.Zone()
+	}
+	_, cur := now.Zone()
+
+	solar := lon * 240 // seconds of offset
+	diff, curDiff := (float64(std)-solar)/60, (float64(cur)-solar)/60
+	res := MeridianOffsetResult{
+		Timezone:                 tz,
+		Longitude:                lon,
+		StandardOffset:           formatOffset(std),
+		StandardMeridian:         float64(std) / 240,
+		SolarOffset:              formatOffset(int(math.Round(solar))),
+		DifferenceMinutes:        math.Round(diff*10) / 10,
+		Assessment:               "aligned",
+		CurrentOffset:            formatOffset(cur),
+		CurrentDifferenceMinutes: math.Round(curDiff*10) / 10,
+	}
+	switch {
+	case res.DifferenceMinutes >= 1:
+		res.Assessment = "ahead of the sun"
+	case res.DifferenceMinutes <= -1:
+		res.Assessment = "behind the sun"
+	}
+	return res, nil
+}
+
+// SeasonalMarker returns the instant of one equinox or solstice of year
+// (default the current year) in tz. Meeus's coefficients are only valid for
+// the years 1000-3000, so other years are rejected.
+func (t *TimeServer) SeasonalMarker(year int, marker, tz string) (SeasonalMarkerResult, error) {
+	marker = strings.ToLower(strings.TrimSpace(marker))
+	k := -1
+	for i, name := range seasonEvents {
+		if name == marker {
+			k = i
+		}
+	}
+	if k < 0 {
+		return SeasonalMarkerResult{}, fmt.Errorf("marker must be one of %s: %s", strings.Join(seasonEvents, ", "), marker)
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return SeasonalMarkerResult{}, err
+	}
+	if year == 0 {
+		year = t.nowFunc().In(loc).Year()
+	}
+	if year < seasonMinYear || year > seasonMaxYear {
+		return SeasonalMarkerResult{}, fmt.Errorf("year must be between %d and %d: %d", seasonMinYear, seasonMaxYear, year)
+	}
+
+	at := seasonEvent(year, k)
+	return SeasonalMarkerResult{
+		Year:      year,
+		Marker:    marker,
+		Instant:   makeTimeResult(tz, at.In(loc)),
+		UTC:       at.Round(time.Second).Format(time.RFC3339),
+		Precision: seasonPrecision,
+	}, nil
+}
+
+/* ----- tools ----- */
+
+func registerSolarTools(s *server.MCPServer, ts *TimeServer) {
+	photoLight := mcp.NewTool(
+		"photo_light",
+		mcp.WithDescription("Golden-hour and blue-hour intervals for a location and date."),
+		mcp.WithNumber("latitude", mcp.Required()),
+		mcp.WithNumber("longitude", mcp.Required()),
+		mcp.WithString("date", mcp.Description("Local date (YYYY-MM-DD). Defaults to today.")),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(photoLight, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lat, err := r.RequireFloat("latitude")
+		if err != nil {
+			return errorResult(err)
+		}
+		lon, err := r.RequireFloat("longitude")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.PhotoLight(lat, lon, r.GetString("date", ""), r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	trend := mcp.NewTool(
+		"daylight_trend",
+		mcp.WithDescription("Whether daylight is increasing or decreasing: today's and yesterday's day length, the change in minutes, and the next solstice."),
+		mcp.WithNumber("latitude", mcp.Required()),
+		mcp.WithNumber("longitude", mcp.Required()),
+		mcp.WithString("date", mcp.Description("Local date (YYYY-MM-DD). Defaults to today.")),
+		mcp.WithString("timezone"),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(trend, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lat, err := r.RequireFloat("latitude")
+		if err != nil {
+			return errorResult(err)
+		}
+		lon, err := r.RequireFloat("longitude")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.DaylightTrend(lat, lon, r.GetString("date", ""), r.GetString("timezone", ""), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	shadow := mcp.NewTool(
+		"shadow_length",
+		mcp.WithDescription("Shadow length of an object at an instant, the shortest shadow of the day (solar noon) and the longest practical shadows (sun at 5°). Lengths are in the unit of height."),
+		mcp.WithNumber("latitude", mcp.Required()),
+		mcp.WithNumber("longitude", mcp.Required()),
+		mcp.WithNumber("height", mcp.Required(), mcp.Description("Object height in any unit.")),
+		mcp.WithString("datetime", mcp.Description("Defaults to now.")),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(shadow, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lat, err := r.RequireFloat("latitude")
+		if err != nil {
+			return errorResult(err)
+		}
+		lon, err := r.RequireFloat("longitude")
+		if err != nil {
+			return errorResult(err)
+		}
+		height, err := r.RequireFloat("height")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.ShadowLength(lat, lon, height, r.GetString("datetime", ""), r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	season := mcp.NewTool(
+		"season",
+		mcp.WithDescription("Astronomical season for a date and hemisphere, with the equinox/solstice instants that start and end it. Southern-hemisphere seasons are inverted."),
+		mcp.WithString("date", mcp.Description("Defaults to today.")),
+		mcp.WithString("timezone"),
+		mcp.WithString("hemisphere", mcp.Enum("north", "south"), mcp.Description("Default north.")),
+	)
+
+	s.AddTool(season, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := ts.Season(r.GetString("date", ""), r.GetString("timezone", ""), r.GetString("hemisphere", "north"))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	fraction := mcp.NewTool(
+		"daylight_fraction",
+		mcp.WithDescription("Fraction of the 24-hour day that is daylight, with and without civil twilight. Polar day is 1, polar night 0."),
+		mcp.WithNumber("latitude", mcp.Required()),
+		mcp.WithNumber("longitude", mcp.Required()),
+		mcp.WithString("date", mcp.Description("Local date (YYYY-MM-DD). Defaults to today.")),
+		mcp.WithString("timezone"),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(fraction, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lat, err := r.RequireFloat("latitude")
+		if err != nil {
+			return errorResult(err)
+		}
+		lon, err := r.RequireFloat("longitude")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.DaylightFraction(lat, lon, r.GetString("date", ""), r.GetString("timezone", ""), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	average := mcp.NewTool(
+		"daylight_average",
+		mcp.WithDescription("Average daily daylight over a date range with the shortest and longest days. Polar days count as 24h and polar nights as zero."),
+		mcp.WithNumber("latitude", mcp.Required()),
+		mcp.WithNumber("longitude", mcp.Required()),
+		mcp.WithString("start", mcp.Required(), mcp.Description("First local date (YYYY-MM-DD).")),
+		mcp.WithString("end", mcp.Required(), mcp.Description("Last local date, inclusive.")),
+		mcp.WithString("timezone"),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(average, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lat, err := r.RequireFloat("latitude")
+		if err != nil {
+			return errorResult(err)
+		}
+		lon, err := r.RequireFloat("longitude")
+		if err != nil {
+			return errorResult(err)
+		}
+		start, err := r.RequireString("start")
+		if err != nil {
+			return errorResult(err)
+		}
+		end, err := r.RequireString("end")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.DaylightAverage(lat, lon, start, end, r.GetString("timezone", ""), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	altitude := mcp.NewTool(
+		"time_at_sun_altitude",
+		mcp.WithDescription("When the sun reaches a given altitude on the rising or setting side of the day, e.g. -6, -12 or -18 degrees for civil, nautical and astronomical twilight."),
+		mcp.WithNumber("latitude", mcp.Required()),
+		mcp.WithNumber("longitude", mcp.Required()),
+		mcp.WithNumber("altitude", mcp.Required(), mcp.Description("Sun altitude in degrees; negative is below the horizon.")),
+		mcp.WithString("crossing", mcp.Enum("rising", "setting"), mcp.Description("Default rising.")),
+		mcp.WithString("date", mcp.Description("Local date (YYYY-MM-DD). Defaults to today.")),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(altitude, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lat, err := r.RequireFloat("latitude")
+		if err != nil {
+			return errorResult(err)
+		}
+		lon, err := r.RequireFloat("longitude")
+		if err != nil {
+			return errorResult(err)
+		}
+		alt, err := r.RequireFloat("altitude")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.TimeAtSunAltitude(lat, lon, r.GetString("date", ""), alt, r.GetString("crossing", "rising"), r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	meridian := mcp.NewTool(
+		"meridian_offset",
+		mcp.WithDescription("How far a zone's standard UTC offset is from the natural solar offset of a longitude (15° per hour), e.g. why western Spain runs over an hour ahead of the sun."),
+		mcp.WithString("timezone", mcp.Required()),
+		mcp.WithNumber("longitude", mcp.Required(), mcp.Description("Representative longitude, degrees east.")),
+	)
+
+	s.AddTool(meridian, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		tz, err := r.RequireString("timezone")
+		if err != nil {
+			return errorResult(err)
+		}
+		lon, err := r.RequireFloat("longitude")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.MeridianOffset(tz, lon)
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	marker := mcp.NewTool(
+		"seasonal_marker",
+		mcp.WithDescription("Exact instant of an equinox or solstice in a given year, rendered in a timezone (Meeus approximation, about a minute of precision)."),
+		mcp.WithString("marker", mcp.Required(), mcp.Enum(seasonEvents...)),
+		mcp.WithNumber("year", mcp.Description("Defaults to the current year.")),
+		mcp.WithString("timezone"),
+	)
+
+	s.AddTool(marker, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		m, err := r.RequireString("marker")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.SeasonalMarker(r.GetInt("year", 0), m, r.GetString("timezone", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+}
@@ -0,0 +1,433 @@
+// solar_test.go
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// withinMinutes reports whether got is within tol minutes of want.
+func withinMinutes(got, want time.Time, tol float64) bool {
+	d := got.Sub(want)
+	if d < 0 {
+		d = -d
+	}
+	return d.Minutes() <= tol
+}
+
+func TestSunCrossingLondonSolstice(t *testing.T) {
+	locLondon, _ := time.LoadLocation("Europe/London")
+	d := time.Date(2025, time.June, 21, 0, 0, 0, 0, locLondon)
+
+	// Published sunrise/sunset for London on 2025-06-21: 04:43 and 21:21 BST.
+	rise, st := sunCrossing(d, 51.5074, -0.1278, -0.833, true)
+	if st != crossingOK || !withinMinutes(rise, time.Date(2025, 6, 21, 4, 43, 0, 0, locLondon), 2) {
+		t.Errorf("sunrise = %v (status %v), want ~04:43 BST", rise.In(locLondon), st)
+	}
+	set, st := sunCrossing(d, 51.5074, -0.1278, -0.833, false)
+	if st != crossingOK || !withinMinutes(set, time.Date(2025, 6, 21, 21, 21, 0, 0, locLondon), 2) {
+		t.Errorf("sunset = %v (status %v), want ~21:21 BST", set.In(locLondon), st)
+	}
+}
+
+func TestPhotoLight(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	res, err := ts.PhotoLight(51.5074, -0.1278, "2025-03-20", "Europe/London")
+	if err != nil {
+		t.Fatalf("PhotoLight error: %v", err)
+	}
+	for name, iv := range map[string]LightInterval{
+		"morning blue": res.MorningBlueHour, "morning golden": res.MorningGoldenHour,
+		"evening golden": res.EveningGoldenHour, "evening blue": res.EveningBlueHour,
+	} {
+		if iv.Status != "ok" || iv.Start >= iv.End {
+			t.Errorf("%s hour = %+v, want an ok interval", name, iv)
+		}
+	}
+	if res.MorningBlueHour.End != res.MorningGoldenHour.Start {
+		t.Errorf("morning blue hour should end where golden hour starts: %+v / %+v", res.MorningBlueHour, res.MorningGoldenHour)
+	}
+
+	// Tromsø at the June solstice: the sun never drops below -4°, so there is
+	// no blue hour and golden light merges across solar midnight.
+	res, err = ts.PhotoLight(69.6492, 18.9553, "2025-06-21", "Europe/Oslo")
+	if err != nil {
+		t.Fatalf("PhotoLight error: %v", err)
+	}
+	if res.MorningBlueHour.Status != "none" || res.EveningBlueHour.Status != "none" {
+		t.Errorf("expected no blue hour during midnight sun, got %+v / %+v", res.MorningBlueHour, res.EveningBlueHour)
+	}
+	if res.MorningGoldenHour.Status != "merged" {
+		t.Errorf("expected merged golden hour during midnight sun, got %+v", res.MorningGoldenHour)
+	}
+
+	if _, err := ts.PhotoLight(95, 0, "2025-06-21", "UTC"); err == nil {
+		t.Errorf("expected error for invalid latitude, got nil")
+	}
+}
+
+func TestDaylightTrend(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	res, err := ts.DaylightTrend(51.5074, -0.1278, "2025-03-20", "Europe/London", "")
+	if err != nil {
+		t.Fatalf("DaylightTrend error: %v", err)
+	}
+	// Around the equinox London gains close to four minutes a day.
+	if res.Trend != "gaining" || res.DeltaMinutes < 3 || res.DeltaMinutes > 5 {
+		t.Errorf("expected ~4 minutes gained, got %+v", res)
+	}
+	if res.NextSolstice != "2025-06-21" || res.NextSolsticeKind != "june" || res.DaysUntilSolstice != 93 {
+		t.Errorf("next solstice = %s (%s, %d days), want 2025-06-21 (june, 93 days)", res.NextSolstice, res.NextSolsticeKind, res.DaysUntilSolstice)
+	}
+
+	res, err = ts.DaylightTrend(-33.8688, 151.2093, "2025-08-01", "Australia/Sydney", "")
+	if err != nil {
+		t.Fatalf("DaylightTrend error: %v", err)
+	}
+	if res.Trend != "gaining" || res.NextSolsticeKind != "december" {
+		t.Errorf("expected Sydney gaining daylight towards the December solstice, got %+v", res)
+	}
+
+	res, err = ts.DaylightTrend(78.2232, 15.6267, "2025-06-10", "Arctic/Longyearbyen", "")
+	if err != nil {
+		t.Fatalf("DaylightTrend error: %v", err)
+	}
+	if res.Trend != "steady" || res.DayLengthSeconds != 86400 {
+		t.Errorf("expected steady 24h polar day, got %+v", res)
+	}
+}
+
+func TestShadowLength(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	// London at the June solstice: the noon sun stands about 62° high, so a
+	// 1 m pole casts a shadow of roughly 0.53 m.
+	res, err := ts.ShadowLength(51.5074, -0.1278, 1, "2025-06-21 13:02", "Europe/London")
+	if err != nil {
+		t.Fatalf("ShadowLength error: %v", err)
+	}
+	if res.Status != "ok" || math.Abs(res.Length-0.53) > 0.02 {
+		t.Errorf("noon shadow = %s %.3f, want ok ~0.53", res.Status, res.Length)
+	}
+	if res.Shortest.Availability != "ok" || res.Shortest.Length > res.Length+1e-3 {
+		t.Errorf("shortest shadow %+v should not exceed the noon reading %.3f", res.Shortest, res.Length)
+	}
+	if res.MorningLongest.Availability != "ok" || math.Abs(res.MorningLongest.Length-11.43) > 0.01 {
+		t.Errorf("morning longest = %+v, want ~11.43", res.MorningLongest)
+	}
+
+	night, err := ts.ShadowLength(51.5074, -0.1278, 1, "2025-06-21 00:30", "Europe/London")
+	if err != nil {
+		t.Fatalf("ShadowLength error: %v", err)
+	}
+	if night.Status != "night" || night.Length != 0 {
+		t.Errorf("expected night status, got %s %.3f", night.Status, night.Length)
+	}
+
+	// Polar night at Svalbard: no shadow at any time of day.
+	polar, err := ts.ShadowLength(78.22, 15.65, 1, "2025-12-21 12:00", "Arctic/Longyearbyen")
+	if err != nil {
+		t.Fatalf("ShadowLength error: %v", err)
+	}
+	if polar.Shortest.Availability != "none" || polar.EveningLongest.Availability != "none" {
+		t.Errorf("expected no shadows in polar night, got %+v", polar)
+	}
+
+	if _, err := ts.ShadowLength(0, 0, -1, "", "UTC"); err == nil {
+		t.Errorf("expected error for a negative height, got nil")
+	}
+}
+
+func TestSeasonEvents(t *testing.T) {
+	// Published UTC instants (USNO) for 2025.
+	want := []time.Time{
+		time.Date(2025, 3, 20, 9, 1, 0, 0, time.UTC),
+		time.Date(2025, 6, 21, 2, 42, 0, 0, time.UTC),
+		time.Date(2025, 9, 22, 18, 19, 0, 0, time.UTC),
+		time.Date(2025, 12, 21, 15, 3, 0, 0, time.UTC),
+	}
+	for k, w := range want {
+		if got := seasonEvent(2025, k); !withinMinutes(got, w, 2) {
+			t.Errorf("%s 2025 = %s, want %s", seasonEvents[k], got.Format(time.RFC3339), w.Format(time.RFC3339))
+		}
+	}
+}
+
+func TestDeltaT(t *testing.T) {
+	// Tabulated ΔT values (NASA Five Millennium Canon) in seconds.
+	cases := []struct {
+		year, want, tol float64
+	}{
+		{1000, 1570, 20},
+		{1500, 200, 10},
+		{1700, 9, 2},
+		{1900, -3, 1},
+		{1950, 29, 1},
+		{2000, 64, 1},
+		{2100, 202, 10},
+		{3000, 4430, 50},
+	}
+	for _, tc := range cases {
+		if got := deltaT(tc.year); math.Abs(got-tc.want) > tc.tol {
+			t.Errorf("deltaT(%v) = %.1f, want %v±%v", tc.year, got, tc.want, tc.tol)
+		}
+	}
+}
+
+func TestSeason(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	cases := []struct {
+		date, hemisphere string
+		want, startEvent string
+	}{
+		{"2025-05-14", "north", "spring", "march_equinox"},
+		{"2025-05-14", "south", "autumn", "march_equinox"},
+		{"2025-01-10", "north", "winter", "december_solstice"},
+		{"2025-01-10", "south", "summer", "december_solstice"},
+		{"2025-12-25", "north", "winter", "december_solstice"},
+		{"2025-09-22 12:00", "north", "summer", "june_solstice"},
+		{"2025-09-23", "north", "autumn", "september_equinox"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.hemisphere+"/"+tc.date, func(t *testing.T) {
+			res, err := ts.Season(tc.date, "UTC", tc.hemisphere)
+			if err != nil {
+				t.Fatalf("Season error: %v", err)
+			}
+			if res.Season != tc.want || res.StartEvent != tc.startEvent {
+				t.Errorf("Season(%s, %s) = %s from %s, want %s from %s", tc.date, tc.hemisphere, res.Season, res.StartEvent, tc.want, tc.startEvent)
+			}
+		})
+	}
+
+	if _, err := ts.Season("2025-05-14", "UTC", "east"); err == nil {
+		t.Errorf("expected error for an invalid hemisphere, got nil")
+	}
+	for _, date := range []string{"0999-12-31", "3001-01-01"} {
+		if _, err := ts.Season(date, "UTC", "north"); err == nil {
+			t.Errorf("expected error for %s outside 1000-3000, got nil", date)
+		}
+	}
+
+	// Far from the UnixNano range the bounds must still bracket the date.
+	for _, date := range []string{"1000-05-01", "2999-11-30"} {
+		res, err := ts.Season(date, "UTC", "north")
+		if err != nil {
+			t.Fatalf("Season(%s) error: %v", date, err)
+		}
+		if res.Start.Datetime[:4] != date[:4] || res.End.Datetime[:4] != date[:4] {
+			t.Errorf("Season(%s) = %s to %s, want bounds in %s", date, res.Start.Datetime, res.End.Datetime, date[:4])
+		}
+	}
+}
+
+func TestDaylightFraction(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	// On the equator the sun is up just over half the day year-round.
+	for _, date := range []string{"2025-03-20", "2025-06-21", "2025-12-21"} {
+		res, err := ts.DaylightFraction(0, 0, date, "UTC", "")
+		if err != nil {
+			t.Fatalf("DaylightFraction error: %v", err)
+		}
+		if math.Abs(res.DaylightFraction-0.5) > 0.01 || res.CivilDaylightFraction <= res.DaylightFraction {
+			t.Errorf("%s: equator fractions = %+v", date, res)
+		}
+	}
+
+	polarDay, err := ts.DaylightFraction(78.2232, 15.6267, "2025-06-21", "Arctic/Longyearbyen", "")
+	if err != nil {
+		t.Fatalf("DaylightFraction error: %v", err)
+	}
+	if polarDay.DaylightFraction != 1 || polarDay.Polar != "day" {
+		t.Errorf("polar day = %+v", polarDay)
+	}
+
+	polarNight, err := ts.DaylightFraction(78.2232, 15.6267, "2025-12-21", "Arctic/Longyearbyen", "")
+	if err != nil {
+		t.Fatalf("DaylightFraction error: %v", err)
+	}
+	if polarNight.DaylightFraction != 0 || polarNight.CivilDaylightFraction != 0 || polarNight.Polar != "night" {
+		t.Errorf("polar night = %+v", polarNight)
+	}
+}
+
+func TestDaylightAverage(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	// A full year at the equator averages just over 12 hours.
+	res, err := ts.DaylightAverage(0, 0, "2025-01-01", "2025-12-31", "UTC", "")
+	if err != nil {
+		t.Fatalf("DaylightAverage error: %v", err)
+	}
+	if res.Days != 365 || res.AverageHours < 12 || res.AverageHours > 12.2 {
+		t.Errorf("equator average = %+v", res)
+	}
+
+	// Longyearbyen from late October into November passes from short days
+	// into polar night, which must pull the average down rather than be skipped.
+	polar, err := ts.DaylightAverage(78.2232, 15.6267, "2025-10-20", "2025-11-10", "Arctic/Longyearbyen", "")
+	if err != nil {
+		t.Fatalf("DaylightAverage error: %v", err)
+	}
+	if polar.PolarNights == 0 || polar.Shortest.DayLengthSeconds != 0 || polar.Longest.Date != "2025-10-20" {
+		t.Errorf("polar night average = %+v", polar)
+	}
+	want := (polar.Longest.DayLengthSeconds) * float64(polar.Days-polar.PolarNights) / float64(polar.Days)
+	if polar.AverageSeconds <= 0 || polar.AverageSeconds > want {
+		t.Errorf("average %v exceeds the bound %v", polar.AverageSeconds, want)
+	}
+
+	if _, err := ts.DaylightAverage(0, 0, "2025-02-01", "2025-01-01", "UTC", ""); err == nil {
+		t.Errorf("expected error for a reversed range, got nil")
+	}
+}
+
+func TestTimeAtSunAltitude(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	locLondon, _ := time.LoadLocation("Europe/London")
+
+	// At -0.833° the setting crossing is sunset, ~21:21 BST on the solstice;
+	// civil dusk follows roughly three quarters of an hour later.
+	sunset, err := ts.TimeAtSunAltitude(51.5074, -0.1278, "2025-06-21", -0.833, "setting", "Europe/London")
+	if err != nil {
+		t.Fatalf("TimeAtSunAltitude error: %v", err)
+	}
+	res, err := ts.TimeAtSunAltitude(51.5074, -0.1278, "2025-06-21", -6, "setting", "Europe/London")
+	if err != nil {
+		t.Fatalf("TimeAtSunAltitude error: %v", err)
+	}
+	if sunset.Status != "ok" || res.Status != "ok" {
+		t.Fatalf("sunset = %+v, civil dusk = %+v", sunset, res)
+	}
+	set, _ := time.Parse(time.RFC3339, sunset.Time.Datetime)
+	dusk, _ := time.Parse(time.RFC3339, res.Time.Datetime)
+	if !withinMinutes(set, time.Date(2025, 6, 21, 21, 21, 0, 0, locLondon), 2) {
+		t.Errorf("sunset = %s, want ~21:21 BST", sunset.Time.Datetime)
+	}
+	if gap := dusk.Sub(set); gap < 40*time.Minute || gap > 55*time.Minute {
+		t.Errorf("civil dusk %s is %v after sunset", res.Time.Datetime, gap)
+	}
+
+	cases := []struct {
+		name     string
+		lat, alt float64
+		date     string
+		want     string
+	}{
+		{"noAstronomicalNight", 51.5074, -18, "2025-06-21", "always_above"},
+		{"tooHigh", 51.5074, 70, "2025-06-21", "never_reached"},
+		{"polarDay", 78.2232, -0.833, "2025-06-21", "always_above"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.TimeAtSunAltitude(tc.lat, 15, tc.date, tc.alt, "rising", "UTC")
+			if err != nil {
+				t.Fatalf("TimeAtSunAltitude error: %v", err)
+			}
+			if res.Status != tc.want || res.Time != nil {
+				t.Errorf("got %+v, want status %s", res, tc.want)
+			}
+		})
+	}
+
+	if _, err := ts.TimeAtSunAltitude(0, 0, "", 95, "rising", "UTC"); err == nil {
+		t.Errorf("expected error for an altitude above 90, got nil")
+	}
+}
+
+func TestMeridianOffset(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC) })
+
+	// Vigo, Galicia (8.72°W) keeps Central European Time: +60 minutes plus
+	// 34.9 minutes of longitude, and another hour in summer.
+	res, err := ts.MeridianOffset("Europe/Madrid", -8.72)
+	if err != nil {
+		t.Fatalf("MeridianOffset error: %v", err)
+	}
+	if res.StandardOffset != "+01:00" || res.StandardMeridian != 15 || res.DifferenceMinutes != 94.9 ||
+		res.Assessment != "ahead of the sun" || res.CurrentDifferenceMinutes != 154.9 {
+		t.Errorf("Vigo = %+v", res)
+	}
+
+	// India Standard Time is defined on the 82.5°E meridian.
+	ist, err := ts.MeridianOffset("Asia/Kolkata", 82.5)
+	if err != nil {
+		t.Fatalf("MeridianOffset error: %v", err)
+	}
+	if ist.DifferenceMinutes != 0 || ist.Assessment != "aligned" {
+		t.Errorf("IST meridian = %+v", ist)
+	}
+
+	// Southern hemisphere DST falls in January; the standard offset must not.
+	syd, err := ts.MeridianOffset("Australia/Sydney", 151.2)
+	if err != nil {
+		t.Fatalf("MeridianOffset error: %v", err)
+	}
+	if syd.StandardOffset != "+10:00" || syd.Assessment != "behind the sun" {
+		t.Errorf("Sydney = %+v", syd)
+	}
+}
+
+func TestSeasonalMarker(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC) })
+
+	// Published instants (USNO): 2024 June solstice 20:51 UTC on June 20,
+	// 2030 March equinox 13:52 UTC on March 20.
+	cases := []struct {
+		year   int
+		marker string
+		want   time.Time
+	}{
+		{0, "june_solstice", time.Date(2024, 6, 20, 20, 51, 0, 0, time.UTC)},
+		{2030, "march_equinox", time.Date(2030, 3, 20, 13, 52, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		res, err := ts.SeasonalMarker(tc.year, tc.marker, "Asia/Tokyo")
+		if err != nil {
+			t.Fatalf("SeasonalMarker error: %v", err)
+		}
+		got, _ := time.Parse(time.RFC3339, res.Instant.Datetime)
+		if !withinMinutes(got, tc.want, 2) || !strings.HasSuffix(res.Instant.Datetime, "+09:00") {
+			t.Errorf("%s %d = %s, want %s", tc.marker, tc.year, res.Instant.Datetime, tc.want.Format(time.RFC3339))
+		}
+	}
+
+	// The ends of the accepted range lie outside the span of UnixNano, and
+	// ΔT is only loosely known there, so only the calendar day is checked
+	// (proleptic Gregorian).
+	ends := []struct {
+		year   int
+		marker string
+		want   string
+	}{
+		{1000, "march_equinox", "1000-03-20"},
+		{1600, "december_solstice", "1600-12-21"},
+		{2300, "june_solstice", "2300-06-21"},
+		{3000, "september_equinox", "3000-09-22"},
+	}
+	for _, tc := range ends {
+		res, err := ts.SeasonalMarker(tc.year, tc.marker, "UTC")
+		if err != nil {
+			t.Fatalf("SeasonalMarker(%d) error: %v", tc.year, err)
+		}
+		if !strings.HasPrefix(res.UTC, tc.want) {
+			t.Errorf("%s %d = %s, want on %s", tc.marker, tc.year, res.UTC, tc.want)
+		}
+	}
+
+	if _, err := ts.SeasonalMarker(2025, "midsummer", "UTC"); err == nil {
+		t.Errorf("expected error for an unknown marker, got nil")
+	}
+	for _, year := range []int{500, 999, 3001} {
+		if _, err := ts.SeasonalMarker(year, "june_solstice", "UTC"); err == nil {
+			t.Errorf("expected error for year %d outside 1000-3000, got nil", year)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !embed_tzdata
+
+package main
+
+// Without the embed_tzdata tag the binary relies on the host's tzdata.
+
+const embeddedTZData = false
+
+var embeddedZoneNames []string
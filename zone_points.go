@@ -0,0 +1,360 @@
+// zone_points.go
+
+package main
+
+// zonePoint is a reference location known to lie in an IANA zone.
+type zonePoint struct {
+	Lat, Lon float64
+	Zone     string
+}
+
+// zonePoints is a coarse stand-in for real timezone boundary polygons: one
+// or more populated places per zone, with extra points along borders that
+// are commonly queried (US, Canada, Brazil, Russia, Australia). Nearest-point
+// lookup is right for most populated areas but can be wrong within roughly
+// 100-200 km of a zone boundary.
+var zonePoints = []zonePoint{
+	// North America
+	{40.71, -74.01, "America/New_York"},
+	{42.36, -71.06, "America/New_York"},
+	{39.95, -75.17, "America/New_York"},
+	{38.91, -77.04, "America/New_York"},
+	{33.75, -84.39, "America/New_York"},
+	{25.76, -80.19, "America/New_York"},
+	{28.54, -81.38, "America/New_York"},
+	{35.23, -80.84, "America/New_York"},
+	{41.50, -81.69, "America/New_York"},
+	{40.44, -79.99, "America/New_York"},
+	{42.89, -78.88, "America/New_York"},
+	{39.96, -83.00, "America/New_York"},
+	{42.33, -83.05, "America/Detroit"},
+	{42.96, -85.67, "America/Detroit"},
+	{39.77, -86.16, "America/Indiana/Indianapolis"},
+	{38.25, -85.76, "America/Kentucky/Louisville"},
+	{41.88, -87.63, "America/Chicago"},
+	{29.76, -95.37, "America/Chicago"},
+	{32.78, -96.80, "America/Chicago"},
+	{29.42, -98.49, "America/Chicago"},
+	{30.27, -97.74, "America/Chicago"},
+	{44.98, -93.27, "America/Chicago"},
+	{38.63, -90.20, "America/Chicago"},
+	{39.10, -94.58, "America/Chicago"},
+	{29.95, -90.07, "America/Chicago"},
+	{36.16, -86.78, "America/Chicago"},
+	{35.15, -90.05, "America/Chicago"},
+	{43.04, -87.91, "America/Chicago"},
+	{35.47, -97.52, "America/Chicago"},
+	{41.26, -95.93, "America/Chicago"},
+	{46.88, -96.79, "America/Chicago"},
+	{39.74, -104.99, "America/Denver"},
+	{40.76, -111.89, "America/Denver"},
+	{35.08, -106.65, "America/Denver"},
+	{31.76, -106.49, "America/Denver"},
+	{46.59, -112.04, "America/Denver"},
+	{41.14, -104.82, "America/Denver"},
+	{43.62, -116.20, "America/Boise"},
+	{33.45, -112.07, "America/Phoenix"},
+	{32.22, -110.97, "America/Phoenix"},
+	{34.05, -118.24, "America/Los_Angeles"},
+	{37.77, -122.42, "America/Los_Angeles"},
+	{32.72, -117.16, "America/Los_Angeles"},
+	{47.61, -122.33, "America/Los_Angeles"},
+	{45.52, -122.68, "America/Los_Angeles"},
+	{36.17, -115.14, "America/Los_Angeles"},
+	{38.58, -121.49, "America/Los_Angeles"},
+	{47.66, -117.43, "America/Los_Angeles"},
+	{61.22, -149.90, "America/Anchorage"},
+	{64.84, -147.72, "America/Anchorage"},
+	{58.30, -134.42, "America/Juneau"},
+	{21.31, -157.86, "Pacific/Honolulu"},
+	{43.65, -79.38, "America/Toronto"},
+	{45.42, -75.70, "America/Toronto"},
+	{45.50, -73.57, "America/Toronto"},
+	{46.81, -71.21, "America/Toronto"},
+	{49.90, -97.14, "America/Winnipeg"},
+	{50.45, -104.61, "America/Regina"},
+	{51.05, -114.07, "America/Edmonton"},
+	{53.55, -113.49, "America/Edmonton"},
+	{49.28, -123.12, "America/Vancouver"},
+	{48.43, -123.37, "America/Vancouver"},
+	{44.65, -63.58, "America/Halifax"},
+	{45.96, -66.64, "America/Moncton"},
+	{47.56, -52.71, "America/St_Johns"},
+	{60.72, -135.06, "America/Whitehorse"},
+	{62.45, -114.37, "America/Yellowknife"},
+	{63.75, -68.52, "America/Iqaluit"},
+	{64.18, -51.72, "America/Nuuk"},
+	{19.43, -99.13, "America/Mexico_City"},
+	{20.67, -103.35, "America/Mexico_City"},
+	{25.69, -100.32, "America/Monterrey"},
+	{21.16, -86.85, "America/Cancun"},
+	{32.51, -117.04, "America/Tijuana"},
+	{29.07, -110.96, "America/Hermosillo"},
+	{28.63, -106.09, "America/Chihuahua"},
+	{23.23, -106.42, "America/Mazatlan"},
+	// Central America and Caribbean
+	{14.63, -90.51, "America/Guatemala"},
+	{13.69, -89.22, "America/El_Salvador"},
+	{14.07, -87.19, "America/Tegucigalpa"},
+	{12.11, -86.24, "America/Managua"},
+	{9.93, -84.09, "America/Costa_Rica"},
+	{8.98, -79.52, "America/Panama"},
+	{23.11, -82.37, "America/Havana"},
+	{18.47, -69.90, "America/Santo_Domingo"},
+	{18.47, -66.11, "America/Puerto_Rico"},
+	{18.02, -76.80, "America/Jamaica"},
+	{18.54, -72.34, "America/Port-au-Prince"},
+	{25.05, -77.34, "America/Nassau"},
+	{10.65, -61.51, "America/Port_of_Spain"},
+	{13.10, -59.61, "America/Barbados"},
+	// South America
+	{4.71, -74.07, "America/Bogota"},
+	{10.48, -66.90, "America/Caracas"},
+	{-0.18, -78.47, "America/Guayaquil"},
+	{-12.05, -77.04, "America/Lima"},
+	{-16.50, -68.15, "America/La_Paz"},
+	{-33.45, -70.67, "America/Santiago"},
+	{-34.60, -58.38, "America/Argentina/Buenos_Aires"},
+	{-31.42, -64.18, "America/Argentina/Cordoba"},
+	{-32.89, -68.83, "America/Argentina/Mendoza"},
+	{-34.90, -56.16, "America/Montevideo"},
+	{-25.26, -57.58, "America/Asuncion"},
+	{-23.55, -46.63, "America/Sao_Paulo"},
+	{-22.91, -43.17, "America/Sao_Paulo"},
+	{-15.79, -47.88, "America/Sao_Paulo"},
+	{-19.92, -43.94, "America/Sao_Paulo"},
+	{-30.03, -51.23, "America/Sao_Paulo"},
+	{-12.97, -38.50, "America/Bahia"},
+	{-8.05, -34.88, "America/Recife"},
+	{-3.73, -38.52, "America/Fortaleza"},
+	{-1.46, -48.50, "America/Belem"},
+	{-3.12, -60.02, "America/Manaus"},
+	{-15.60, -56.10, "America/Cuiaba"},
+	{-8.76, -63.90, "America/Porto_Velho"},
+	{-9.97, -67.81, "America/Rio_Branco"},
+	{5.85, -55.20, "America/Paramaribo"},
+	{6.80, -58.16, "America/Guyana"},
+	{4.94, -52.33, "America/Cayenne"},
+	// Europe
+	{51.51, -0.13, "Europe/London"},
+	{53.48, -2.24, "Europe/London"},
+	{55.95, -3.19, "Europe/London"},
+	{53.35, -6.26, "Europe/Dublin"},
+	{38.72, -9.14, "Europe/Lisbon"},
+	{40.42, -3.70, "Europe/Madrid"},
+	{41.39, 2.17, "Europe/Madrid"},
+	{48.86, 2.35, "Europe/Paris"},
+	{43.30, 5.37, "Europe/Paris"},
+	{45.76, 4.84, "Europe/Paris"},
+	{50.85, 4.35, "Europe/Brussels"},
+	{52.37, 4.90, "Europe/Amsterdam"},
+	{49.61, 6.13, "Europe/Luxembourg"},
+	{52.52, 13.40, "Europe/Berlin"},
+	{48.14, 11.58, "Europe/Berlin"},
+	{50.11, 8.68, "Europe/Berlin"},
+	{53.55, 9.99, "Europe/Berlin"},
+	{47.37, 8.54, "Europe/Zurich"},
+	{46.20, 6.14, "Europe/Zurich"},
+	{48.21, 16.37, "Europe/Vienna"},
+	{41.90, 12.50, "Europe/Rome"},
+	{45.46, 9.19, "Europe/Rome"},
+	{40.85, 14.27, "Europe/Rome"},
+	{35.90, 14.51, "Europe/Malta"},
+	{55.68, 12.57, "Europe/Copenhagen"},
+	{59.91, 10.75, "Europe/Oslo"},
+	{60.39, 5.32, "Europe/Oslo"},
+	{59.33, 18.07, "Europe/Stockholm"},
+	{60.17, 24.94, "Europe/Helsinki"},
+	{64.15, -21.94, "Atlantic/Reykjavik"},
+	{52.23, 21.01, "Europe/Warsaw"},
+	{50.06, 19.94, "Europe/Warsaw"},
+	{50.08, 14.44, "Europe/Prague"},
+	{48.15, 17.11, "Europe/Bratislava"},
+	{47.50, 19.04, "Europe/Budapest"},
+	{46.06, 14.51, "Europe/Ljubljana"},
+	{45.81, 15.98, "Europe/Zagreb"},
+	{44.79, 20.45, "Europe/Belgrade"},
+	{43.86, 18.41, "Europe/Sarajevo"},
+	{42.00, 21.43, "Europe/Skopje"},
+	{41.33, 19.82, "Europe/Tirane"},
+	{44.43, 26.10, "Europe/Bucharest"},
+	{42.70, 23.32, "Europe/Sofia"},
+	{37.98, 23.73, "Europe/Athens"},
+	{35.17, 33.36, "Asia/Nicosia"},
+	{41.01, 28.98, "Europe/Istanbul"},
+	{39.93, 32.86, "Europe/Istanbul"},
+	{47.01, 28.86, "Europe/Chisinau"},
+	{50.45, 30.52, "Europe/Kyiv"},
+	{49.99, 36.23, "Europe/Kyiv"},
+	{53.90, 27.57, "Europe/Minsk"},
+	{54.69, 25.28, "Europe/Vilnius"},
+	{56.95, 24.11, "Europe/Riga"},
+	{59.44, 24.75, "Europe/Tallinn"},
+	{54.71, 20.51, "Europe/Kaliningrad"},
+	{55.76, 37.62, "Europe/Moscow"},
+	{59.93, 30.34, "Europe/Moscow"},
+	{56.33, 44.00, "Europe/Moscow"},
+	{55.80, 49.11, "Europe/Moscow"},
+	{53.20, 50.15, "Europe/Samara"},
+	{48.71, 44.51, "Europe/Volgograd"},
+	{56.84, 60.61, "Asia/Yekaterinburg"},
+	{55.16, 61.40, "Asia/Yekaterinburg"},
+	{54.99, 73.37, "Asia/Omsk"},
+	{55.01, 82.93, "Asia/Novosibirsk"},
+	{56.01, 92.89, "Asia/Krasnoyarsk"},
+	{52.29, 104.28, "Asia/Irkutsk"},
+	{62.03, 129.73, "Asia/Yakutsk"},
+	{43.12, 131.89, "Asia/Vladivostok"},
+	{48.48, 135.08, "Asia/Vladivostok"},
+	{59.57, 150.80, "Asia/Magadan"},
+	{53.02, 158.65, "Asia/Kamchatka"},
+	// Middle East, Caucasus and Central Asia
+	{41.72, 44.79, "Asia/Tbilisi"},
+	{40.18, 44.51, "Asia/Yerevan"},
+	{40.41, 49.87, "Asia/Baku"},
+	{35.69, 51.39, "Asia/Tehran"},
+	{33.31, 44.37, "Asia/Baghdad"},
+	{33.51, 36.28, "Asia/Damascus"},
+	{33.89, 35.50, "Asia/Beirut"},
+	{31.95, 35.93, "Asia/Amman"},
+	{31.77, 35.21, "Asia/Jerusalem"},
+	{32.09, 34.78, "Asia/Jerusalem"},
+	{24.71, 46.68, "Asia/Riyadh"},
+	{21.49, 39.19, "Asia/Riyadh"},
+	{29.38, 47.99, "Asia/Kuwait"},
+	{26.23, 50.59, "Asia/Bahrain"},
+	{25.29, 51.53, "Asia/Qatar"},
+	{25.20, 55.27, "Asia/Dubai"},
+	{23.59, 58.41, "Asia/Muscat"},
+	{15.37, 44.19, "Asia/Aden"},
+	{41.30, 69.24, "Asia/Tashkent"},
+	{43.24, 76.89, "Asia/Almaty"},
+	{51.17, 71.45, "Asia/Almaty"},
+	{42.87, 74.59, "Asia/Bishkek"},
+	{38.56, 68.77, "Asia/Dushanbe"},
+	{37.95, 58.38, "Asia/Ashgabat"},
+	{34.53, 69.17, "Asia/Kabul"},
+	// South and East Asia
+	{24.86, 67.01, "Asia/Karachi"},
+	{31.55, 74.34, "Asia/Karachi"},
+	{28.61, 77.21, "Asia/Kolkata"},
+	{19.08, 72.88, "Asia/Kolkata"},
+	{12.97, 77.59, "Asia/Kolkata"},
+	{22.57, 88.36, "Asia/Kolkata"},
+	{13.08, 80.27, "Asia/Kolkata"},
+	{27.72, 85.32, "Asia/Kathmandu"},
+	{23.81, 90.41, "Asia/Dhaka"},
+	{27.47, 89.64, "Asia/Thimphu"},
+	{6.93, 79.85, "Asia/Colombo"},
+	{4.18, 73.51, "Indian/Maldives"},
+	{16.87, 96.20, "Asia/Yangon"},
+	{13.76, 100.50, "Asia/Bangkok"},
+	{17.97, 102.63, "Asia/Vientiane"},
+	{11.56, 104.92, "Asia/Phnom_Penh"},
+	{10.82, 106.63, "Asia/Ho_Chi_Minh"},
+	{21.03, 105.85, "Asia/Bangkok"},
+	{3.14, 101.69, "Asia/Kuala_Lumpur"},
+	{1.35, 103.82, "Asia/Singapore"},
+	{-6.21, 106.85, "Asia/Jakarta"},
+	{-7.25, 112.75, "Asia/Jakarta"},
+	{-0.03, 109.33, "Asia/Pontianak"},
+	{-8.65, 115.22, "Asia/Makassar"},
+	{-5.15, 119.43, "Asia/Makassar"},
+	{-2.53, 140.72, "Asia/Jayapura"},
+	{14.60, 120.98, "Asia/Manila"},
+	{10.32, 123.89, "Asia/Manila"},
+	{4.89, 114.94, "Asia/Brunei"},
+	{22.32, 114.17, "Asia/Hong_Kong"},
+	{22.20, 113.54, "Asia/Macau"},
+	{25.03, 121.57, "Asia/Taipei"},
+	{39.90, 116.41, "Asia/Shanghai"},
+	{31.23, 121.47, "Asia/Shanghai"},
+	{23.13, 113.26, "Asia/Shanghai"},
+	{30.57, 104.07, "Asia/Shanghai"},
+	{34.34, 108.94, "Asia/Shanghai"},
+	{43.83, 87.62, "Asia/Urumqi"},
+	{47.89, 106.91, "Asia/Ulaanbaatar"},
+	{39.04, 125.76, "Asia/Pyongyang"},
+	{37.57, 126.98, "Asia/Seoul"},
+	{35.18, 129.08, "Asia/Seoul"},
+	{35.68, 139.69, "Asia/Tokyo"},
+	{34.69, 135.50, "Asia/Tokyo"},
+	{43.06, 141.35, "Asia/Tokyo"},
+	{33.59, 130.40, "Asia/Tokyo"},
+	{26.21, 127.68, "Asia/Tokyo"},
+	// Africa
+	{30.04, 31.24, "Africa/Cairo"},
+	{32.89, 13.19, "Africa/Tripoli"},
+	{36.81, 10.18, "Africa/Tunis"},
+	{36.75, 3.06, "Africa/Algiers"},
+	{33.57, -7.59, "Africa/Casablanca"},
+	{15.50, 32.56, "Africa/Khartoum"},
+	{9.03, 38.74, "Africa/Addis_Ababa"},
+	{-1.29, 36.82, "Africa/Nairobi"},
+	{-6.79, 39.21, "Africa/Dar_es_Salaam"},
+	{0.35, 32.58, "Africa/Kampala"},
+	{-1.95, 30.06, "Africa/Kigali"},
+	{2.05, 45.32, "Africa/Mogadishu"},
+	{-4.32, 15.31, "Africa/Kinshasa"},
+	{-11.66, 27.48, "Africa/Lubumbashi"},
+	{-8.84, 13.23, "Africa/Luanda"},
+	{-15.39, 28.32, "Africa/Lusaka"},
+	{-17.83, 31.05, "Africa/Harare"},
+	{-25.97, 32.57, "Africa/Maputo"},
+	{-26.20, 28.05, "Africa/Johannesburg"},
+	{-33.92, 18.42, "Africa/Johannesburg"},
+	{-22.56, 17.08, "Africa/Windhoek"},
+	{-24.65, 25.91, "Africa/Gaborone"},
+	{-18.88, 47.51, "Indian/Antananarivo"},
+	{-20.16, 57.50, "Indian/Mauritius"},
+	{6.52, 3.38, "Africa/Lagos"},
+	{9.08, 7.40, "Africa/Lagos"},
+	{5.60, -0.19, "Africa/Accra"},
+	{5.36, -4.01, "Africa/Abidjan"},
+	{14.72, -17.47, "Africa/Dakar"},
+	{12.64, -8.00, "Africa/Bamako"},
+	{12.37, -1.52, "Africa/Ouagadougou"},
+	{13.51, 2.11, "Africa/Niamey"},
+	{12.13, 15.06, "Africa/Ndjamena"},
+	{3.85, 11.50, "Africa/Douala"},
+	{0.39, 9.45, "Africa/Libreville"},
+	{8.48, -13.23, "Africa/Freetown"},
+	{6.30, -10.80, "Africa/Monrovia"},
+	{28.12, -15.43, "Atlantic/Canary"},
+	{14.93, -23.51, "Atlantic/Cape_Verde"},
+	// Oceania
+	{-33.87, 151.21, "Australia/Sydney"},
+	{-35.28, 149.13, "Australia/Sydney"},
+	{-37.81, 144.96, "Australia/Melbourne"},
+	{-42.88, 147.33, "Australia/Hobart"},
+	{-27.47, 153.03, "Australia/Brisbane"},
+	{-19.26, 146.82, "Australia/Brisbane"},
+	{-34.93, 138.60, "Australia/Adelaide"},
+	{-31.95, 140.47, "Australia/Broken_Hill"},
+	{-12.46, 130.84, "Australia/Darwin"},
+	{-23.70, 133.88, "Australia/Darwin"},
+	{-31.95, 115.86, "Australia/Perth"},
+	{-20.31, 118.58, "Australia/Perth"},
+	{-36.85, 174.76, "Pacific/Auckland"},
+	{-41.29, 174.78, "Pacific/Auckland"},
+	{-43.53, 172.64, "Pacific/Auckland"},
+	{-43.95, -176.55, "Pacific/Chatham"},
+	{-9.44, 147.18, "Pacific/Port_Moresby"},
+	{-9.43, 159.95, "Pacific/Guadalcanal"},
+	{-22.28, 166.46, "Pacific/Noumea"},
+	{-17.73, 168.32, "Pacific/Efate"},
+	{-18.14, 178.44, "Pacific/Fiji"},
+	{-21.14, -175.20, "Pacific/Tongatapu"},
+	{-13.83, -171.76, "Pacific/Apia"},
+	{-17.54, -149.57, "Pacific/Tahiti"},
+	{13.44, 144.79, "Pacific/Guam"},
+	{7.09, 171.38, "Pacific/Majuro"},
+	{1.45, 172.98, "Pacific/Tarawa"},
+	{-21.21, -159.78, "Pacific/Rarotonga"},
+	{-27.11, -109.35, "Pacific/Easter"},
+	{-0.90, -89.61, "Pacific/Galapagos"},
+	{-51.69, -57.86, "Atlantic/Stanley"},
+	{32.29, -64.78, "Atlantic/Bermuda"},
+	{37.74, -25.67, "Atlantic/Azores"},
+	{32.65, -16.91, "Atlantic/Madeira"},
+}
@@ -0,0 +1,1703 @@
+// zones.go
+
+package main
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+/* ----- data types ----- */
+
+type OffsetSegment struct {
+	Start         string `json:"start"`
+	End           string `json:"end"`
+	UTCOffset     string `json:"utc_offset"`
+	OffsetSeconds int    `json:"offset_seconds"`
+	Abbreviation  string `json:"abbreviation"`
+	IsDST         bool   `json:"is_dst"`
+}
+
+type OffsetTimelineResult struct {
+	Timezone string          `json:"timezone"`
+	Segments []OffsetSegment `json:"segments"`
+}
+
+// wallClock is a local date and time without a zone.
+type wallClock struct {
+	Year                         int
+	Month                        time.Month
+	Day, Hour, Minute, Sec, Nsec int
+}
+
+type LocalizeResult struct {
+	Input      string       `json:"input"`
+	Timezone   string       `json:"timezone"`
+	Status     string       `json:"status"`             // "ok", "ambiguous" or "nonexistent"
+	Datetime   string       `json:"datetime,omitempty"` // set only when the wall time is unique
+	Candidates []TimeResult `json:"candidates,omitempty"`
+	Shifted    *TimeResult  `json:"shifted,omitempty"` // nonexistent times pushed past the gap
+	Note       string       `json:"note,omitempty"`
+}
+
+type ResolveWallclockResult struct {
+	Input    string     `json:"input"`
+	Timezone string     `json:"timezone"`
+	Fold     int        `json:"fold"`
+	Status   string     `json:"status"` // "ok", "ambiguous" or "nonexistent"
+	UTC      string     `json:"utc"`
+	Local    TimeResult `json:"local"`
+	Offset   string     `json:"offset"`
+	Note     string     `json:"note,omitempty"`
+}
+
+type PhotoTimeResult struct {
+	CaptureTime    string     `json:"capture_time"`
+	Timezone       string     `json:"timezone"`
+	UTC            string     `json:"utc"`
+	Local          TimeResult `json:"local"`
+	Ambiguous      bool       `json:"ambiguous"`
+	Nonexistent    bool       `json:"nonexistent"`
+	AlternativeUTC string     `json:"alternative_utc,omitempty"` // the later reading of an ambiguous time
+	Warning        string     `json:"warning,omitempty"`
+}
+
+type MemberOffset struct {
+	Timezone      string `json:"timezone"`
+	Offset        string `json:"offset"`
+	OffsetSeconds int    `json:"offset_seconds"`
+	IsDST         bool   `json:"is_dst"`
+	ObservesDST   bool   `json:"observes_dst"`
+}
+
+type TeamSpreadResult struct {
+	ComputedAt      string         `json:"computed_at"`
+	Members         []MemberOffset `json:"members"`
+	MinOffset       string         `json:"min_offset"`
+	MinZones        []string       `json:"min_zones"`
+	MaxOffset       string         `json:"max_offset"`
+	MaxZones        []string       `json:"max_zones"`
+	SpreadHours     float64        `json:"spread_hours"`
+	DistinctOffsets int            `json:"distinct_offsets"`
+	Note            string         `json:"note"`
+}
+
+type SameDateWindowResult struct {
+	Timezones     []string `json:"timezones"`
+	Found         bool     `json:"found"`
+	Date          string   `json:"date,omitempty"`
+	Start         string   `json:"start,omitempty"`
+	End           string   `json:"end,omitempty"`
+	Duration      string   `json:"duration,omitempty"`
+	DurationHours float64  `json:"duration_hours"`
+	ActiveNow     bool     `json:"active_now"`
+	Note          string   `json:"note,omitempty"`
+}
+
+type DSTAffectedRun struct {
+	Date    string   `json:"date"`
+	Kind    string   `json:"kind"`              // "skipped" or "repeated"
+	Fires   []string `json:"fires,omitempty"`   // both instants of a repeated time
+	Shifted string   `json:"shifted,omitempty"` // where time.Date would place a skipped time
+}
+
+type DSTMissedRunsResult struct {
+	Timezone string           `json:"timezone"`
+	FireTime string           `json:"fire_time"`
+	Start    string           `json:"start"`
+	End      string           `json:"end"`
+	Days     int              `json:"days"`
+	Skipped  int              `json:"skipped"`
+	Repeated int              `json:"repeated"`
+	Affected []DSTAffectedRun `json:"affected"`
+}
+
+type DaypartGroup struct {
+	Offset    string   `json:"offset"`
+	LocalTime string   `json:"local_time"`
+	Zones     []string `json:"zones"`
+}
+
+type ZonesInDaypartResult struct {
+	Daypart string         `json:"daypart"`
+	Hours   string         `json:"hours"`
+	Now     string         `json:"now"`
+	Count   int            `json:"count"`
+	Groups  []DaypartGroup `json:"groups"`
+}
+
+// zoneDayparts are the local ranges ZonesInDaypart uses by default. They
+// follow the fuzzy-time dayparts, except that night wraps to 05:00 so a zone
+// at 02:00 counts as being in its night.
+var zoneDayparts = map[string]clockWindow{
+	"morning":   dayparts["morning"],
+	"afternoon": dayparts["afternoon"],
+	"evening":   dayparts["evening"],
+	"night":     {21 * time.Hour, 5 * time.Hour},
+}
+
+type RolloutStep struct {
+	Order    int        `json:"order"`
+	Timezone string     `json:"timezone"`
+	UTC      string     `json:"utc"`
+	Local    TimeResult `json:"local"`
+	Status   string     `json:"status"` // "ok", "ambiguous" or "nonexistent"
+	Note     string     `json:"note,omitempty"`
+}
+
+type RolloutScheduleResult struct {
+	Date       string        `json:"date"`
+	DeployTime string        `json:"deploy_time"`
+	Steps      []RolloutStep `json:"steps"`
+	Span       string        `json:"span"`
+}
+
+type RotationSeat struct {
+	Timezone      string     `json:"timezone"`
+	Local         TimeResult `json:"local"`
+	Inconvenience float64    `json:"inconvenience_hours"`
+}
+
+type RotationMeeting struct {
+	Meeting int            `json:"meeting"`
+	UTC     string         `json:"utc"`
+	Seats   []RotationSeat `json:"participants"`
+}
+
+type RotationTotal struct {
+	Timezone      string  `json:"timezone"`
+	Inconvenience float64 `json:"inconvenience_hours"`
+	WorstCount    int     `json:"worst_count"` // meetings where this seat was hit hardest
+}
+
+type FairRotationResult struct {
+	Meetings []RotationMeeting `json:"meetings"`
+	Totals   []RotationTotal   `json:"totals"`
+	Method   string            `json:"method"`
+}
+
+type MidpointSeat struct {
+	Timezone        string     `json:"timezone"`
+	Local           TimeResult `json:"local"`
+	HoursFromCenter float64    `json:"hours_from_center"` // signed, relative to 13:00 local
+	WithinHours     bool       `json:"within_hours"`
+}
+
+type MidpointTimeResult struct {
+	Date            string         `json:"date"`
+	UTC             string         `json:"utc"`
+	Participants    []MidpointSeat `json:"participants"`
+	BothWithinHours bool           `json:"both_within_hours"`
+	Note            string         `json:"note,omitempty"`
+}
+
+type Observation struct {
+	UTC   string `json:"utc"`
+	Local string `json:"local"`
+}
+
+type ZoneCandidate struct {
+	Timezone string   `json:"timezone"`
+	Offsets  []string `json:"offsets"`
+}
+
+type InferTimezoneResult struct {
+	ObservedOffsets []string        `json:"observed_offsets"`
+	Candidates      []ZoneCandidate `json:"candidates"`
+}
+
+type ZoneFromCoordinatesResult struct {
+	Latitude   float64    `json:"latitude"`
+	Longitude  float64    `json:"longitude"`
+	Timezone   string     `json:"timezone"`
+	Current    TimeResult `json:"current"`
+	MatchedLat float64    `json:"matched_latitude"`
+	MatchedLon float64    `json:"matched_longitude"`
+	DistanceKm float64    `json:"distance_km"`
+	Confidence string     `json:"confidence"`
+	Note       string     `json:"note"`
+}
+
+type ValidateTimezoneResult struct {
+	Timezone  string `json:"timezone"`
+	Valid     bool   `json:"valid"`
+	Canonical string `json:"canonical,omitempty"`
+}
+
+/* ----- helpers ----- */
+
+// zoneAliases maps common legacy names from tzdata's "backward" file to the
+// zone they link to. The standard library resolves links silently, so
+// canonical names for aliases must come from a table. "UTC" is itself a
+// link to Etc/UTC but is left as given, being the name callers expect.
+var zoneAliases = map[string]string{
+	"US/Eastern":           "America/New_York",
+	"US/Central":           "America/Chicago",
+	"US/Mountain":          "America/Denver",
+	"US/Pacific":           "America/Los_Angeles",
+	"US/Alaska":            "America/Anchorage",
+	"US/Hawaii":            "Pacific/Honolulu",
+	"US/Arizona":           "America/Phoenix",
+	"Canada/Eastern":       "America/Toronto",
+	"Canada/Pacific":       "America/Vancouver",
+	"America/Buenos_Aires": "America/Argentina/Buenos_Aires",
+	"America/Indianapolis": "America/Indiana/Indianapolis",
+	"Brazil/East":          "America/Sao_Paulo",
+	"Asia/Calcutta":        "Asia/Kolkata",
+	"Asia/Saigon":          "Asia/Ho_Chi_Minh",
+	"Asia/Katmandu":        "Asia/Kathmandu",
+	"Asia/Rangoon":         "Asia/Yangon",
+	"Asia/Istanbul":        "Europe/Istanbul",
+	"Europe/Kiev":          "Europe/Kyiv",
+	"Europe/Belfast":       "Europe/London",
+	"Australia/ACT":        "Australia/Sydney",
+	"Australia/NSW":        "Australia/Sydney",
+	"Pacific/Samoa":        "Pacific/Pago_Pago",
+	"GB":                   "Europe/London",
+	"Japan":                "Asia/Tokyo",
+	"PRC":                  "Asia/Shanghai",
+	"ROK":                  "Asia/Seoul",
+	"Singapore":            "Asia/Singapore",
+	"Hongkong":             "Asia/Hong_Kong",
+	"Israel":               "Asia/Jerusalem",
+	"Turkey":               "Europe/Istanbul",
+	"Egypt":                "Africa/Cairo",
+	"Iran":                 "Asia/Tehran",
+	"NZ":                   "Pacific/Auckland",
+	"Etc/UCT":              "Etc/UTC",
+	"Etc/Universal":        "Etc/UTC",
+	"Etc/Zulu":             "Etc/UTC",
+	"UCT":                  "Etc/UTC",
+	"Universal":            "Etc/UTC",
+	"Zulu":                 "Etc/UTC",
+}
+
+// friendlyZones maps city names and common abbreviations, keyed as
+// normalised by resolveZone, to IANA zones. Abbreviations name a region,
+// not a fixed offset: "PST" and "PDT" both give America/Los_Angeles, which
+// applies DST by date. Ambiguous ones take their most common reading: CST is
+// US Central (not China), IST is India (not Ireland or Israel), and MST is
+// America/Denver, which observes DST unlike Arizona.
+var friendlyZones = map[string]string{
+	"est": "America/New_York", "edt": "America/New_York", "et": "America/New_York", "eastern": "America/New_York",
+	"cst": "America/Chicago", "cdt": "America/Chicago", "ct": "America/Chicago", "central": "America/Chicago",
+	"mst": "America/Denver", "mdt": "America/Denver", "mt": "America/Denver", "mountain": "America/Denver",
+	"pst": "America/Los_Angeles", "pdt": "America/Los_Angeles", "pt": "America/Los_Angeles", "pacific": "America/Los_Angeles",
+	"akst": "America/Anchorage", "akdt": "America/Anchorage", "hst": "Pacific/Honolulu",
+	"bst": "Europe/London", "cet": "Europe/Paris", "cest": "Europe/Paris", "eet": "Europe/Athens", "eest": "Europe/Athens",
+	"msk": "Europe/Moscow", "ist": "Asia/Kolkata", "sgt": "Asia/Singapore", "hkt": "Asia/Hong_Kong",
+	"jst": "Asia/Tokyo", "kst": "Asia/Seoul", "aest": "Australia/Sydney", "aedt": "Australia/Sydney",
+	"nzst": "Pacific/Auckland", "nzdt": "Pacific/Auckland",
+
+	"new york": "America/New_York", "nyc": "America/New_York", "boston": "America/New_York",
+	"washington dc": "America/New_York", "miami": "America/New_York", "atlanta": "America/New_York",
+	"chicago": "America/Chicago", "dallas": "America/Chicago", "houston": "America/Chicago",
+	"denver": "America/Denver", "phoenix": "America/Phoenix",
+	"los angeles": "America/Los_Angeles", "san francisco": "America/Los_Angeles", "seattle": "America/Los_Angeles",
+	"anchorage": "America/Anchorage", "honolulu": "Pacific/Honolulu",
+	"toronto": "America/Toronto", "vancouver": "America/Vancouver", "mexico city": "America/Mexico_City",
+	"sao paulo": "America/Sao_Paulo", "buenos aires": "America/Argentina/Buenos_Aires",
+	"london": "Europe/London", "dublin": "Europe/Dublin", "lisbon": "Europe/Lisbon",
+	"paris": "Europe/Paris", "berlin": "Europe/Berlin", "madrid": "Europe/Madrid", "rome": "Europe/Rome",
+	"amsterdam": "Europe/Amsterdam", "zurich": "Europe/Zurich", "stockholm": "Europe/Stockholm",
+	"athens": "Europe/Athens", "kyiv": "Europe/Kyiv", "moscow": "Europe/Moscow", "istanbul": "Europe/Istanbul",
+	"cairo": "Africa/Cairo", "johannesburg": "Africa/Johannesburg", "lagos": "Africa/Lagos", "nairobi": "Africa/Nairobi",
+	"dubai": "Asia/Dubai", "mumbai": "Asia/Kolkata", "delhi": "Asia/Kolkata", "new delhi": "Asia/Kolkata",
+	"bangalore": "Asia/Kolkata", "bengaluru": "Asia/Kolkata", "singapore": "Asia/Singapore",
+	"hong kong": "Asia/Hong_Kong", "shanghai": "Asia/Shanghai", "beijing": "Asia/Shanghai",
+	"tokyo": "Asia/Tokyo", "seoul": "Asia/Seoul", "sydney": "Australia/Sydney",
+	"melbourne": "Australia/Melbourne", "auckland": "Pacific/Auckland",
+}
+
+// resolveZone maps a friendly name or abbreviation ("New York", "pst") to
+// its IANA zone via friendlyZones, matching case-insensitively with
+// underscores, dots and repeated spaces ignored. Other names are returned
+// unchanged for time.LoadLocation.
+func resolveZone(name string) string {
+	key := strings.ToLower(strings.Join(strings.Fields(strings.NewReplacer("_", " ", ".", "").Replace(name)), " "))
+	if z, ok := friendlyZones[key]; ok {
+		return z
+	}
+	return name
+}
+
+// earthRadiusKm is the mean Earth radius used for great-circle distances.
+const earthRadiusKm = 6371.0
+
+// maxZonePointKm is the distance beyond which a coordinate is treated as
+// open water and given a nautical Etc/GMT zone instead of the nearest point.
+const maxZonePointKm = 1000
+
+// haversineKm returns the great-circle distance between two coordinates.
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	dLat := deg2rad(lat2 - lat1)
+	dLon := deg2rad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(deg2rad(lat1))*math.Cos(deg2rad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// nauticalZone returns the Etc/GMT zone for a longitude's 15° band. Etc
+// names use POSIX signs, so 75°W (UTC-5) is Etc/GMT+5.
+func nauticalZone(lon float64) string {
+	h := int(math.Round(lon / 15))
+	switch {
+	case h == 0:
+		return "Etc/GMT"
+	case h > 0:
+		return fmt.Sprintf("Etc/GMT-%d", h)
+	}
+	return fmt.Sprintf("Etc/GMT+%d", -h)
+}
+
+// formatOffset renders an offset in seconds east of UTC as ±HH:MM.
+func formatOffset(sec int) string {
+	sign := '+'
+	if sec < 0 {
+		sign = '-'
+		sec = -sec
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, sec/3600, (sec%3600)/60)
+}
+
+// nextOffsetChange returns the first instant after from, and no later than
+// until, at which the UTC offset or abbreviation of from's location changes.
+// It walks zone periods with ZoneBounds, so the scan is bounded by the number
+// of rule changes in the range rather than its length.
+func nextOffsetChange(from, until time.Time) (time.Time, bool) {
+	name, off := from.Zone()
+	cur := from
+	for {
+		_, end := cur.ZoneBounds()
+		if end.IsZero() || end.After(until) {
+			return time.Time{}, false
+		}
+		if n, o := end.Zone(); n != name || o != off {
+			return end, true
+		}
+		cur = end
+	}
+}
+
+// parseNaive parses a zone-less datetime. Inputs that carry an offset are
+// rejected so that they are not silently reinterpreted.
+func parseNaive(s string) (wallClock, error) {
+	s = strings.TrimSpace(s)
+	if _, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return wallClock{}, fmt.Errorf("datetime %s already has a UTC offset", s)
+	}
+	layouts := append([]string{"2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05.999999999"}, dateTimeLayouts...)
+	for _, layout := range layouts {
+		if tm, err := time.Parse(layout, s); err == nil {
+			return wallOf(tm), nil
+		}
+	}
+	return wallClock{}, fmt.Errorf("naive datetime must be YYYY-MM-DD[ HH:MM[:SS]]: %s", s)
+}
+
+// exifDateTime is the EXIF DateTimeOriginal layout, which separates the
+// date with colons.
+const exifDateTime = "2006:01:02 15:04:05"
+
+func wallOf(tm time.Time) wallClock {
+	return wallClock{tm.Year(), tm.Month(), tm.Day(), tm.Hour(), tm.Minute(), tm.Second(), tm.Nanosecond()}
+}
+
+// in builds w in loc with time.Date, which normalizes nonexistent times
+// forward and picks one side of an ambiguous time.
+func (w wallClock) in(loc *time.Location) time.Time {
+	return time.Date(w.Year, w.Month, w.Day, w.Hour, w.Minute, w.Sec, w.Nsec, loc)
+}
+
+func (w wallClock) String() string {
+	return w.in(time.UTC).Format("2006-01-02T15:04:05")
+}
+
+// resolveWall returns every instant whose wall clock in loc equals w, in
+// chronological order. The result is empty for a time skipped by a
+// spring-forward gap and has two entries for a repeated fall-back time.
+func resolveWall(w wallClock, loc *time.Location) []time.Time {
+	asUTC := w.in(time.UTC)
+	seen := map[int]bool{}
+	var out []time.Time
+	// Probe offsets in effect up to ±36h around the wall time; this covers
+	// every real offset (-12h..+14h) and any transition near it.
+	for k := -6; k <= 6; k++ {
+		_, off := asUTC.Add(time.Duration(k) * 6 * time.Hour).In(loc).Zone()
+		if seen[off] {
+			continue
+		}
+		seen[off] = true
+		cand := asUTC.Add(-time.Duration(off) * time.Second).In(loc)
+		if wallOf(cand) == w {
+			out = append(out, cand)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	return out
+}
+
+// resolveGapWall reads a wall time that resolveWall found skipped by a
+// spring-forward gap, using the offset in effect before the gap (fold 0,
+// a clock not yet adjusted) or after it (fold 1). It returns the instant
+// and the offset used, in seconds east of UTC.
+func resolveGapWall(w wallClock, loc *time.Location, fold int) (time.Time, int) {
+	asUTC := w.in(time.UTC)
+	probe := -day
+	if fold == 1 {
+		probe = day
+	}
+	_, off := asUTC.Add(probe).In(loc).Zone()
+	return asUTC.Add(-time.Duration(off) * time.Second).In(loc), off
+}
+
+// zoneDirs are the usual system tzdata locations, checked after $ZONEINFO.
+var zoneDirs = []string{
+	"/usr/share/zoneinfo",
+	"/usr/share/lib/zoneinfo",
+	"/usr/lib/locale/TZ",
+	"/etc/zoneinfo",
+}
+
+var (
+	zoneNamesOnce   sync.Once
+	zoneNamesCache  []string
+	zoneNamesSource string
+)
+
+// zoneNames returns the sorted IANA zone names from the first tzdata source
+// that yields any. The standard library cannot enumerate zones itself, so the
+// zoneinfo tree (or a $ZONEINFO zip) is walked once and cached. Binaries
+// built with embed_tzdata fall back to the embedded list, matching the order
+// in which time.LoadLocation itself consults its sources.
+func zoneNames() []string {
+	zoneNamesOnce.Do(func() {
+		var sources []string
+		if z := os.Getenv("ZONEINFO"); z != "" {
+			sources = append(sources, z)
+		}
+		for _, src := range append(sources, zoneDirs...) {
+			if names := zoneNamesFrom(src); len(names) > 0 {
+				zoneNamesCache, zoneNamesSource = names, src
+				return
+			}
+		}
+		if embeddedTZData {
+			zoneNamesCache, zoneNamesSource = filterZoneNames(embeddedZoneNames), "embedded"
+		}
+	})
+	return zoneNamesCache
+}
+
+// tzdataSource describes where zone data comes from, for the startup log:
+// "system (<path>)", "embedded", or "none" when no database was found.
+func tzdataSource() string {
+	zoneNames()
+	switch zoneNamesSource {
+	case "":
+		return "none"
+	case "embedded":
+		return "embedded"
+	default:
+		return "system (" + zoneNamesSource + ")"
+	}
+}
+
+// ListTimezones returns the sorted IANA zone names, keeping only those that
+// contain filter (case-insensitive) when one is given.
+func (t *TimeServer) ListTimezones(filter string) ([]string, error) {
+	names := zoneNames()
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no timezone database available to list")
+	}
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	out := []string{}
+	for _, n := range names {
+		if strings.Contains(strings.ToLower(n), filter) {
+			out = append(out, n)
+		}
+	}
+	return out, nil
+}
+
+// ValidateTimezone reports whether tz names a usable zone without touching
+// any clock. The canonical name resolves friendly names (see resolveZone)
+// and legacy aliases (US/Eastern -> America/New_York), fixes letter case
+// when the zone list is available (america/new_york), and normalises
+// offsets (UTC+5:30 -> UTC+05:30). An unknown name is not an error: it
+// yields false with no canonical name.
+func (t *TimeServer) ValidateTimezone(tz string) (bool, string, error) {
+	name := strings.TrimSpace(tz)
+	if name == "" {
+		return false, "", fmt.Errorf("timezone is required")
+	}
+	canonical := func(n string) string {
+		if c, ok := zoneAliases[n]; ok {
+			return c
+		}
+		return n
+	}
+	if z := resolveZone(name); z != name {
+		return true, z, nil
+	}
+	if _, err := time.LoadLocation(name); err == nil {
+		return true, canonical(name), nil
+	}
+	for _, n := range zoneNames() {
+		if strings.EqualFold(n, name) {
+			return true, canonical(n), nil
+		}
+	}
+	for alias := range zoneAliases {
+		if strings.EqualFold(alias, name) {
+			return true, canonical(alias), nil
+		}
+	}
+	if loc, ok := fixedOffsetZone(name); ok {
+		return true, loc.String(), nil
+	}
+	return false, "", nil
+}
+
+// zoneNamesFrom lists the loadable zones in a zoneinfo directory or zip.
+func zoneNamesFrom(src string) []string {
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil
+	}
+	var raw []string
+	if info.IsDir() {
+		_ = filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			rel, _ := filepath.Rel(src, path)
+			rel = filepath.ToSlash(rel)
+			if d.IsDir() {
+				if rel == "posix" || rel == "right" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			raw = append(raw, rel)
+			return nil
+		})
+	} else if zr, err := zip.OpenReader(src); err == nil {
+		for _, f := range zr.File {
+			raw = append(raw, f.Name)
+		}
+		zr.Close()
+	}
+	return filterZoneNames(raw)
+}
+
+// filterZoneNames keeps the unique entries of raw that are loadable zone
+// names, sorted.
+func filterZoneNames(raw []string) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, n := range raw {
+		if seen[n] || !isZoneName(n) {
+			continue
+		}
+		if _, err := time.LoadLocation(n); err != nil {
+			continue
+		}
+		seen[n] = true
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isZoneName filters out the non-zone files shipped in zoneinfo trees.
+func isZoneName(n string) bool {
+	if n == "" || n[0] < 'A' || n[0] > 'Z' {
+		return false
+	}
+	switch n {
+	case "SECURITY", "Factory", "README":
+		return false
+	}
+	return !strings.ContainsAny(n, ". ")
+}
+
+// zoneRank orders zone names so that canonical Area/Location zones come
+// before Etc/ zones and legacy aliases such as US/Eastern or EST5EDT.
+func zoneRank(n string) int {
+	area, _, ok := strings.Cut(n, "/")
+	switch {
+	case !ok:
+		return 2
+	case area == "Etc":
+		return 1
+	}
+	switch area {
+	case "Africa", "America", "Antarctica", "Arctic", "Asia", "Atlantic", "Australia", "Europe", "Indian", "Pacific":
+		return 0
+	}
+	return 2
+}
+
+/* ----- core methods ----- */
+
+// InferTimezone returns the zones whose UTC offset matches every observed
+// (UTC instant, local wall time) pair. Observations on both sides of a DST
+// change narrow the result to zones that follow the same rules.
+func (t *TimeServer) InferTimezone(obs []Observation) (InferTimezoneResult, error) {
+	if len(obs) == 0 {
+		return InferTimezoneResult{}, fmt.Errorf("at least one observation is required")
+	}
+	instants := make([]time.Time, len(obs))
+	offsets := make([]int, len(obs))
+	var res InferTimezoneResult
+	for i, o := range obs {
+		u, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(o.UTC))
+		if err != nil {
+			return InferTimezoneResult{}, fmt.Errorf("observation %d: utc must be RFC3339: %s", i, o.UTC)
+		}
+		w, err := parseNaive(o.Local)
+		if err != nil {
+			return InferTimezoneResult{}, fmt.Errorf("observation %d: %w", i, err)
+		}
+		instants[i] = u
+		offsets[i] = int(w.in(time.UTC).Sub(u).Round(time.Minute) / time.Second)
+		res.ObservedOffsets = append(res.ObservedOffsets, formatOffset(offsets[i]))
+	}
+
+	names := zoneNames()
+	if len(names) == 0 {
+		return InferTimezoneResult{}, fmt.Errorf("no timezone database available to search")
+	}
+	for _, n := range names {
+		loc, err := time.LoadLocation(n)
+		if err != nil {
+			continue
+		}
+		match := true
+		for i, u := range instants {
+			if _, off := u.In(loc).Zone(); off != offsets[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			res.Candidates = append(res.Candidates, ZoneCandidate{Timezone: n, Offsets: distinctOffsets(instants, loc)})
+		}
+	}
+	sort.SliceStable(res.Candidates, func(i, j int) bool {
+		return zoneRank(res.Candidates[i].Timezone) < zoneRank(res.Candidates[j].Timezone)
+	})
+	return res, nil
+}
+
+// distinctOffsets lists the offsets loc uses at the given instants.
+func distinctOffsets(instants []time.Time, loc *time.Location) []string {
+	var out []string
+	seen := map[int]bool{}
+	for _, u := range instants {
+		if _, off := u.In(loc).Zone(); !seen[off] {
+			seen[off] = true
+			out = append(out, formatOffset(off))
+		}
+	}
+	return out
+}
+
+// LocalizeNaive interprets a zone-less datetime as local time in tz. Wall
+// times that are skipped or repeated by a DST change are flagged rather than
+// silently resolved.
+func (t *TimeServer) LocalizeNaive(naive, tz string) (LocalizeResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return LocalizeResult{}, err
+	}
+	w, err := parseNaive(naive)
+	if err != nil {
+		return LocalizeResult{}, err
+	}
+
+	res := LocalizeResult{Input: naive, Timezone: tz}
+	cands := resolveWall(w, loc)
+	switch len(cands) {
+	case 0:
+		shifted := makeTimeResult(tz, w.in(loc))
+		res.Status = "nonexistent"
+		res.Shifted = &shifted
+		res.Note = fmt.Sprintf("%s does not exist in %s (skipped by a DST change)", w, tz)
+	case 1:
+		res.Status = "ok"
+		res.Datetime = cands[0].Format(time.RFC3339Nano)
+	default:
+		res.Status = "ambiguous"
+		for _, c := range cands {
+			res.Candidates = append(res.Candidates, makeTimeResult(tz, c))
+		}
+		res.Note = fmt.Sprintf("%s occurs twice in %s (repeated by a DST change)", w, tz)
+	}
+	return res, nil
+}
+
+// ResolveWallclock maps a naive local datetime in tz to one UTC instant,
+// with fold choosing the side of a DST change in the PEP 495 manner: for a
+// repeated fall-back time fold 0 is the first occurrence and fold 1 the
+// second; for a time skipped by spring-forward fold 0 reads it with the
+// offset from before the gap (landing after it) and fold 1 with the offset
+// from after (landing before it). Outside transitions fold has no effect.
+func (t *TimeServer) ResolveWallclock(naive, tz string, fold int) (ResolveWallclockResult, error) {
+	if fold != 0 && fold != 1 {
+		return ResolveWallclockResult{}, fmt.Errorf("fold must be 0 or 1")
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return ResolveWallclockResult{}, err
+	}
+	w, err := parseNaive(naive)
+	if err != nil {
+		return ResolveWallclockResult{}, err
+	}
+
+	res := ResolveWallclockResult{Input: naive, Timezone: tz, Fold: fold, Status: "ok"}
+	var at time.Time
+	cands := resolveWall(w, loc)
+	switch len(cands) {
+	case 0:
+		var off int
+		at, off = resolveGapWall(w, loc, fold)
+		res.Status = "nonexistent"
+		res.Note = fmt.Sprintf("%s is skipped by a DST change in %s; read with offset %s", w, tz, formatOffset(off))
+	case 1:
+		at = cands[0]
+	default:
+		at = cands[fold]
+		res.Status = "ambiguous"
+		res.Note = fmt.Sprintf("%s occurs twice in %s; fold %d selects the %s occurrence", w, tz, fold, []string{"first", "second"}[fold])
+	}
+	_, off := at.Zone()
+	res.UTC = at.UTC().Format(time.RFC3339Nano)
+	res.Local = makeTimeResult(tz, at)
+	res.Offset = formatOffset(off)
+	return res, nil
+}
+
+// OffsetTimeline splits [start, end] into segments of constant UTC offset.
+func (t *TimeServer) OffsetTimeline(tz, start, end string) (OffsetTimelineResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return OffsetTimelineResult{}, err
+	}
+	from, err := t.parseDateTime(start, loc)
+	if err != nil {
+		return OffsetTimelineResult{}, err
+	}
+	until, err := t.parseDateTime(end, loc)
+	if err != nil {
+		return OffsetTimelineResult{}, err
+	}
+	if !from.Before(until) {
+		return OffsetTimelineResult{}, fmt.Errorf("start must be before end")
+	}
+
+	res := OffsetTimelineResult{Timezone: tz}
+	cur := from
+	for cur.Before(until) {
+		segEnd, ok := nextOffsetChange(cur, until)
+		if !ok {
+			segEnd = until
+		}
+		name, off := cur.Zone()
+		res.Segments = append(res.Segments, OffsetSegment{
+			Start:         cur.Format(time.RFC3339),
+			End:           segEnd.Format(time.RFC3339),
+			UTCOffset:     formatOffset(off),
+			OffsetSeconds: off,
+			Abbreviation:  name,
+			IsDST:         cur.IsDST(),
+		})
+		cur = segEnd
+	}
+	return res, nil
+}
+
+// ZoneFromCoordinates maps a coordinate to the zone of the nearest entry in
+// zonePoints. This is an approximation, not a boundary lookup: confidence is
+// graded by distance to the matched point, and coordinates far from any
+// point get a nautical Etc/GMT zone from their longitude.
+func (t *TimeServer) ZoneFromCoordinates(lat, lon float64) (ZoneFromCoordinatesResult, error) {
+	if err := validateCoordinates(lat, lon); err != nil {
+		return ZoneFromCoordinatesResult{}, err
+	}
+	best, bestKm := zonePoints[0], math.Inf(1)
+	for _, p := range zonePoints {
+		if km := haversineKm(lat, lon, p.Lat, p.Lon); km < bestKm {
+			best, bestKm = p, km
+		}
+	}
+
+	res := ZoneFromCoordinatesResult{
+		Latitude:   lat,
+		Longitude:  lon,
+		Timezone:   best.Zone,
+		MatchedLat: best.Lat,
+		MatchedLon: best.Lon,
+		DistanceKm: math.Round(bestKm*10) / 10,
+	}
+	switch {
+	case bestKm > maxZonePointKm:
+		res.Timezone = nauticalZone(lon)
+		res.Confidence = "low"
+		res.Note = fmt.Sprintf("no reference location within %d km; using the nautical zone for this longitude", maxZonePointKm)
+	case bestKm > 500:
+		res.Confidence = "low"
+		res.Note = "nearest reference location is far away; the zone may differ"
+	case bestKm > 150:
+		res.Confidence = "medium"
+		res.Note = "matched by nearest reference location; may be wrong near zone boundaries"
+	default:
+		res.Confidence = "high"
+		res.Note = "matched by nearest reference location; may be wrong within a few dozen km of a zone boundary"
+	}
+	_, loc, err := t.location(res.Timezone)
+	if err != nil {
+		return ZoneFromCoordinatesResult{}, err
+	}
+	res.Current = makeTimeResult(res.Timezone, t.nowFunc().In(loc))
+	return res, nil
+}
+
+// ResolvePhotoTime turns a camera's zone-less capture time (ISO or EXIF
+// "2006:01:02 15:04:05" form) into a UTC instant, assuming the camera clock
+// was set to local time in tz. A time repeated by a fall-back transition
+// resolves to its first occurrence with the second offered as an
+// alternative; a time skipped by spring-forward is read with the offset
+// from before the gap, as a camera not yet adjusted would have recorded it.
+// Both cases carry a warning so a cataloguing app can ask the user.
+func (t *TimeServer) ResolvePhotoTime(capture, tz string) (PhotoTimeResult, error) {
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return PhotoTimeResult{}, err
+	}
+	var w wallClock
+	if tm, perr := time.Parse(exifDateTime, strings.TrimSpace(capture)); perr == nil {
+		w = wallOf(tm)
+	} else if w, err = parseNaive(capture); err != nil {
+		return PhotoTimeResult{}, err
+	}
+
+	res := PhotoTimeResult{CaptureTime: capture, Timezone: tz}
+	var at time.Time
+	cands := resolveWall(w, loc)
+	switch len(cands) {
+	case 0:
+		at, _ = resolveGapWall(w, loc, 0)
+		res.Nonexistent = true
+		res.Warning = fmt.Sprintf("%s does not exist in %s (skipped by a DST change); assuming the camera clock was not yet adjusted", w, tz)
+	case 1:
+		at = cands[0]
+	default:
+		at = cands[0]
+		res.Ambiguous = true
+		res.AlternativeUTC = cands[len(cands)-1].UTC().Format(time.RFC3339)
+		res.Warning = fmt.Sprintf("%s occurs twice in %s (repeated by a DST change); assuming the first occurrence", w, tz)
+	}
+	res.UTC = at.UTC().Format(time.RFC3339)
+	res.Local = makeTimeResult(tz, at)
+	return res, nil
+}
+
+// TeamSpread summarizes how far apart a team's timezones are at a single
+// instant (datetime, default now): the westernmost and easternmost offsets,
+// the spread between them in hours and how many distinct offsets occur.
+// Because DST moves some members and not others, the spread is only valid
+// for the instant it was computed at; members whose zone uses different
+// offsets in January and July are flagged as observing DST.
+func (t *TimeServer) TeamSpread(zones []string, datetime string) (TeamSpreadResult, error) {
+	if len(zones) == 0 {
+		return TeamSpreadResult{}, fmt.Errorf("timezones must not be empty")
+	}
+	at, err := t.parseDateTime(datetime, time.UTC)
+	if err != nil {
+		return TeamSpreadResult{}, err
+	}
+
+	res := TeamSpreadResult{ComputedAt: at.Format(time.RFC3339)}
+	distinct := map[int]bool{}
+	minOff, maxOff, seasonal := 0, 0, 0
+	for i, z := range zones {
+		tz, loc, err := t.location(strings.TrimSpace(z))
+		if err != nil {
+			return TeamSpreadResult{}, err
+		}
+		local := at.In(loc)
+		_, off := local.Zone()
+		_, jan := time.Date(at.Year(), time.January, 1, 0, 0, 0, 0, loc).Zone()
+		_, jul := time.Date(at.Year(), time.July, 1, 0, 0, 0, 0, loc).Zone()
+		m := MemberOffset{Timezone: tz, Offset: formatOffset(off), OffsetSeconds: off, IsDST: local.IsDST(), ObservesDST: jan != jul}
+		if m.ObservesDST {
+			seasonal++
+		}
+		res.Members = append(res.Members, m)
+		distinct[off] = true
+		if i == 0 || off < minOff {
+			minOff = off
+		}
+		if i == 0 || off > maxOff {
+			maxOff = off
+		}
+	}
+	for _, m := range res.Members {
+		if m.OffsetSeconds == minOff {
+			res.MinZones = append(res.MinZones, m.Timezone)
+		}
+		if m.OffsetSeconds == maxOff {
+			res.MaxZones = append(res.MaxZones, m.Timezone)
+		}
+	}
+	res.MinOffset, res.MaxOffset = formatOffset(minOff), formatOffset(maxOff)
+	res.SpreadHours = float64(maxOff-minOff) / 3600
+	res.DistinctOffsets = len(distinct)
+	res.Note = fmt.Sprintf("spread as of %s", at.Format("2006-01-02"))
+	if seasonal > 0 {
+		res.Note += fmt.Sprintf("; %d of %d zones observe DST, so the spread can change during the year", seasonal, len(zones))
+	}
+	return res, nil
+}
+
+// SameDateWindow finds the next UTC interval, ending after now, during which
+// every zone shows the same local calendar date. For a date D each zone is on
+// D from its local midnight to the next, so the shared window is the
+// intersection of those intervals: it lasts 24h minus the spread between the
+// zones' offsets and does not exist once the spread reaches 24h. A window
+// already in progress is returned whole and flagged active. Duration is
+// rendered in format (see durationFormatter).
+func (t *TimeServer) SameDateWindow(zones []string, format string) (SameDateWindowResult, error) {
+	if len(zones) == 0 {
+		return SameDateWindowResult{}, fmt.Errorf("timezones must not be empty")
+	}
+	render, err := durationFormatter(format)
+	if err != nil {
+		return SameDateWindowResult{}, err
+	}
+	res := SameDateWindowResult{}
+	locs := make([]*time.Location, 0, len(zones))
+	for _, z := range zones {
+		tz, loc, err := t.location(strings.TrimSpace(z))
+		if err != nil {
+			return SameDateWindowResult{}, err
+		}
+		res.Timezones = append(res.Timezones, tz)
+		locs = append(locs, loc)
+	}
+
+	now := t.nowFunc().UTC()
+	// Local dates lie within a day either side of the UTC date, and offset
+	// changes can only narrow a window for a few days, so a week is ample.
+	base := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	for i := -1; i <= 7; i++ {
+		d := base.AddDate(0, 0, i)
+		var start, end time.Time
+		for j, loc := range locs {
+			s := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+			e := time.Date(d.Year(), d.Month(), d.Day()+1, 0, 0, 0, 0, loc)
+			if j == 0 || s.After(start) {
+				start = s
+			}
+			if j == 0 || e.Before(end) {
+				end = e
+			}
+		}
+		if !start.Before(end) || !end.After(now) {
+			continue
+		}
+		span := end.Sub(start)
+		res.Found = true
+		res.Date = d.Format("2006-01-02")
+		res.Start = start.UTC().Format(time.RFC3339)
+		res.End = end.UTC().Format(time.RFC3339)
+		res.Duration = render(span)
+		res.DurationHours = span.Hours()
+		res.ActiveNow = !start.After(now)
+		return res, nil
+	}
+	res.Note = "the zones span 24 hours or more, so they are never all on the same date"
+	return res, nil
+}
+
+// DSTMissedRuns checks a job that fires daily at a local wall time against
+// every date from start to end inclusive, reporting dates where that time is
+// skipped by a spring-forward gap (the run is missed) or repeated by a
+// fall-back overlap (it may run twice).
+func (t *TimeServer) DSTMissedRuns(fireTime, tz, start, end string) (DSTMissedRunsResult, error) {
+	c, err := parseClock(fireTime)
+	if err != nil {
+		return DSTMissedRunsResult{}, err
+	}
+	if c >= day {
+		return DSTMissedRunsResult{}, fmt.Errorf("fire time must be before 24:00: %s", fireTime)
+	}
+	tz, loc, err := t.location(tz)
+	if err != nil {
+		return DSTMissedRunsResult{}, err
+	}
+	from, err := t.parseDateTime(start, loc)
+	if err != nil {
+		return DSTMissedRunsResult{}, err
+	}
+	until, err := t.parseDateTime(end, loc)
+	if err != nil {
+		return DSTMissedRunsResult{}, err
+	}
+	n := daysBetween(from, until) + 1
+	if n < 1 {
+		return DSTMissedRunsResult{}, fmt.Errorf("end must not be before start")
+	}
+	if n > maxSpannedDates {
+		return DSTMissedRunsResult{}, fmt.Errorf("range must be at most %d days", maxSpannedDates)
+	}
+
+	res := DSTMissedRunsResult{
+		Timezone: tz,
+		FireTime: fireTime,
+		Start:    from.Format("2006-01-02"),
+		End:      until.Format("2006-01-02"),
+		Days:     n,
+		Affected: []DSTAffectedRun{},
+	}
+	for i := 0; i < n; i++ {
+		d := startOfDay(from).AddDate(0, 0, i)
+		w := wallClock{d.Year(), d.Month(), d.Day(), int(c / time.Hour), int(c % time.Hour / time.Minute), int(c % time.Minute / time.Second), 0}
+		cands := resolveWall(w, loc)
+		switch len(cands) {
+		case 0:
+			res.Skipped++
+			res.Affected = append(res.Affected, DSTAffectedRun{
+				Date:    d.Format("2006-01-02"),
+				Kind:    "skipped",
+				Shifted: w.in(loc).Format(time.RFC3339),
+			})
+		case 1:
+		default:
+			run := DSTAffectedRun{Date: d.Format("2006-01-02"), Kind: "repeated"}
+			for _, f := range cands {
+				run.Fires = append(run.Fires, f.Format(time.RFC3339))
+			}
+			res.Repeated++
+			res.Affected = append(res.Affected, run)
+		}
+	}
+	return res, nil
+}
+
+// ZonesInDaypart lists the canonical Area/Location zones whose local time
+// at now lies inside a part of the day, grouped by UTC offset from west to
+// east. hours overrides the daypart's default range and may wrap past
+// midnight; the start is inclusive and the end exclusive.
+func (t *TimeServer) ZonesInDaypart(daypart, hours string) (ZonesInDaypartResult, error) {
+	daypart = strings.ToLower(strings.TrimSpace(daypart))
+	w, ok := zoneDayparts[daypart]
+	if !ok {
+		return ZonesInDaypartResult{}, fmt.Errorf("daypart must be morning, afternoon, evening or night: %s", daypart)
+	}
+	if hours != "" {
+		var err error
+		if w, err = parseClockWindow(hours, true); err != nil {
+			return ZonesInDaypartResult{}, err
+		}
+	}
+	names := zoneNames()
+	if len(names) == 0 {
+		return ZonesInDaypartResult{}, fmt.Errorf("no timezone database available to search")
+	}
+
+	now := t.nowFunc()
+	res := ZonesInDaypartResult{Daypart: daypart, Hours: formatClock(w.Start) + "-" + formatClock(w.End), Now: now.UTC().Format(time.RFC3339), Groups: []DaypartGroup{}}
+	byOffset := map[int]*DaypartGroup{}
+	for _, n := range names {
+		if zoneRank(n) != 0 {
+			continue
+		}
+		loc, err := time.LoadLocation(n)
+		if err != nil {
+			continue
+		}
+		local := now.In(loc)
+		c := local.Sub(startOfDay(local))
+		if w.End > w.Start && (c < w.Start || c >= w.End) || w.End < w.Start && c < w.Start && c >= w.End {
+			continue
+		}
+		_, off := local.Zone()
+		g := byOffset[off]
+		if g == nil {
+			g = &DaypartGroup{Offset: formatOffset(off), LocalTime: local.Format("15:04")}
+			byOffset[off] = g
+		}
+		g.Zones = append(g.Zones, n)
+		res.Count++
+	}
+	offsets := make([]int, 0, len(byOffset))
+	for off := range byOffset {
+		offsets = append(offsets, off)
+	}
+	sort.Ints(offsets)
+	for _, off := range offsets {
+		res.Groups = append(res.Groups, *byOffset[off])
+	}
+	return res, nil
+}
+
+// RolloutSchedule finds, for each zone, the instant its local clock reads
+// deployTime on date, and orders the zones chronologically so a rollout can
+// follow the night around the globe. A deploy time repeated by a fall-back
+// change uses its first occurrence; one skipped by spring-forward is read
+// with the pre-gap offset, landing just after the gap. Both are flagged.
+func (t *TimeServer) RolloutSchedule(zones []string, deployTime, date, format string) (RolloutScheduleResult, error) {
+	render, err := durationFormatter(format)
+	if err != nil {
+		return RolloutScheduleResult{}, err
+	}
+	if len(zones) == 0 {
+		return RolloutScheduleResult{}, fmt.Errorf("timezones must not be empty")
+	}
+	c, err := parseClock(deployTime)
+	if err != nil {
+		return RolloutScheduleResult{}, err
+	}
+	if c >= day {
+		return RolloutScheduleResult{}, fmt.Errorf("deploy time must be before 24:00: %s", deployTime)
+	}
+	d, err := t.parseDateTime(date, time.UTC)
+	if err != nil {
+		return RolloutScheduleResult{}, err
+	}
+	w := wallClock{d.Year(), d.Month(), d.Day(), int(c / time.Hour), int(c % time.Hour / time.Minute), int(c % time.Minute / time.Second), 0}
+
+	res := RolloutScheduleResult{Date: d.Format("2006-01-02"), DeployTime: formatClock(c)}
+	for _, z := range zones {
+		tz, loc, err := t.location(strings.TrimSpace(z))
+		if err != nil {
+			return RolloutScheduleResult{}, err
+		}
+		step := RolloutStep{Timezone: tz, Status: "ok"}
+		var at time.Time
+		cands := resolveWall(w, loc)
+		switch len(cands) {
+		case 0:
+			at, _ = resolveGapWall(w, loc, 0)
+			step.Status = "nonexistent"
+			step.Note = fmt.Sprintf("%s is skipped by a DST change in %s; deploying at %s local", w, tz, at.Format("15:04"))
+		case 1:
+			at = cands[0]
+		default:
+			at = cands[0]
+			step.Status = "ambiguous"
+			step.Note = fmt.Sprintf("%s occurs twice in %s; using the first occurrence", w, tz)
+		}
+		step.UTC = at.UTC().Format(time.RFC3339)
+		step.Local = makeTimeResult(tz, at)
+		res.Steps = append(res.Steps, step)
+	}
+	sort.SliceStable(res.Steps, func(i, j int) bool { return res.Steps[i].UTC < res.Steps[j].UTC })
+	for i := range res.Steps {
+		res.Steps[i].Order = i + 1
+	}
+	first, _ := time.Parse(time.RFC3339, res.Steps[0].UTC)
+	last, _ := time.Parse(time.RFC3339, res.Steps[len(res.Steps)-1].UTC)
+	res.Span = render(last.Sub(first))
+	return res, nil
+}
+
+// maxRotationMeetings bounds the series FairRotation will plan.
+const maxRotationMeetings = 104
+
+// rotationMethod documents how FairRotation scores and picks slots.
+const rotationMethod = "hourly UTC slots scored by hours outside each participant's 09:00-17:00 for a 1h meeting; each meeting takes the slot minimizing the largest running total, then the smallest meeting total"
+
+// inconvenience is how many hours a one-hour meeting starting at local
+// clock c lies outside 09:00-17:00, measured the short way round the clock.
+func inconvenience(c time.Duration) float64 {
+	h := c.Hours()
+	switch {
+	case h < 9:
+		return math.Min(9-h, h+24-16)
+	case h > 16:
+		return math.Min(h-16, 24-h+9)
+	}
+	return 0
+}
+
+// FairRotation plans the given number of weekly meetings from start so the
+// pain of an awkward hour rotates around the team instead of landing on one
+// zone. Every on-the-hour UTC slot is scored per participant, and each
+// meeting greedily takes the slot that keeps the worst running total
+// lowest, so a seat that suffered last week is favored next. Local times
+// are taken on each meeting's own date, so DST shifts are reflected.
+func (t *TimeServer) FairRotation(zones []string, meetings int, start string) (FairRotationResult, error) {
+	if len(zones) == 0 {
+		return FairRotationResult{}, fmt.Errorf("timezones must not be empty")
+	}
+	if meetings < 1 || meetings > maxRotationMeetings {
+		return FairRotationResult{}, fmt.Errorf("meetings must be between 1 and %d", maxRotationMeetings)
+	}
+	d, err := t.parseDateTime(start, time.UTC)
+	if err != nil {
+		return FairRotationResult{}, err
+	}
+	names := make([]string, len(zones))
+	locs := make([]*time.Location, len(zones))
+	for i, z := range zones {
+		if names[i], locs[i], err = t.location(strings.TrimSpace(z)); err != nil {
+			return FairRotationResult{}, err
+		}
+	}
+
+	res := FairRotationResult{Method: rotationMethod}
+	totals := make([]float64, len(zones))
+	worst := make([]int, len(zones))
+	day0 := startOfDay(d.UTC())
+	for m := 0; m < meetings; m++ {
+		base := day0.AddDate(0, 0, 7*m)
+		var best time.Time
+		var bestCost []float64
+		bestMax, bestSum := math.Inf(1), math.Inf(1)
+		for h := 0; h < 24; h++ {
+			slot := base.Add(time.Duration(h) * time.Hour)
+			cost := make([]float64, len(zones))
+			peak, sum := 0.0, 0.0
+			for i, loc := range locs {
+				local := slot.In(loc)
+				cost[i] = inconvenience(local.Sub(startOfDay(local)))
+				peak = math.Max(peak, totals[i]+cost[i])
+				sum += cost[i]
+			}
+			if peak < bestMax || (peak == bestMax && sum < bestSum) {
+				best, bestCost, bestMax, bestSum = slot, cost, peak, sum
+			}
+		}
+
+		meeting := RotationMeeting{Meeting: m + 1, UTC: best.Format(time.RFC3339)}
+		hardest := 0.0
+		for _, c := range bestCost {
+			hardest = math.Max(hardest, c)
+		}
+		for i, c := range bestCost {
+			totals[i] += c
+			if c > 0 && c == hardest {
+				worst[i]++
+			}
+			meeting.Seats = append(meeting.Seats, RotationSeat{Timezone: names[i], Local: makeTimeResult(names[i], best.In(locs[i])), Inconvenience: c})
+		}
+		res.Meetings = append(res.Meetings, meeting)
+	}
+	for i := range zones {
+		res.Totals = append(res.Totals, RotationTotal{Timezone: names[i], Inconvenience: totals[i], WorstCount: worst[i]})
+	}
+	return res, nil
+}
+
+// MidpointTime finds the fairest single meeting instant for two zones on
+// date: the point halfway, the short way round the clock, between each
+// zone's 13:00 local, the centre of a 09:00-17:00 day. That minimizes the
+// larger of the two distances from centre, and both parties end up the same
+// number of hours from it in opposite directions. When the centres are more
+// than eight hours apart the midpoint leaves both outside working hours.
+func (t *TimeServer) MidpointTime(first, second, date string) (MidpointTimeResult, error) {
+	d, err := t.parseDateTime(date, time.UTC)
+	if err != nil {
+		return MidpointTimeResult{}, err
+	}
+	var names [2]string
+	var centers [2]time.Time
+	for i, z := range []string{first, second} {
+		name, loc, err := t.location(z)
+		if err != nil {
+			return MidpointTimeResult{}, err
+		}
+		names[i] = name
+		centers[i] = time.Date(d.Year(), d.Month(), d.Day(), 13, 0, 0, 0, loc)
+	}
+	gap := mod(int(centers[1].Sub(centers[0])/time.Minute)+12*60, 24*60) - 12*60
+	mid := centers[0].Add(time.Duration(gap) * time.Minute / 2)
+
+	res := MidpointTimeResult{Date: d.Format("2006-01-02"), UTC: mid.UTC().Format(time.RFC3339), BothWithinHours: true}
+	for i, c := range centers {
+		local := mid.In(c.Location())
+		from := local.Sub(c)
+		// Measure against the centre on the midpoint's own local date.
+		if from > 12*time.Hour {
+			from -= day
+		} else if from < -12*time.Hour {
+			from += day
+		}
+		seat := MidpointSeat{
+			Timezone:        names[i],
+			Local:           makeTimeResult(names[i], local),
+			HoursFromCenter: from.Hours(),
+			WithinHours:     from >= -4*time.Hour && from <= 4*time.Hour,
+		}
+		res.BothWithinHours = res.BothWithinHours && seat.WithinHours
+		res.Participants = append(res.Participants, seat)
+	}
+	if !res.BothWithinHours {
+		spread := time.Duration(gap) * time.Minute
+		if spread < 0 {
+			spread = -spread
+		}
+		res.Note = fmt.Sprintf("centres are %s apart; no time keeps both inside 09:00-17:00", spread)
+	}
+	return res, nil
+}
+
+/* ----- tools ----- */
+
+func registerZoneTools(s *server.MCPServer, ts *TimeServer) {
+	timeline := mcp.NewTool(
+		"offset_timeline",
+		mcp.WithDescription("List the UTC offset segments (with abbreviations) a timezone passes through between two dates."),
+		mcp.WithString("timezone", mcp.Required()),
+		mcp.WithString("start", mcp.Required()),
+		mcp.WithString("end", mcp.Required()),
+	)
+
+	s.AddTool(timeline, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		tz, err := r.RequireString("timezone")
+		if err != nil {
+			return errorResult(err)
+		}
+		start, err := r.RequireString("start")
+		if err != nil {
+			return errorResult(err)
+		}
+		end, err := r.RequireString("end")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.OffsetTimeline(tz, start, end)
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	localize := mcp.NewTool(
+		"localize_naive",
+		mcp.WithDescription("Interpret a datetime recorded without a zone as local time in a timezone, flagging ambiguous and nonexistent wall times."),
+		mcp.WithString("datetime", mcp.Required(), mcp.Description("Naive datetime, e.g. 2025-11-02 01:30:00.")),
+		mcp.WithString("timezone", mcp.Required()),
+	)
+
+	s.AddTool(localize, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		naive, err := r.RequireString("datetime")
+		if err != nil {
+			return errorResult(err)
+		}
+		tz, err := r.RequireString("timezone")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.LocalizeNaive(naive, tz)
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	resolve := mcp.NewTool(
+		"resolve_wallclock",
+		mcp.WithDescription("Resolve a naive local datetime to one UTC instant, choosing the side of a DST change with fold: 0 = first occurrence of a repeated time, 1 = second. Times skipped by spring-forward are flagged."),
+		mcp.WithString("datetime", mcp.Required(), mcp.Description("Naive datetime, e.g. 2025-11-02 01:30:00.")),
+		mcp.WithString("timezone", mcp.Required()),
+		mcp.WithNumber("fold", mcp.Description("0 (default) or 1.")),
+	)
+
+	s.AddTool(resolve, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		naive, err := r.RequireString("datetime")
+		if err != nil {
+			return errorResult(err)
+		}
+		tz, err := r.RequireString("timezone")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.ResolveWallclock(naive, tz, r.GetInt("fold", 0))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	infer := mcp.NewTool(
+		"infer_timezone",
+		mcp.WithDescription("Find the IANA zones consistent with a set of observed (UTC instant, local wall time) pairs."),
+		mcp.WithArray("observations", mcp.Required(), mcp.Items(map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"utc":   map[string]any{"type": "string", "description": "RFC3339 instant"},
+				"local": map[string]any{"type": "string", "description": "Local wall time, e.g. 2025-01-15 09:00"},
+			},
+			"required": []string{"utc", "local"},
+		})),
+	)
+
+	s.AddTool(infer, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			Observations []Observation `json:"observations"`
+		}
+		if err := r.BindArguments(&args); err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.InferTimezone(args.Observations)
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	fromCoords := mcp.NewTool(
+		"zone_from_coordinates",
+		mcp.WithDescription("Approximate IANA timezone for a latitude/longitude, with the current local time there. Uses nearest-match against a built-in set of reference locations, so results near zone borders can be wrong; check the confidence field."),
+		mcp.WithNumber("latitude", mcp.Required()),
+		mcp.WithNumber("longitude", mcp.Required()),
+	)
+
+	s.AddTool(fromCoords, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lat, err := r.RequireFloat("latitude")
+		if err != nil {
+			return errorResult(err)
+		}
+		lon, err := r.RequireFloat("longitude")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.ZoneFromCoordinates(lat, lon)
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	photo := mcp.NewTool(
+		"resolve_photo_time",
+		mcp.WithDescription("Resolve a camera capture time recorded without a UTC offset (EXIF style) to a UTC instant in a best-guess timezone, flagging ambiguous and nonexistent local times."),
+		mcp.WithString("capture_time", mcp.Required(), mcp.Description("Naive capture time, e.g. 2025:11:02 01:30:00 or 2025-11-02T01:30:00.")),
+		mcp.WithString("timezone", mcp.Required(), mcp.Description("Zone the camera clock was set to.")),
+	)
+
+	s.AddTool(photo, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		capture, err := r.RequireString("capture_time")
+		if err != nil {
+			return errorResult(err)
+		}
+		tz, err := r.RequireString("timezone")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.ResolvePhotoTime(capture, tz)
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	spread := mcp.NewTool(
+		"team_spread",
+		mcp.WithDescription("How many hours a team's timezones span at one instant: minimum and maximum UTC offsets, the spread in hours and the number of distinct offsets. DST can change the spread, so the instant used is reported."),
+		mcp.WithArray("timezones", mcp.Required(), mcp.Items(map[string]any{"type": "string"}), mcp.Description("One IANA zone per member; duplicates are fine.")),
+		mcp.WithString("datetime", mcp.Description("Instant to compute the spread for. Defaults to now.")),
+	)
+
+	s.AddTool(spread, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		zones, err := r.RequireStringSlice("timezones")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.TeamSpread(zones, r.GetString("datetime", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	sameDate := mcp.NewTool(
+		"same_date_window",
+		mcp.WithDescription("Next UTC interval during which every given timezone is on the same local calendar date, with its duration. Useful for fair same-day global deadlines."),
+		mcp.WithArray("timezones", mcp.Required(), mcp.Items(map[string]any{"type": "string"})),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(sameDate, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		zones, err := r.RequireStringSlice("timezones")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.SameDateWindow(zones, r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	missed := mcp.NewTool(
+		"dst_missed_runs",
+		mcp.WithDescription("Dates in a range on which a daily job at a fixed local time would be skipped (spring-forward gap) or could run twice (fall-back overlap)."),
+		mcp.WithString("fire_time", mcp.Required(), mcp.Description("Local daily fire time HH:MM, e.g. 02:30.")),
+		mcp.WithString("timezone", mcp.Required()),
+		mcp.WithString("start", mcp.Required(), mcp.Description("First date (YYYY-MM-DD).")),
+		mcp.WithString("end", mcp.Required(), mcp.Description("Last date, inclusive.")),
+	)
+
+	s.AddTool(missed, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		fireTime, err := r.RequireString("fire_time")
+		if err != nil {
+			return errorResult(err)
+		}
+		tz, err := r.RequireString("timezone")
+		if err != nil {
+			return errorResult(err)
+		}
+		start, err := r.RequireString("start")
+		if err != nil {
+			return errorResult(err)
+		}
+		end, err := r.RequireString("end")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.DSTMissedRuns(fireTime, tz, start, end)
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	daypart := mcp.NewTool(
+		"zones_in_daypart",
+		mcp.WithDescription("Which timezones are currently in their morning, afternoon, evening or night, grouped by UTC offset. Hour ranges can be overridden."),
+		mcp.WithString("daypart", mcp.Required(), mcp.Enum("morning", "afternoon", "evening", "night")),
+		mcp.WithString("hours", mcp.Description("Local range HH:MM-HH:MM overriding the default (morning 06-12, afternoon 12-17, evening 17-21, night 21-05).")),
+	)
+
+	s.AddTool(daypart, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		part, err := r.RequireString("daypart")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.ZonesInDaypart(part, r.GetString("hours", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	rollout := mcp.NewTool(
+		"rollout_schedule",
+		mcp.WithDescription("Staggered rollout: the UTC instant at which each zone reaches a local deploy time on a date, ordered chronologically, with DST-skipped or repeated times flagged."),
+		mcp.WithArray("timezones", mcp.Required(), mcp.Items(map[string]any{"type": "string"})),
+		mcp.WithString("deploy_time", mcp.Description("Local time HH:MM (default 02:00).")),
+		mcp.WithString("date", mcp.Description("Local calendar date YYYY-MM-DD. Defaults to today (UTC).")),
+		mcp.WithString("format", mcp.Description("Format of duration fields: go (default, 1h30m0s), human (1 hour 30 minutes) or iso8601 (PT1H30M).")),
+	)
+
+	s.AddTool(rollout, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		zones, err := r.RequireStringSlice("timezones")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.RolloutSchedule(zones, r.GetString("deploy_time", "02:00"), r.GetString("date", ""), r.GetString("format", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	rotation := mcp.NewTool(
+		"fair_rotation",
+		mcp.WithDescription("Plan a weekly meeting series whose UTC time rotates so the inconvenience (hours outside 09:00-17:00 local) is shared fairly, with each participant's local time per meeting."),
+		mcp.WithArray("timezones", mcp.Required(), mcp.Items(map[string]any{"type": "string"})),
+		mcp.WithNumber("meetings", mcp.Required(), mcp.Description("Number of weekly meetings to plan.")),
+		mcp.WithString("start_date", mcp.Description("UTC date of the first meeting. Defaults to today.")),
+	)
+
+	s.AddTool(rotation, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		zones, err := r.RequireStringSlice("timezones")
+		if err != nil {
+			return errorResult(err)
+		}
+		meetings, err := r.RequireInt("meetings")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.FairRotation(zones, meetings, r.GetString("start_date", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	midpoint := mcp.NewTool(
+		"midpoint_time",
+		mcp.WithDescription("Fairest single meeting time for two timezones: the UTC instant halfway between their 13:00 local centres of a 09:00-17:00 day, with each side's local time and distance from centre."),
+		mcp.WithString("first", mcp.Required(), mcp.Description("First participant's timezone.")),
+		mcp.WithString("second", mcp.Required(), mcp.Description("Second participant's timezone.")),
+		mcp.WithString("date", mcp.Description("Date YYYY-MM-DD. Defaults to today.")),
+	)
+
+	s.AddTool(midpoint, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		first, err := r.RequireString("first")
+		if err != nil {
+			return errorResult(err)
+		}
+		second, err := r.RequireString("second")
+		if err != nil {
+			return errorResult(err)
+		}
+		res, err := ts.MidpointTime(first, second, r.GetString("date", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	list := mcp.NewTool(
+		"list_timezones",
+		mcp.WithDescription("List the IANA timezone names known to the server, sorted, optionally filtered by a case-insensitive substring such as \"america\" or \"paris\"."),
+		mcp.WithString("filter", mcp.Description("Substring to match, e.g. paris.")),
+	)
+
+	s.AddTool(list, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := ts.ListTimezones(r.GetString("filter", ""))
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(res)
+	})
+
+	validate := mcp.NewTool(
+		"validate_timezone",
+		mcp.WithDescription("Check whether a timezone name is valid and return its canonical form: legacy aliases such as US/Eastern map to America/New_York, letter case is fixed, and UTC offsets such as UTC+5:30 are accepted."),
+		mcp.WithString("timezone", mcp.Required()),
+	)
+
+	s.AddTool(validate, func(_ context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		tz, err := r.RequireString("timezone")
+		if err != nil {
+			return errorResult(err)
+		}
+		valid, canonical, err := ts.ValidateTimezone(tz)
+		if err != nil {
+			return errorResult(err)
+		}
+		return jsonResult(ValidateTimezoneResult{Timezone: tz, Valid: valid, Canonical: canonical})
+	})
+}
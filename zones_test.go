@@ -0,0 +1,671 @@
+// zones_test.go
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOffsetTimeline(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	res, err := ts.OffsetTimeline("America/New_York", "2025-01-01", "2025-12-31")
+	if err != nil {
+		t.Fatalf("OffsetTimeline error: %v", err)
+	}
+	want := []struct{ start, offset, abbr string }{
+		{"2025-01-01T00:00:00-05:00", "-05:00", "EST"},
+		{"2025-03-09T03:00:00-04:00", "-04:00", "EDT"},
+		{"2025-11-02T01:00:00-05:00", "-05:00", "EST"},
+	}
+	if len(res.Segments) != len(want) {
+		t.Fatalf("expected %d segments, got %d: %+v", len(want), len(res.Segments), res.Segments)
+	}
+	for i, w := range want {
+		seg := res.Segments[i]
+		if seg.Start != w.start || seg.UTCOffset != w.offset || seg.Abbreviation != w.abbr {
+			t.Errorf("segment %d = %+v, want start %s offset %s abbr %s", i, seg, w.start, w.offset, w.abbr)
+		}
+		if i > 0 && res.Segments[i-1].End != seg.Start {
+			t.Errorf("segment %d does not start where segment %d ends", i, i-1)
+		}
+	}
+
+	res, err = ts.OffsetTimeline("Asia/Tokyo", "2025-01-01", "2025-12-31")
+	if err != nil {
+		t.Fatalf("OffsetTimeline error: %v", err)
+	}
+	if len(res.Segments) != 1 || res.Segments[0].UTCOffset != "+09:00" {
+		t.Errorf("expected a single +09:00 segment for Tokyo, got %+v", res.Segments)
+	}
+
+	if _, err := ts.OffsetTimeline("UTC", "2025-02-01", "2025-01-01"); err == nil {
+		t.Errorf("expected error for reversed range, got nil")
+	}
+}
+
+func TestLocalizeNaive(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	res, err := ts.LocalizeNaive("2025-07-01 12:00:00", "America/New_York")
+	if err != nil {
+		t.Fatalf("LocalizeNaive error: %v", err)
+	}
+	if res.Status != "ok" || res.Datetime != "2025-07-01T12:00:00-04:00" {
+		t.Errorf("got %+v, want ok 2025-07-01T12:00:00-04:00", res)
+	}
+
+	res, err = ts.LocalizeNaive("2025-11-02 01:30", "America/New_York")
+	if err != nil {
+		t.Fatalf("LocalizeNaive error: %v", err)
+	}
+	if res.Status != "ambiguous" || len(res.Candidates) != 2 ||
+		res.Candidates[0].Datetime != "2025-11-02T01:30:00-04:00" ||
+		res.Candidates[1].Datetime != "2025-11-02T01:30:00-05:00" {
+		t.Errorf("fall-back time: got %+v, want EDT then EST candidates", res)
+	}
+
+	res, err = ts.LocalizeNaive("2025-03-09 02:30", "America/New_York")
+	if err != nil {
+		t.Fatalf("LocalizeNaive error: %v", err)
+	}
+	if res.Status != "nonexistent" || res.Datetime != "" || res.Shifted == nil {
+		t.Errorf("spring-forward time: got %+v, want nonexistent with a shifted suggestion", res)
+	}
+
+	if _, err := ts.LocalizeNaive("2025-07-01T12:00:00Z", "UTC"); err == nil {
+		t.Errorf("expected error for input with an offset, got nil")
+	}
+}
+
+func TestInferTimezone(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	if len(zoneNames()) == 0 {
+		t.Skip("no zoneinfo database available")
+	}
+
+	// -05:00 in January and -04:00 in July rules out zones without DST and
+	// zones in the southern hemisphere.
+	res, err := ts.InferTimezone([]Observation{
+		{UTC: "2025-01-15T14:00:00Z", Local: "2025-01-15 09:00"},
+		{UTC: "2025-07-15T13:00:00Z", Local: "2025-07-15 09:00"},
+	})
+	if err != nil {
+		t.Fatalf("InferTimezone error: %v", err)
+	}
+	found := map[string]bool{}
+	for _, c := range res.Candidates {
+		found[c.Timezone] = true
+	}
+	if !found["America/New_York"] || !found["America/Toronto"] {
+		t.Errorf("expected New York and Toronto among candidates, got %+v", res.Candidates)
+	}
+	if found["America/Bogota"] || found["America/Chicago"] {
+		t.Errorf("unexpected zone with different rules among candidates: %+v", res.Candidates)
+	}
+	if zoneRank(res.Candidates[0].Timezone) != 0 {
+		t.Errorf("expected a canonical zone ranked first, got %s", res.Candidates[0].Timezone)
+	}
+
+	if _, err := ts.InferTimezone(nil); err == nil {
+		t.Errorf("expected error for no observations, got nil")
+	}
+}
+
+func TestZoneFromCoordinates(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	cases := []struct {
+		name     string
+		lat, lon float64
+		wantZone string
+		wantConf string
+	}{
+		{"manhattan", 40.78, -73.97, "America/New_York", "high"},
+		{"phoenixSuburb", 33.42, -111.83, "America/Phoenix", "high"},
+		{"kyoto", 35.01, 135.77, "Asia/Tokyo", "high"},
+		{"midPacific", 0, -140, "Etc/GMT+9", "low"},
+		{"southAtlantic", -40, -15, "Etc/GMT+1", "low"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.ZoneFromCoordinates(tc.lat, tc.lon)
+			if err != nil {
+				t.Fatalf("ZoneFromCoordinates error: %v", err)
+			}
+			if res.Timezone != tc.wantZone || res.Confidence != tc.wantConf {
+				t.Errorf("got %s (%s, %.0f km), want %s (%s)", res.Timezone, res.Confidence, res.DistanceKm, tc.wantZone, tc.wantConf)
+			}
+		})
+	}
+
+	// Every reference zone must load so lookups never fail on bad data.
+	for _, p := range zonePoints {
+		if _, err := time.LoadLocation(p.Zone); err != nil {
+			t.Errorf("zonePoints entry %v: %v", p, err)
+		}
+	}
+
+	if _, err := ts.ZoneFromCoordinates(91, 0); err == nil {
+		t.Errorf("expected error for latitude 91, got nil")
+	}
+}
+
+func TestResolvePhotoTime(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	cases := []struct {
+		name, capture, want, alt string
+		ambiguous, nonexistent   bool
+	}{
+		{"exifLayout", "2025:07:01 12:00:00", "2025-07-01T16:00:00Z", "", false, false},
+		{"isoLayout", "2025-07-01T12:00:00", "2025-07-01T16:00:00Z", "", false, false},
+		{"fallBack", "2025:11:02 01:30:00", "2025-11-02T05:30:00Z", "2025-11-02T06:30:00Z", true, false},
+		{"springForward", "2025:03:09 02:30:00", "2025-03-09T07:30:00Z", "", false, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.ResolvePhotoTime(tc.capture, "America/New_York")
+			if err != nil {
+				t.Fatalf("ResolvePhotoTime error: %v", err)
+			}
+			if res.UTC != tc.want || res.AlternativeUTC != tc.alt || res.Ambiguous != tc.ambiguous || res.Nonexistent != tc.nonexistent {
+				t.Errorf("got %+v, want %s (alt %q)", res, tc.want, tc.alt)
+			}
+			if (tc.ambiguous || tc.nonexistent) == (res.Warning == "") {
+				t.Errorf("warning = %q", res.Warning)
+			}
+		})
+	}
+
+	if _, err := ts.ResolvePhotoTime("2025-07-01T12:00:00+02:00", "Europe/Paris"); err == nil {
+		t.Errorf("expected error for a capture time with an offset, got nil")
+	}
+}
+
+func TestTeamSpread(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC) })
+	team := []string{"America/Los_Angeles", "Europe/London", "Asia/Kolkata", "Australia/Sydney", "America/Los_Angeles"}
+
+	winter, err := ts.TeamSpread(team, "")
+	if err != nil {
+		t.Fatalf("TeamSpread error: %v", err)
+	}
+	// -08:00 to +11:00 in January.
+	if winter.SpreadHours != 19 || winter.MinOffset != "-08:00" || winter.MaxOffset != "+11:00" || winter.DistinctOffsets != 4 {
+		t.Errorf("January spread = %+v", winter)
+	}
+	if len(winter.MinZones) != 2 || !strings.Contains(winter.Note, "4 of 5") {
+		t.Errorf("January members = %v, note %q", winter.MinZones, winter.Note)
+	}
+
+	summer, err := ts.TeamSpread(team, "2025-07-15T12:00:00Z")
+	if err != nil {
+		t.Fatalf("TeamSpread error: %v", err)
+	}
+	// -07:00 to +10:00 in July.
+	if summer.SpreadHours != 17 || summer.ComputedAt != "2025-07-15T12:00:00Z" {
+		t.Errorf("July spread = %+v", summer)
+	}
+
+	if _, err := ts.TeamSpread([]string{"Mars/Olympus_Mons"}, ""); err == nil {
+		t.Errorf("expected error for an unknown zone, got nil")
+	}
+}
+
+func TestSameDateWindow(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC) })
+
+	// Los Angeles (-08:00) and Tokyo (+09:00) share a date for 7 hours a day,
+	// from 08:00 to 15:00 UTC; at noon UTC that window is open.
+	res, err := ts.SameDateWindow([]string{"America/Los_Angeles", "Asia/Tokyo"}, "")
+	if err != nil {
+		t.Fatalf("SameDateWindow error: %v", err)
+	}
+	if !res.Found || !res.ActiveNow || res.Date != "2025-01-15" || res.Start != "2025-01-15T08:00:00Z" ||
+		res.End != "2025-01-15T15:00:00Z" || res.DurationHours != 7 {
+		t.Errorf("LA/Tokyo window = %+v", res)
+	}
+
+	// Auckland (+13:00 in January) and Honolulu (-10:00) are 23 hours apart.
+	narrow, err := ts.SameDateWindow([]string{"Pacific/Auckland", "Pacific/Honolulu"}, "")
+	if err != nil {
+		t.Fatalf("SameDateWindow error: %v", err)
+	}
+	if !narrow.Found || narrow.ActiveNow || narrow.DurationHours != 1 || narrow.Start != "2025-01-16T10:00:00Z" {
+		t.Errorf("Auckland/Honolulu window = %+v", narrow)
+	}
+
+	// Kiritimati (+14:00) and Pago Pago (-11:00) are 25 hours apart.
+	never, err := ts.SameDateWindow([]string{"Pacific/Kiritimati", "Pacific/Pago_Pago"}, "")
+	if err != nil {
+		t.Fatalf("SameDateWindow error: %v", err)
+	}
+	if never.Found || never.Note == "" {
+		t.Errorf("Kiritimati/Pago Pago window = %+v, want none", never)
+	}
+}
+
+func TestDSTMissedRuns(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	res, err := ts.DSTMissedRuns("02:30", "America/New_York", "2025-01-01", "2025-12-31")
+	if err != nil {
+		t.Fatalf("DSTMissedRuns error: %v", err)
+	}
+	if res.Days != 365 || res.Skipped != 1 || res.Repeated != 0 || len(res.Affected) != 1 || res.Affected[0].Date != "2025-03-09" {
+		t.Errorf("02:30 in New York = %+v, want only 2025-03-09 skipped", res)
+	}
+
+	twice, err := ts.DSTMissedRuns("01:15", "America/New_York", "2025-01-01", "2025-12-31")
+	if err != nil {
+		t.Fatalf("DSTMissedRuns error: %v", err)
+	}
+	if twice.Repeated != 1 || twice.Skipped != 0 || twice.Affected[0].Date != "2025-11-02" ||
+		len(twice.Affected[0].Fires) != 2 || twice.Affected[0].Fires[1] != "2025-11-02T01:15:00-05:00" {
+		t.Errorf("01:15 in New York = %+v, want 2025-11-02 repeated", twice)
+	}
+
+	safe, err := ts.DSTMissedRuns("02:30", "Asia/Tokyo", "2025-01-01", "2025-12-31")
+	if err != nil {
+		t.Fatalf("DSTMissedRuns error: %v", err)
+	}
+	if len(safe.Affected) != 0 {
+		t.Errorf("Tokyo has no DST, got %+v", safe.Affected)
+	}
+}
+
+func TestZonesInDaypart(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	if len(zoneNames()) == 0 {
+		t.Skip("no zoneinfo database available")
+	}
+	// 19:00 UTC: evening in London and Paris, early afternoon in New York.
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 1, 15, 19, 0, 0, 0, time.UTC) })
+
+	has := func(res ZonesInDaypartResult, zone string) bool {
+		for _, g := range res.Groups {
+			for _, z := range g.Zones {
+				if z == zone {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	evening, err := ts.ZonesInDaypart("evening", "")
+	if err != nil {
+		t.Fatalf("ZonesInDaypart error: %v", err)
+	}
+	if !has(evening, "Europe/London") || !has(evening, "Europe/Paris") || has(evening, "America/New_York") || has(evening, "Asia/Tokyo") {
+		t.Errorf("evening zones = %+v", evening.Groups)
+	}
+	prev := -24 * 3600
+	for _, g := range evening.Groups {
+		tm, _ := time.Parse("-07:00", g.Offset)
+		if _, off := tm.Zone(); off <= prev {
+			t.Errorf("group %s is out of west-to-east order", g.Offset)
+		} else {
+			prev = off
+		}
+	}
+
+	// Tokyo is at 04:00, inside the default night that wraps past midnight.
+	night, err := ts.ZonesInDaypart("night", "")
+	if err != nil {
+		t.Fatalf("ZonesInDaypart error: %v", err)
+	}
+	if !has(night, "Asia/Tokyo") || has(night, "Europe/London") {
+		t.Errorf("night zones = %+v", night.Groups)
+	}
+
+	narrow, err := ts.ZonesInDaypart("afternoon", "14:00-15:00")
+	if err != nil {
+		t.Fatalf("ZonesInDaypart error: %v", err)
+	}
+	if !has(narrow, "America/New_York") || has(narrow, "America/Chicago") {
+		t.Errorf("14:00-15:00 zones = %+v", narrow.Groups)
+	}
+
+	if _, err := ts.ZonesInDaypart("brunch", ""); err == nil {
+		t.Errorf("expected error for an unknown daypart, got nil")
+	}
+}
+
+func TestRolloutSchedule(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	res, err := ts.RolloutSchedule([]string{"America/New_York", "Asia/Tokyo", "Europe/London"}, "02:00", "2025-05-14", "")
+	if err != nil {
+		t.Fatalf("RolloutSchedule error: %v", err)
+	}
+	want := []struct{ tz, utc string }{
+		{"Asia/Tokyo", "2025-05-13T17:00:00Z"},
+		{"Europe/London", "2025-05-14T01:00:00Z"},
+		{"America/New_York", "2025-05-14T06:00:00Z"},
+	}
+	for i, w := range want {
+		if s := res.Steps[i]; s.Timezone != w.tz || s.UTC != w.utc || s.Order != i+1 || s.Status != "ok" {
+			t.Errorf("step %d = %+v, want %s at %s", i, s, w.tz, w.utc)
+		}
+	}
+	if res.Span != "13h0m0s" {
+		t.Errorf("span = %s, want 13h0m0s", res.Span)
+	}
+
+	// 02:30 does not exist in New York on 2025-03-09.
+	gap, err := ts.RolloutSchedule([]string{"America/New_York"}, "02:30", "2025-03-09", "")
+	if err != nil {
+		t.Fatalf("RolloutSchedule error: %v", err)
+	}
+	if s := gap.Steps[0]; s.Status != "nonexistent" || s.UTC != "2025-03-09T07:30:00Z" || s.Local.Datetime != "2025-03-09T03:30:00-04:00" {
+		t.Errorf("spring-forward step = %+v", s)
+	}
+}
+
+func TestFairRotation(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	res, err := ts.FairRotation([]string{"America/Los_Angeles", "Europe/London", "Asia/Tokyo"}, 6, "2025-01-06")
+	if err != nil {
+		t.Fatalf("FairRotation error: %v", err)
+	}
+	if len(res.Meetings) != 6 || res.Meetings[5].UTC[:10] != "2025-02-10" {
+		t.Fatalf("meetings = %+v, want 6 weekly from 2025-01-06", res.Meetings)
+	}
+	lo, hi := math.Inf(1), 0.0
+	for _, tot := range res.Totals {
+		lo, hi = math.Min(lo, tot.Inconvenience), math.Max(hi, tot.Inconvenience)
+		if tot.WorstCount == len(res.Meetings) {
+			t.Errorf("%s took the worst slot in every meeting", tot.Timezone)
+		}
+	}
+	if hi-lo > 6 {
+		t.Errorf("inconvenience totals range %v..%v, want within 6h", lo, hi)
+	}
+	seen := map[string]bool{}
+	for _, m := range res.Meetings {
+		seen[m.UTC[11:]] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("meeting time never rotates: %v", seen)
+	}
+
+	// A lone participant simply meets at the start of their working day.
+	solo, err := ts.FairRotation([]string{"UTC"}, 2, "2025-01-06")
+	if err != nil {
+		t.Fatalf("FairRotation error: %v", err)
+	}
+	for _, m := range solo.Meetings {
+		if m.UTC[11:] != "09:00:00Z" || m.Seats[0].Inconvenience != 0 {
+			t.Errorf("solo meeting = %+v, want 09:00Z with no inconvenience", m)
+		}
+	}
+
+	if _, err := ts.FairRotation([]string{"UTC"}, 0, "2025-01-06"); err == nil {
+		t.Error("expected error for zero meetings")
+	}
+}
+
+func TestInconvenience(t *testing.T) {
+	cases := map[time.Duration]float64{
+		9 * time.Hour:  0,
+		16 * time.Hour: 0,
+		17 * time.Hour: 1,
+		22 * time.Hour: 6,
+		2 * time.Hour:  7,
+		5 * time.Hour:  4,
+		0:              8,
+	}
+	for c, want := range cases {
+		if got := inconvenience(c); got != want {
+			t.Errorf("inconvenience(%s) = %v, want %v", c, got, want)
+		}
+	}
+}
+
+func TestResolveWallclock(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	cases := []struct {
+		name, naive, tz string
+		fold            int
+		status, utc     string
+	}{
+		{"ordinary", "2025-07-01 12:00", "America/New_York", 0, "ok", "2025-07-01T16:00:00Z"},
+		{"ordinaryIgnoresFold", "2025-07-01 12:00", "America/New_York", 1, "ok", "2025-07-01T16:00:00Z"},
+		{"fallBackFirst", "2025-11-02 01:30", "America/New_York", 0, "ambiguous", "2025-11-02T05:30:00Z"},
+		{"fallBackSecond", "2025-11-02 01:30", "America/New_York", 1, "ambiguous", "2025-11-02T06:30:00Z"},
+		{"gapFold0", "2025-03-09 02:30", "America/New_York", 0, "nonexistent", "2025-03-09T07:30:00Z"},
+		{"gapFold1", "2025-03-09 02:30", "America/New_York", 1, "nonexistent", "2025-03-09T06:30:00Z"},
+		{"fractional", "2025-11-02T01:30:00.25", "America/New_York", 1, "ambiguous", "2025-11-02T06:30:00.25Z"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.ResolveWallclock(tc.naive, tc.tz, tc.fold)
+			if err != nil {
+				t.Fatalf("ResolveWallclock error: %v", err)
+			}
+			if res.Status != tc.status || res.UTC != tc.utc {
+				t.Errorf("got %s %s, want %s %s", res.Status, res.UTC, tc.status, tc.utc)
+			}
+		})
+	}
+
+	if _, err := ts.ResolveWallclock("2025-11-02 01:30", "America/New_York", 2); err == nil {
+		t.Error("expected error for fold 2")
+	}
+	if _, err := ts.ResolveWallclock("2025-11-02T01:30:00Z", "America/New_York", 0); err == nil {
+		t.Error("expected error for datetime with offset")
+	}
+}
+
+func TestMidpointTime(t *testing.T) {
+	ts := NewTimeServer("UTC")
+
+	cases := []struct {
+		name, first, second, date, utc string
+		from                           [2]float64
+		within                         bool
+	}{
+		// Winter: London UTC+0, New York UTC-5. Centres 13:00Z and 18:00Z.
+		{"londonNewYork", "Europe/London", "America/New_York", "2025-01-15", "2025-01-15T15:30:00Z", [2]float64{2.5, -2.5}, true},
+		{"sameZone", "Europe/Paris", "Europe/Berlin", "2025-01-15", "2025-01-15T12:00:00Z", [2]float64{0, 0}, true},
+		// India UTC+5:30 and Los Angeles UTC-8: centres 13.5h apart, so go
+		// the short way round (10.5h) across midnight.
+		{"kolkataLosAngeles", "Asia/Kolkata", "America/Los_Angeles", "2025-01-15", "2025-01-15T02:15:00Z", [2]float64{-5.25, 5.25}, false},
+		// Summer: the New York centre moves to 17:00Z.
+		{"summerDST", "Europe/London", "America/New_York", "2025-07-15", "2025-07-15T14:30:00Z", [2]float64{2.5, -2.5}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ts.MidpointTime(tc.first, tc.second, tc.date)
+			if err != nil {
+				t.Fatalf("MidpointTime error: %v", err)
+			}
+			if res.UTC != tc.utc || res.BothWithinHours != tc.within {
+				t.Errorf("got %s within=%v, want %s within=%v", res.UTC, res.BothWithinHours, tc.utc, tc.within)
+			}
+			for i, p := range res.Participants {
+				if p.HoursFromCenter != tc.from[i] {
+					t.Errorf("%s hours from centre = %v, want %v", p.Timezone, p.HoursFromCenter, tc.from[i])
+				}
+			}
+			if !tc.within && res.Note == "" {
+				t.Error("expected a note when no time fits both")
+			}
+		})
+	}
+}
+
+func TestFilterZoneNames(t *testing.T) {
+	raw := []string{"Europe/Paris", "zone1970.tab", "Asia/Tokyo", "Factory", "Europe/Paris", "Not/AZone", "iso3166.tab"}
+	got := filterZoneNames(raw)
+	want := []string{"Asia/Tokyo", "Europe/Paris"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("filterZoneNames = %v, want %v", got, want)
+	}
+}
+
+func TestTZDataSource(t *testing.T) {
+	src := tzdataSource()
+	switch {
+	case len(zoneNames()) == 0:
+		if src != "none" {
+			t.Errorf("tzdataSource() = %q with no zones, want none", src)
+		}
+	case embeddedTZData && src == "embedded":
+		if len(zoneNames()) < 300 {
+			t.Errorf("embedded zone list has only %d names", len(zoneNames()))
+		}
+	case !strings.HasPrefix(src, "system ("):
+		t.Errorf("tzdataSource() = %q, want system (<path>)", src)
+	}
+}
+
+func TestListTimezones(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	if len(zoneNames()) == 0 {
+		t.Skip("no zoneinfo database available")
+	}
+
+	all, err := ts.ListTimezones("")
+	if err != nil {
+		t.Fatalf("ListTimezones error: %v", err)
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i-1] >= all[i] {
+			t.Fatalf("not sorted and unique at %d: %s, %s", i, all[i-1], all[i])
+		}
+	}
+
+	paris, err := ts.ListTimezones("PARIS")
+	if err != nil {
+		t.Fatalf("ListTimezones error: %v", err)
+	}
+	if len(paris) != 1 || paris[0] != "Europe/Paris" {
+		t.Errorf("ListTimezones(PARIS) = %v, want [Europe/Paris]", paris)
+	}
+
+	america, _ := ts.ListTimezones("america")
+	if len(america) < 50 || len(america) >= len(all) {
+		t.Errorf("ListTimezones(america) returned %d of %d zones", len(america), len(all))
+	}
+	for _, n := range america {
+		if !strings.Contains(strings.ToLower(n), "america") {
+			t.Errorf("%s does not match filter", n)
+		}
+	}
+
+	none, err := ts.ListTimezones("atlantis")
+	if err != nil || none == nil || len(none) != 0 {
+		t.Errorf("ListTimezones(atlantis) = %v, %v; want empty list", none, err)
+	}
+}
+
+func TestValidateTimezone(t *testing.T) {
+	ts := NewTimeServer("UTC")
+	cases := []struct {
+		in        string
+		valid     bool
+		canonical string
+		needsList bool // case fixing relies on the enumerated zone list
+	}{
+		{"America/New_York", true, "America/New_York", false},
+		{"US/Eastern", true, "America/New_York", false},
+		{"us/eastern", true, "America/New_York", false},
+		{"Asia/Calcutta", true, "Asia/Kolkata", false},
+		{"america/new_york", true, "America/New_York", true},
+		{"UTC", true, "UTC", false},
+		{"Etc/UTC", true, "Etc/UTC", false},
+		{"Zulu", true, "Etc/UTC", false},
+		{"UTC+5:30", true, "UTC+05:30", false},
+		{"-03:00", true, "UTC-03:00", false},
+		{"New York", true, "America/New_York", false},
+		{"PST", true, "America/Los_Angeles", false},
+		{"Mars/Olympus_Mons", false, "", false},
+		{"UTC+25", false, "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.in, func(t *testing.T) {
+			if tc.needsList && len(zoneNames()) == 0 {
+				t.Skip("no zoneinfo tree to enumerate")
+			}
+			valid, canonical, err := ts.ValidateTimezone(tc.in)
+			if err != nil {
+				t.Fatalf("ValidateTimezone(%q) error: %v", tc.in, err)
+			}
+			if valid != tc.valid || canonical != tc.canonical {
+				t.Errorf("ValidateTimezone(%q) = %v %q, want %v %q", tc.in, valid, canonical, tc.valid, tc.canonical)
+			}
+		})
+	}
+
+	// Every alias target must itself be a loadable zone.
+	for alias, target := range zoneAliases {
+		if _, err := time.LoadLocation(target); err != nil {
+			t.Errorf("alias %s -> %s: target does not load: %v", alias, target, err)
+		}
+	}
+
+	if _, _, err := ts.ValidateTimezone("  "); err == nil {
+		t.Error("expected error for empty timezone")
+	}
+}
+
+func TestResolveZone(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"new york", "America/New_York"},
+		{"New  York", "America/New_York"},
+		{"new_york", "America/New_York"},
+		{"NYC", "America/New_York"},
+		{"EST", "America/New_York"},
+		{"pst", "America/Los_Angeles"},
+		{"P.S.T.", "America/Los_Angeles"},
+		{"Tokyo", "Asia/Tokyo"},
+		{"IST", "Asia/Kolkata"},
+		{"America/New_York", "America/New_York"},
+		{"UTC+5:30", "UTC+5:30"},
+		{"Atlantis", "Atlantis"},
+	}
+	for _, tc := range cases {
+		if got := resolveZone(tc.in); got != tc.want {
+			t.Errorf("resolveZone(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+	for key, zone := range friendlyZones {
+		if _, err := time.LoadLocation(zone); err != nil {
+			t.Errorf("friendly name %q -> %s does not load: %v", key, zone, err)
+		}
+	}
+
+	// Every entry point resolves the friendly name and reports the IANA zone.
+	ts := NewTimeServer("UTC")
+	ts.forTesting_SetNowFunc(func() time.Time { return time.Date(2025, 7, 1, 16, 0, 0, 0, time.UTC) })
+	cur, err := ts.GetCurrentTime("new york", "")
+	if err != nil {
+		t.Fatalf("GetCurrentTime(new york) error: %v", err)
+	}
+	if cur.Timezone != "America/New_York" || cur.Datetime != "2025-07-01T12:00:00-04:00" {
+		t.Errorf("GetCurrentTime(new york) = %s %s", cur.Timezone, cur.Datetime)
+	}
+	// PST in July still follows Los Angeles daylight time.
+	conv, err := ts.ConvertTime("new york", "09:00", "PST", "")
+	if err != nil {
+		t.Fatalf("ConvertTime error: %v", err)
+	}
+	if conv.Target.Timezone != "America/Los_Angeles" || conv.Target.Datetime != "2025-07-01T06:00:00-07:00" {
+		t.Errorf("ConvertTime(new york -> PST) target = %s %s", conv.Target.Timezone, conv.Target.Datetime)
+	}
+	day, err := ts.DayInfo("", "tokyo")
+	if err != nil {
+		t.Fatalf("DayInfo(tokyo) error: %v", err)
+	}
+	if day.Timezone != "Asia/Tokyo" || day.Date != "2025-07-02" {
+		t.Errorf("DayInfo(tokyo) = %s %s", day.Timezone, day.Date)
+	}
+}